@@ -196,34 +196,70 @@ func main() {
 	clientStore := store.NewClientStore()
 	documentStore := store.NewDocumentStore()
 	sessionStore := store.NewSessionStore()
+	sessionSeriesStore := store.NewSessionSeriesStore()
+	availabilityStore := store.NewAvailabilityStore()
+	calendarStore := store.NewCalendarStore()
+	externalCalendarStore := store.NewExternalCalendarStore()
+	referralStore := store.NewReferralStore()
+	jobPostingStore := store.NewJobPostingStore()
+	referralNotificationStore := store.NewReferralNotificationStore()
 	tutorReviewStore := store.NewTutorReviewStore()
 	questionStore := store.NewQuestionStore()
+	questionAnswerStore := store.NewQuestionAnswerStore()
 	companyStore := store.NewCompanyStore()
 	conferenceStore := store.NewConferenceStore()
 	reportClientStore := store.NewReportClientStore()
+	privacyStore := store.NewPrivacyStore()
+	accountStore := store.NewAccountStore()
+	tutorApplicationStore := store.NewTutorApplicationStore()
+	menteeFeedbackStore := store.NewMenteeFeedbackStore()
 	fireStoreClient := store.NewFireStore(firebaseClient, firebaseAuth)
 
 	// Usecases
 	emailerUseCase := usecases.NewEmailerUseCase()
-	authUseCase := usecases.NewAuthUseCase(*db, authStore, clientStore, fireStoreClient)
-	clientUseCase := usecases.NewClientUseCase(*db, clientStore, authStore, emailerUseCase, fireStoreClient, companyStore)
+	authUseCase := usecases.NewAuthUseCase(*db, authStore, clientStore, fireStoreClient, tutorApplicationStore)
+	referralNotificationUseCase := usecases.NewReferralNotificationUseCase(*db, referralNotificationStore, clientStore, companyStore, emailerUseCase)
+	clientUseCase := usecases.NewClientUseCase(*db, clientStore, authStore, emailerUseCase, fireStoreClient, companyStore, referralNotificationUseCase, tutorApplicationStore)
 	documentUseCase := usecases.NewDocumentUseCase(*db, documentStore)
-	sessionUseCase := usecases.NewSessionUseCase(*db, sessionStore, clientStore)
-	tutorUseCase := usecases.NewTutorReviewUseCase(db, tutorReviewStore, sessionStore, clientStore)
-	questionUseCase := usecases.NewQuestionUsesCase(db, clientStore, questionStore)
+	sessionUseCase := usecases.NewSessionUseCase(*db, sessionStore, clientStore, privacyStore)
+	sessionSeriesUseCase := usecases.NewSessionSeriesUseCase(*db, sessionSeriesStore, sessionStore, clientStore)
+	availabilityUseCase := usecases.NewAvailabilityUseCase(*db, availabilityStore, sessionStore, clientStore)
+	calendarUseCase := usecases.NewCalendarUseCase(*db, calendarStore, sessionStore, clientStore)
+	externalCalendarUseCase := usecases.NewExternalCalendarUseCase(*db, externalCalendarStore)
+	referralUseCase := usecases.NewReferralUseCase(*db, referralStore, clientStore, documentStore, tutorReviewStore, jobPostingStore)
+	jobPostingUseCase := usecases.NewJobPostingUseCase(*db, jobPostingStore, clientStore)
+	tutorUseCase := usecases.NewTutorReviewUseCase(*db, tutorReviewStore, sessionStore, clientStore)
+	questionUseCase := usecases.NewQuestionUsesCase(db, clientStore, questionStore, sessionStore, emailerUseCase)
+	questionAnswerUseCase := usecases.NewQuestionAnswerUseCase(*db, clientStore, questionStore, questionAnswerStore)
 	companyUseCase := usecases.NewCompanyUseCase(*db, companyStore)
 	conferenceUseCase := usecases.NewConferenceUseCase(db, conferenceStore, conferenceHub, conferencePublisher, fireStoreClient)
 	reportClientUseCase := usecases.NewReportClientUseCase(*db, reportClientStore)
+	privacyUseCase := usecases.NewPrivacyUseCase(*db, privacyStore)
+	accountUseCase := usecases.NewAccountUseCase(*db, accountStore, clientStore, sessionStore, fireStoreClient, tutorApplicationStore)
+	tutorApplicationUseCase := usecases.NewTutorApplicationUseCase(*db, tutorApplicationStore, clientStore, tutorReviewStore)
+	menteeFeedbackUseCase := usecases.NewMenteeFeedbackUseCase(*db, menteeFeedbackStore)
 
 	routes.RegisterAuthRoutes(e, validate, signingKey, publicKey, authUseCase)
-	routes.RegisterClientRoutes(e, validate, publicKey, clientUseCase)
+	routes.RegisterClientRoutes(e, validate, publicKey, clientUseCase, privacyUseCase)
 	routes.RegisterDocumentRoutes(e, validate, publicKey, documentUseCase)
-	routes.RegisterSessionRoutes(e, validate, publicKey, sessionUseCase, tutorUseCase)
-	routes.RegisterTutorReviewRoutes(e, validate, publicKey, tutorUseCase)
-	routes.RegisterQuestionRoutes(e, validate, publicKey, questionUseCase)
+	routes.RegisterSessionRoutes(e, validate, publicKey, sessionUseCase, tutorUseCase, privacyUseCase)
+	routes.RegisterSessionSeriesRoutes(e, validate, publicKey, sessionSeriesUseCase, sessionUseCase, privacyUseCase)
+	routes.RegisterAvailabilityRoutes(e, validate, publicKey, availabilityUseCase, privacyUseCase)
+	routes.RegisterCalendarRoutes(e, publicKey, calendarUseCase)
+	routes.RegisterExternalCalendarRoutes(e, validate, publicKey, externalCalendarUseCase)
+	routes.RegisterReferralRoutes(e, validate, publicKey, referralUseCase)
+	routes.RegisterJobPostingRoutes(e, validate, publicKey, jobPostingUseCase)
+	routes.RegisterNotificationSettingRoutes(e, validate, publicKey, referralNotificationUseCase)
+	routes.RegisterTutorReviewRoutes(e, validate, publicKey, tutorUseCase, privacyUseCase)
+	routes.RegisterQuestionRoutes(e, validate, publicKey, questionUseCase, privacyUseCase)
+	routes.RegisterQuestionAnswerRoutes(e, validate, publicKey, questionAnswerUseCase, privacyUseCase)
 	routes.RegisterCompanyRoutes(e, validate, publicKey, companyUseCase)
 	routes.RegisterConferenceRoutes(e, publicKey, apiKey, sessionUseCase, conferenceUseCase)
 	routes.RegisterReportClient(e, validate, publicKey, reportClientUseCase)
+	routes.RegisterPrivacySettingRoutes(e, validate, publicKey, privacyUseCase)
+	routes.RegisterAccountRoutes(e, publicKey, apiKey, accountUseCase)
+	routes.RegisterTutorApplicationRoutes(e, validate, publicKey, tutorApplicationUseCase)
+	routes.RegisterMenteeFeedbackRoutes(e, validate, publicKey, menteeFeedbackUseCase)
 
 	e.Validator = &Validator{validator: validate}
 	e.GET("/", func(c echo.Context) error {
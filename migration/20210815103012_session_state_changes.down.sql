@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE IF EXISTS session_state_change;
+
+ALTER TABLE tutor_session DROP CONSTRAINT IF EXISTS tutor_session_state_check;
+
+ALTER TABLE tutor_session DROP COLUMN state_reason;
+
+ALTER TABLE tutor_session DROP COLUMN state_changed_at;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE tutor_session ADD COLUMN state_changed_at TIMESTAMP;
+
+ALTER TABLE tutor_session ADD COLUMN state_reason TEXT;
+
+ALTER TABLE tutor_session
+  ADD CONSTRAINT tutor_session_state_check
+  CHECK (state IN ('pending', 'scheduled', 'completed', 'cancelled', 'no_show'));
+
+CREATE TABLE IF NOT EXISTS session_state_change (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  session_id INT REFERENCES tutor_session (id) ON DELETE CASCADE,
+  from_state VARCHAR(15) NOT NULL,
+  to_state VARCHAR(15) NOT NULL,
+  changed_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  reason TEXT
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS session_proposal_response;
+
+DROP TABLE IF EXISTS session_proposal;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS session_proposal (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  session_id INT REFERENCES tutor_session (id) ON DELETE CASCADE,
+  proposed_by UUID REFERENCES client (id) ON DELETE CASCADE,
+  start_time TIMESTAMP NOT NULL,
+  end_time TIMESTAMP NOT NULL,
+  title VARCHAR(40),
+  reason TEXT,
+  state VARCHAR(15) NOT NULL DEFAULT 'open',
+  counter_to INT REFERENCES session_proposal (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS session_proposal_response (
+  proposal_id INT REFERENCES session_proposal (id) ON DELETE CASCADE,
+  client_id UUID REFERENCES client (id) ON DELETE CASCADE,
+  accepted BOOLEAN NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (proposal_id, client_id)
+);
+
+COMMIT;
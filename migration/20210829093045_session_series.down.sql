@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tutor_session_series_id_idx;
+
+ALTER TABLE tutor_session
+  DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS session_series;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS session_series (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tutor_id UUID REFERENCES client (id) ON DELETE RESTRICT,
+  created_by UUID REFERENCES client (id) ON DELETE RESTRICT,
+  room_id VARCHAR(40) NOT NULL,
+  rrule VARCHAR(100) NOT NULL,
+  start_time TIMESTAMP NOT NULL,
+  end_time TIMESTAMP NOT NULL,
+  title VARCHAR(40),
+  state VARCHAR(15) NOT NULL DEFAULT 'active'
+);
+
+ALTER TABLE tutor_session
+  ADD COLUMN series_id INT REFERENCES session_series (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tutor_session_series_id_idx ON tutor_session (series_id);
+
+COMMIT;
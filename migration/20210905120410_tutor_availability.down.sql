@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS tutor_availability_window;
+
+DROP TABLE IF EXISTS tutor_availability;
+
+COMMIT;
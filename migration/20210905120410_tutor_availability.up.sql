@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tutor_availability (
+  tutor_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+  slot_minutes INT NOT NULL DEFAULT 60 CHECK (slot_minutes > 0),
+  buffer_before_minutes INT NOT NULL DEFAULT 0 CHECK (buffer_before_minutes >= 0),
+  buffer_after_minutes INT NOT NULL DEFAULT 0 CHECK (buffer_after_minutes >= 0),
+  max_sessions_per_day INT CHECK (max_sessions_per_day > 0)
+);
+
+CREATE TABLE IF NOT EXISTS tutor_availability_window (
+  id SERIAL PRIMARY KEY,
+  tutor_id UUID REFERENCES tutor_availability (tutor_id) ON DELETE CASCADE,
+  day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+  start_minute INT NOT NULL CHECK (start_minute >= 0),
+  end_minute INT NOT NULL CHECK (end_minute <= 1440),
+  CHECK (start_minute < end_minute)
+);
+
+CREATE INDEX IF NOT EXISTS tutor_availability_window_tutor_id_idx ON tutor_availability_window (tutor_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS external_busy_event;
+DROP TABLE IF EXISTS external_calendar;
+
+COMMIT;
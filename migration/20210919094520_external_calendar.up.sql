@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS external_calendar (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  url TEXT,
+  last_synced_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS external_busy_event (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  calendar_id INTEGER NOT NULL REFERENCES external_calendar (id) ON DELETE CASCADE,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  start_time TIMESTAMP NOT NULL,
+  end_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS external_busy_event_client_start_idx ON external_busy_event (client_id, start_time);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS referral_state_change;
+DROP TABLE IF EXISTS referral;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS referral (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  seeker_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  referrer_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  company_id INT NOT NULL REFERENCES company (id) ON DELETE CASCADE,
+  job_url TEXT NOT NULL,
+  document_id INT REFERENCES document (id) ON DELETE SET NULL,
+  message TEXT,
+  state VARCHAR(15) NOT NULL DEFAULT 'requested'
+    CHECK (state IN ('requested', 'accepted', 'declined', 'submitted', 'withdrawn')),
+  updated_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  state_changed_at TIMESTAMP,
+  state_reason TEXT,
+  CHECK (seeker_id <> referrer_id)
+);
+
+CREATE INDEX IF NOT EXISTS referral_seeker_id_idx ON referral (seeker_id);
+CREATE INDEX IF NOT EXISTS referral_referrer_id_idx ON referral (referrer_id);
+
+CREATE TABLE IF NOT EXISTS referral_state_change (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  referral_id INT NOT NULL REFERENCES referral (id) ON DELETE CASCADE,
+  from_state VARCHAR(15),
+  to_state VARCHAR(15) NOT NULL,
+  changed_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  reason TEXT
+);
+
+COMMIT;
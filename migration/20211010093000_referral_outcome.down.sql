@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS referral_company_id_idx;
+DROP TABLE IF EXISTS referral_outcome;
+
+ALTER TABLE referral
+  DROP COLUMN IF EXISTS outcome_on,
+  DROP COLUMN IF EXISTS outcome;
+
+COMMIT;
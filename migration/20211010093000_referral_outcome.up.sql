@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE referral
+  ADD COLUMN outcome VARCHAR(15) CHECK (outcome IN ('interview', 'offer', 'hired', 'rejected')),
+  ADD COLUMN outcome_on DATE;
+
+CREATE TABLE IF NOT EXISTS referral_outcome (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  referral_id INT NOT NULL REFERENCES referral (id) ON DELETE CASCADE,
+  stage VARCHAR(15) NOT NULL CHECK (stage IN ('interview', 'offer', 'hired', 'rejected')),
+  occurred_on DATE NOT NULL,
+  note TEXT
+);
+
+CREATE INDEX IF NOT EXISTS referral_outcome_referral_id_idx ON referral_outcome (referral_id);
+CREATE INDEX IF NOT EXISTS referral_company_id_idx ON referral (company_id);
+
+COMMIT;
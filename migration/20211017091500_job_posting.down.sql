@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS client_wanting_job_posting_referral;
+
+ALTER TABLE referral DROP COLUMN IF EXISTS job_posting_id;
+
+DROP TABLE IF EXISTS job_posting;
+
+COMMIT;
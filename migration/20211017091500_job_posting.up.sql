@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS job_posting (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  company_id INT NOT NULL REFERENCES company (id) ON DELETE CASCADE,
+  posted_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  title VARCHAR(200) NOT NULL,
+  location VARCHAR(200),
+  remote BOOLEAN NOT NULL DEFAULT FALSE,
+  level VARCHAR(15)
+    CHECK (level IN ('intern', 'entry', 'mid', 'senior', 'staff', 'principal', 'manager', 'director', 'executive')),
+  url TEXT NOT NULL,
+  expires_on DATE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS job_posting_company_id_idx ON job_posting (company_id);
+CREATE INDEX IF NOT EXISTS job_posting_expires_on_idx ON job_posting (expires_on);
+
+ALTER TABLE referral
+  ADD COLUMN IF NOT EXISTS job_posting_id INT REFERENCES job_posting (id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS client_wanting_job_posting_referral (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  job_posting_id INT NOT NULL REFERENCES job_posting (id) ON DELETE CASCADE,
+  UNIQUE(client_id, job_posting_id)
+);
+
+COMMIT;
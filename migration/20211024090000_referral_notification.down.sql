@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS referral_notification;
+DROP TABLE IF EXISTS notification_setting;
+
+COMMIT;
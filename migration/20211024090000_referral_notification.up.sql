@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS notification_setting (
+  client_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  referrer_available BOOLEAN NOT NULL DEFAULT TRUE,
+  pending_seekers_digest BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS referral_notification (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  kind VARCHAR(30) NOT NULL
+    CHECK (kind IN ('referrer_available', 'pending_seekers_digest')),
+  company_id INT REFERENCES company (id) ON DELETE CASCADE,
+  about_client_id UUID REFERENCES client (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS referral_notification_client_id_created_at_idx ON referral_notification (client_id, created_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS client_search_company ON company;
+DROP TRIGGER IF EXISTS client_search_client ON client;
+DROP FUNCTION IF EXISTS client_search_company_trigger();
+DROP FUNCTION IF EXISTS client_search_client_trigger();
+DROP FUNCTION IF EXISTS refresh_client_search(UUID);
+DROP TABLE IF EXISTS client_search;
+
+COMMIT;
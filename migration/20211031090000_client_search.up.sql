@@ -0,0 +1,54 @@
+BEGIN;
+
+-- The search document is kept in its own table so SELECT * FROM client is unchanged
+CREATE TABLE IF NOT EXISTS client_search (
+  client_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  document TSVECTOR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS client_search_document_idx ON client_search USING GIN (document);
+
+CREATE OR REPLACE FUNCTION refresh_client_search(target UUID) RETURNS VOID AS $$
+  INSERT INTO client_search (client_id, document)
+  SELECT
+    client.id,
+    setweight(to_tsvector('english', COALESCE(client.first_name, '') || ' ' || COALESCE(client.last_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(client.work_title, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(company.company_name, '')), 'B') ||
+    setweight(to_tsvector('english',
+      COALESCE(client.institution, '') || ' ' ||
+      COALESCE(client.degree, '') || ' ' ||
+      COALESCE(client.field_of_study, '')
+    ), 'C') ||
+    setweight(to_tsvector('english', COALESCE(client.about, '')), 'D')
+  FROM client
+  LEFT JOIN company ON company.id = client.company_id
+  WHERE client.id = target
+  ON CONFLICT (client_id) DO UPDATE SET document = EXCLUDED.document;
+$$ LANGUAGE SQL;
+
+CREATE OR REPLACE FUNCTION client_search_client_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM refresh_client_search(NEW.id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION client_search_company_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM refresh_client_search(client.id) FROM client WHERE client.company_id = NEW.id;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER client_search_client
+AFTER INSERT OR UPDATE ON client
+FOR EACH ROW EXECUTE PROCEDURE client_search_client_trigger();
+
+CREATE TRIGGER client_search_company
+AFTER UPDATE OF company_name ON company
+FOR EACH ROW EXECUTE PROCEDURE client_search_company_trigger();
+
+SELECT refresh_client_search(id) FROM client;
+
+COMMIT;
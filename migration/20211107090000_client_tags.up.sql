@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS client_tags (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+  proficiency VARCHAR(15) NOT NULL
+    CHECK (proficiency IN ('beginner', 'intermediate', 'advanced', 'expert')),
+  UNIQUE(client_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS client_tags_tag_id_idx ON client_tags (tag_id);
+
+COMMIT;
@@ -0,0 +1,53 @@
+BEGIN;
+
+ALTER TABLE client
+  ADD COLUMN IF NOT EXISTS institution VARCHAR(40),
+  ADD COLUMN IF NOT EXISTS degree VARCHAR(40),
+  ADD COLUMN IF NOT EXISTS field_of_study VARCHAR(40),
+  ADD COLUMN IF NOT EXISTS start_year SMALLINT,
+  ADD COLUMN IF NOT EXISTS end_year SMALLINT;
+
+-- Only the latest education fits back on the client
+UPDATE client
+SET
+  institution = LEFT(latest.institution, 40),
+  degree = LEFT(latest.degree, 40),
+  field_of_study = LEFT(latest.field_of_study, 40),
+  start_year = latest.start_year,
+  end_year = latest.end_year
+FROM (
+  SELECT DISTINCT ON (client_id) *
+  FROM client_education
+  ORDER BY client_id, end_year DESC NULLS FIRST, start_year DESC NULLS LAST, id DESC
+) AS latest
+WHERE latest.client_id = client.id;
+
+DROP TRIGGER IF EXISTS client_search_work_position ON work_position;
+DROP TRIGGER IF EXISTS client_search_education ON client_education;
+DROP FUNCTION IF EXISTS client_search_history_trigger();
+
+CREATE OR REPLACE FUNCTION refresh_client_search(target UUID) RETURNS VOID AS $$
+  INSERT INTO client_search (client_id, document)
+  SELECT
+    client.id,
+    setweight(to_tsvector('english', COALESCE(client.first_name, '') || ' ' || COALESCE(client.last_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(client.work_title, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(company.company_name, '')), 'B') ||
+    setweight(to_tsvector('english',
+      COALESCE(client.institution, '') || ' ' ||
+      COALESCE(client.degree, '') || ' ' ||
+      COALESCE(client.field_of_study, '')
+    ), 'C') ||
+    setweight(to_tsvector('english', COALESCE(client.about, '')), 'D')
+  FROM client
+  LEFT JOIN company ON company.id = client.company_id
+  WHERE client.id = target
+  ON CONFLICT (client_id) DO UPDATE SET document = EXCLUDED.document;
+$$ LANGUAGE SQL;
+
+DROP TABLE IF EXISTS work_position;
+DROP TABLE IF EXISTS client_education;
+
+SELECT refresh_client_search(id) FROM client;
+
+COMMIT;
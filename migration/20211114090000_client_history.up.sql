@@ -0,0 +1,97 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS client_education (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  institution VARCHAR(100) NOT NULL,
+  degree VARCHAR(100),
+  field_of_study VARCHAR(100),
+  start_year SMALLINT,
+  end_year SMALLINT,
+  CHECK (start_year IS NULL OR end_year IS NULL OR end_year >= start_year)
+);
+
+CREATE INDEX IF NOT EXISTS client_education_client_id_idx ON client_education (client_id);
+
+INSERT INTO client_education (client_id, institution, degree, field_of_study, start_year, end_year)
+SELECT id, institution, degree, field_of_study, start_year, end_year
+FROM client
+WHERE institution IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS work_position (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  company_id INT REFERENCES company (id) ON DELETE SET NULL,
+  company_name VARCHAR(100) NOT NULL,
+  title VARCHAR(100) NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE,
+  description TEXT,
+  CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS work_position_client_id_idx ON work_position (client_id);
+
+-- Education and positions are now searched from their own tables
+CREATE OR REPLACE FUNCTION refresh_client_search(target UUID) RETURNS VOID AS $$
+  INSERT INTO client_search (client_id, document)
+  SELECT
+    client.id,
+    setweight(to_tsvector('english', COALESCE(client.first_name, '') || ' ' || COALESCE(client.last_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(client.work_title, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(company.company_name, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE((
+      SELECT string_agg(work_position.title || ' ' || work_position.company_name, ' ')
+      FROM work_position
+      WHERE work_position.client_id = client.id
+    ), '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE((
+      SELECT string_agg(
+        client_education.institution || ' ' ||
+        COALESCE(client_education.degree, '') || ' ' ||
+        COALESCE(client_education.field_of_study, ''),
+        ' '
+      )
+      FROM client_education
+      WHERE client_education.client_id = client.id
+    ), '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(client.about, '')), 'D')
+  FROM client
+  LEFT JOIN company ON company.id = client.company_id
+  WHERE client.id = target
+  ON CONFLICT (client_id) DO UPDATE SET document = EXCLUDED.document;
+$$ LANGUAGE SQL;
+
+CREATE OR REPLACE FUNCTION client_search_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    PERFORM refresh_client_search(OLD.client_id);
+  ELSE
+    PERFORM refresh_client_search(NEW.client_id);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER client_search_education
+AFTER INSERT OR UPDATE OR DELETE ON client_education
+FOR EACH ROW EXECUTE PROCEDURE client_search_history_trigger();
+
+CREATE TRIGGER client_search_work_position
+AFTER INSERT OR UPDATE OR DELETE ON work_position
+FOR EACH ROW EXECUTE PROCEDURE client_search_history_trigger();
+
+ALTER TABLE client
+  DROP COLUMN IF EXISTS institution,
+  DROP COLUMN IF EXISTS degree,
+  DROP COLUMN IF EXISTS field_of_study,
+  DROP COLUMN IF EXISTS start_year,
+  DROP COLUMN IF EXISTS end_year;
+
+SELECT refresh_client_search(id) FROM client;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS privacy_setting (
+  client_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  hide_email BOOLEAN NOT NULL DEFAULT FALSE,
+  hide_work_email BOOLEAN NOT NULL DEFAULT FALSE,
+  hide_linkedin_profile BOOLEAN NOT NULL DEFAULT FALSE,
+  hidden_from_search BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Deleted clients are kept as anonymized rows so sessions, reviews and questions keep their author
+CREATE TABLE IF NOT EXISTS account_deletion (
+  client_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  delete_after TIMESTAMP NOT NULL,
+  deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS account_deletion_delete_after_idx ON account_deletion (delete_after) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tutor_application (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  state VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (state IN ('pending', 'approved', 'rejected')),
+  motivation TEXT,
+  reviewed_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  review_notes TEXT,
+  reviewed_at TIMESTAMP
+);
+
+-- A client can only wait on one application at a time
+CREATE UNIQUE INDEX IF NOT EXISTS tutor_application_pending_idx ON tutor_application (client_id) WHERE state = 'pending';
+
+CREATE INDEX IF NOT EXISTS tutor_application_state_idx ON tutor_application (state, id);
+
+-- Tutors from before applications were required keep being tutors
+INSERT INTO tutor_application (client_id, state, review_notes, reviewed_at)
+SELECT id, 'approved', 'Tutor before applications were required', CURRENT_TIMESTAMP
+FROM client
+WHERE is_tutor;
+
+COMMIT;
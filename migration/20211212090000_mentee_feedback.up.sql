@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS mentee_feedback (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  session_id INT NOT NULL REFERENCES tutor_session (id) ON DELETE CASCADE,
+  tutor_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  mentee_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  communication SMALLINT NOT NULL CHECK (communication BETWEEN 1 AND 5),
+  problem_solving SMALLINT NOT NULL CHECK (problem_solving BETWEEN 1 AND 5),
+  preparation SMALLINT NOT NULL CHECK (preparation BETWEEN 1 AND 5),
+  notes TEXT,
+  visible_to_mentee BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE (session_id, mentee_id)
+);
+
+CREATE INDEX IF NOT EXISTS mentee_feedback_mentee_id_idx ON mentee_feedback (mentee_id, id);
+
+CREATE INDEX IF NOT EXISTS mentee_feedback_tutor_id_idx ON mentee_feedback (tutor_id, id);
+
+COMMIT;
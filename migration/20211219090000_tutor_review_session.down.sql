@@ -0,0 +1,32 @@
+BEGIN;
+
+ALTER TABLE pending_tutor_review
+  DROP CONSTRAINT IF EXISTS pending_tutor_review_pkey;
+
+DELETE FROM pending_tutor_review a
+USING pending_tutor_review b
+WHERE a.mentee_id = b.mentee_id AND a.tutor_id = b.tutor_id AND a.session_id < b.session_id;
+
+ALTER TABLE pending_tutor_review
+  DROP COLUMN IF EXISTS session_id;
+
+ALTER TABLE pending_tutor_review
+  ADD PRIMARY KEY (mentee_id, tutor_id);
+
+DROP INDEX IF EXISTS tutor_review_tutor_id_idx;
+
+ALTER TABLE tutor_review
+  DROP CONSTRAINT IF EXISTS tutor_review_session_id_from_id_key;
+
+-- Only the first review of each mentee for a tutor is kept
+DELETE FROM tutor_review a
+USING tutor_review b
+WHERE a.tutor_id = b.tutor_id AND a.from_id = b.from_id AND a.id > b.id;
+
+ALTER TABLE tutor_review
+  DROP COLUMN IF EXISTS session_id;
+
+ALTER TABLE tutor_review
+  ADD CONSTRAINT tutor_review_tutor_id_from_id_key UNIQUE (tutor_id, from_id);
+
+COMMIT;
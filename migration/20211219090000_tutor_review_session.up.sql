@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Reviews from before sessions were linked keep a NULL session and are not shown as verified
+ALTER TABLE tutor_review
+  ADD COLUMN session_id INT REFERENCES tutor_session (id) ON DELETE SET NULL;
+
+ALTER TABLE tutor_review
+  DROP CONSTRAINT IF EXISTS tutor_review_tutor_id_from_id_key;
+
+ALTER TABLE tutor_review
+  ADD CONSTRAINT tutor_review_session_id_from_id_key UNIQUE (session_id, from_id);
+
+CREATE INDEX IF NOT EXISTS tutor_review_tutor_id_idx ON tutor_review (tutor_id, created_at);
+
+ALTER TABLE pending_tutor_review
+  ADD COLUMN session_id INT REFERENCES tutor_session (id) ON DELETE CASCADE;
+
+-- Pending reviews are moved to the latest session the mentee attended with the tutor
+UPDATE pending_tutor_review SET session_id = (
+  SELECT MAX(tutor_session.id) FROM tutor_session
+  JOIN session_client ON session_client.session_id = tutor_session.id
+  WHERE tutor_session.tutor_id = pending_tutor_review.tutor_id
+  AND session_client.client_id = pending_tutor_review.mentee_id
+  AND session_client.can_attend = TRUE
+  AND tutor_session.state IN ('scheduled', 'completed')
+);
+
+DELETE FROM pending_tutor_review WHERE session_id IS NULL;
+
+ALTER TABLE pending_tutor_review
+  DROP CONSTRAINT IF EXISTS pending_tutor_review_pkey;
+
+ALTER TABLE pending_tutor_review
+  ADD PRIMARY KEY (mentee_id, session_id);
+
+COMMIT;
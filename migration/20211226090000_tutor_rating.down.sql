@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS tutor_rating_review ON tutor_review;
+DROP FUNCTION IF EXISTS tutor_rating_trigger();
+DROP FUNCTION IF EXISTS add_tutor_rating(UUID, INT, TIMESTAMP, INT);
+DROP TABLE IF EXISTS tutor_rating_day;
+DROP TABLE IF EXISTS tutor_rating_star;
+DROP TABLE IF EXISTS tutor_rating;
+
+COMMIT;
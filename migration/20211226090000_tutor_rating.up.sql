@@ -0,0 +1,81 @@
+BEGIN;
+
+-- Ratings are kept up to date by triggers on tutor_review so they can be used to sort searches
+CREATE TABLE IF NOT EXISTS tutor_rating (
+  tutor_id UUID PRIMARY KEY REFERENCES client (id) ON DELETE CASCADE,
+  review_count INT NOT NULL DEFAULT 0,
+  total_stars INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS tutor_rating_star (
+  tutor_id UUID REFERENCES client (id) ON DELETE CASCADE,
+  stars SMALLINT NOT NULL,
+  review_count INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (tutor_id, stars)
+);
+
+-- Reviews per day are used to find how the rating changed recently
+CREATE TABLE IF NOT EXISTS tutor_rating_day (
+  tutor_id UUID REFERENCES client (id) ON DELETE CASCADE,
+  day DATE NOT NULL,
+  review_count INT NOT NULL DEFAULT 0,
+  total_stars INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (tutor_id, day)
+);
+
+CREATE OR REPLACE FUNCTION add_tutor_rating(tutor UUID, review_stars INT, reviewed_at TIMESTAMP, sign INT) RETURNS VOID AS $$
+  INSERT INTO tutor_rating (tutor_id, review_count, total_stars)
+  VALUES (tutor, sign, sign * review_stars)
+  ON CONFLICT (tutor_id) DO UPDATE SET
+    review_count = tutor_rating.review_count + EXCLUDED.review_count,
+    total_stars = tutor_rating.total_stars + EXCLUDED.total_stars;
+
+  INSERT INTO tutor_rating_star (tutor_id, stars, review_count)
+  VALUES (tutor, review_stars, sign)
+  ON CONFLICT (tutor_id, stars) DO UPDATE SET
+    review_count = tutor_rating_star.review_count + EXCLUDED.review_count;
+
+  INSERT INTO tutor_rating_day (tutor_id, day, review_count, total_stars)
+  VALUES (tutor, reviewed_at::date, sign, sign * review_stars)
+  ON CONFLICT (tutor_id, day) DO UPDATE SET
+    review_count = tutor_rating_day.review_count + EXCLUDED.review_count,
+    total_stars = tutor_rating_day.total_stars + EXCLUDED.total_stars;
+$$ LANGUAGE SQL;
+
+CREATE OR REPLACE FUNCTION tutor_rating_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.tutor_id IS NOT NULL THEN
+    PERFORM add_tutor_rating(OLD.tutor_id, OLD.stars, OLD.created_at, -1);
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.tutor_id IS NOT NULL THEN
+    PERFORM add_tutor_rating(NEW.tutor_id, NEW.stars, NEW.created_at, 1);
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tutor_rating_review
+AFTER INSERT OR DELETE OR UPDATE OF tutor_id, stars ON tutor_review
+FOR EACH ROW EXECUTE PROCEDURE tutor_rating_trigger();
+
+INSERT INTO tutor_rating (tutor_id, review_count, total_stars)
+SELECT tutor_id, COUNT(*), SUM(stars)
+FROM tutor_review
+WHERE tutor_id IS NOT NULL
+GROUP BY tutor_id;
+
+INSERT INTO tutor_rating_star (tutor_id, stars, review_count)
+SELECT tutor_id, stars, COUNT(*)
+FROM tutor_review
+WHERE tutor_id IS NOT NULL
+GROUP BY tutor_id, stars;
+
+INSERT INTO tutor_rating_day (tutor_id, day, review_count, total_stars)
+SELECT tutor_id, created_at::date, COUNT(*), SUM(stars)
+FROM tutor_review
+WHERE tutor_id IS NOT NULL
+GROUP BY tutor_id, created_at::date;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- Hidden reviews are counted again by the rating trigger before it stops looking at hidden_at
+UPDATE tutor_review SET hidden_at = NULL WHERE hidden_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION tutor_rating_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.tutor_id IS NOT NULL THEN
+    PERFORM add_tutor_rating(OLD.tutor_id, OLD.stars, OLD.created_at, -1);
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.tutor_id IS NOT NULL THEN
+    PERFORM add_tutor_rating(NEW.tutor_id, NEW.stars, NEW.created_at, 1);
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tutor_rating_review ON tutor_review;
+
+CREATE TRIGGER tutor_rating_review
+AFTER INSERT OR DELETE OR UPDATE OF tutor_id, stars ON tutor_review
+FOR EACH ROW EXECUTE PROCEDURE tutor_rating_trigger();
+
+DROP TABLE IF EXISTS tutor_review_revision;
+DROP TABLE IF EXISTS tutor_review_reply;
+DROP TABLE IF EXISTS tutor_review_flag;
+
+ALTER TABLE tutor_review
+  DROP COLUMN IF EXISTS hidden_by,
+  DROP COLUMN IF EXISTS hidden_at;
+
+COMMIT;
@@ -0,0 +1,62 @@
+BEGIN;
+
+ALTER TABLE tutor_review
+  ADD COLUMN hidden_at TIMESTAMP,
+  ADD COLUMN hidden_by UUID REFERENCES client (id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS tutor_review_flag (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  review_id INT NOT NULL REFERENCES tutor_review (id) ON DELETE CASCADE,
+  flagged_by UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  state VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (state IN ('open', 'upheld', 'dismissed')),
+  resolved_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  resolved_at TIMESTAMP
+);
+
+-- A client can flag a review again once their last flag is resolved
+CREATE UNIQUE INDEX IF NOT EXISTS tutor_review_flag_open_idx ON tutor_review_flag (review_id, flagged_by) WHERE state = 'open';
+
+CREATE TABLE IF NOT EXISTS tutor_review_reply (
+  review_id INT PRIMARY KEY REFERENCES tutor_review (id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tutor_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  reply TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tutor_review_revision (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  review_id INT NOT NULL REFERENCES tutor_review (id) ON DELETE CASCADE,
+  revised_by UUID REFERENCES client (id) ON DELETE SET NULL,
+  stars SMALLINT,
+  review TEXT,
+  headline VARCHAR(120)
+);
+
+CREATE INDEX IF NOT EXISTS tutor_review_revision_review_id_idx ON tutor_review_revision (review_id, id);
+
+-- Hidden reviews do not count towards the tutor's rating
+CREATE OR REPLACE FUNCTION tutor_rating_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.tutor_id IS NOT NULL AND OLD.hidden_at IS NULL THEN
+    PERFORM add_tutor_rating(OLD.tutor_id, OLD.stars, OLD.created_at, -1);
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.tutor_id IS NOT NULL AND NEW.hidden_at IS NULL THEN
+    PERFORM add_tutor_rating(NEW.tutor_id, NEW.stars, NEW.created_at, 1);
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tutor_rating_review ON tutor_review;
+
+CREATE TRIGGER tutor_rating_review
+AFTER INSERT OR DELETE OR UPDATE OF tutor_id, stars, hidden_at ON tutor_review
+FOR EACH ROW EXECUTE PROCEDURE tutor_rating_trigger();
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS question_answer_count ON question_answer;
+DROP FUNCTION IF EXISTS question_answer_count_trigger();
+
+ALTER TABLE question
+  DROP COLUMN IF EXISTS answers,
+  DROP COLUMN IF EXISTS comments;
+
+DROP TABLE IF EXISTS question_answer;
+
+COMMIT;
@@ -0,0 +1,53 @@
+BEGIN;
+
+-- Answers have no parent. Comments reply to an answer or to another comment and keep the answer they
+-- belong to in root_id, so a whole thread can be loaded at once
+CREATE TABLE IF NOT EXISTS question_answer (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  question_id INT NOT NULL REFERENCES question (id) ON DELETE CASCADE,
+  parent_id INT REFERENCES question_answer (id) ON DELETE CASCADE,
+  root_id INT REFERENCES question_answer (id) ON DELETE CASCADE,
+  from_id UUID NOT NULL REFERENCES client (id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  accepted BOOLEAN NOT NULL DEFAULT FALSE,
+  CHECK ((parent_id IS NULL) = (root_id IS NULL)),
+  CHECK (NOT accepted OR parent_id IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS question_answer_question_id_idx ON question_answer (question_id, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS question_answer_root_id_idx ON question_answer (root_id, id);
+
+-- A question has at most one accepted answer
+CREATE UNIQUE INDEX IF NOT EXISTS question_answer_accepted_idx ON question_answer (question_id) WHERE accepted;
+
+ALTER TABLE question
+  ADD COLUMN answers INT NOT NULL DEFAULT 0,
+  ADD COLUMN comments INT NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION question_answer_count_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    UPDATE question
+    SET
+      answers = answers + (CASE WHEN NEW.parent_id IS NULL THEN 1 ELSE 0 END),
+      comments = comments + (CASE WHEN NEW.parent_id IS NULL THEN 0 ELSE 1 END)
+    WHERE id = NEW.question_id;
+  ELSE
+    UPDATE question
+    SET
+      answers = answers - (CASE WHEN OLD.parent_id IS NULL THEN 1 ELSE 0 END),
+      comments = comments - (CASE WHEN OLD.parent_id IS NULL THEN 0 ELSE 1 END)
+    WHERE id = OLD.question_id;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER question_answer_count
+AFTER INSERT OR DELETE ON question_answer
+FOR EACH ROW EXECUTE PROCEDURE question_answer_count_trigger();
+
+COMMIT;
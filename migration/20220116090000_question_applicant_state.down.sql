@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tutor_session_question_id_idx;
+
+ALTER TABLE tutor_session
+  DROP COLUMN IF EXISTS question_id;
+
+DROP INDEX IF EXISTS question_applicants_accepted_idx;
+
+ALTER TABLE question_applicants
+  DROP COLUMN IF EXISTS created_at,
+  DROP COLUMN IF EXISTS updated_at,
+  DROP COLUMN IF EXISTS state,
+  DROP COLUMN IF EXISTS session_id;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- The author of the question shortlists, declines or accepts each applicant. Accepting one creates a
+-- pending session with them
+ALTER TABLE question_applicants
+  ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN state VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (state IN ('applied', 'shortlisted', 'declined', 'accepted')),
+  ADD COLUMN session_id INT REFERENCES tutor_session (id) ON DELETE SET NULL;
+
+-- A question has at most one accepted applicant
+CREATE UNIQUE INDEX IF NOT EXISTS question_applicants_accepted_idx ON question_applicants (question_id) WHERE state = 'accepted';
+
+ALTER TABLE tutor_session
+  ADD COLUMN question_id INT REFERENCES question (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tutor_session_question_id_idx ON tutor_session (question_id);
+
+COMMIT;
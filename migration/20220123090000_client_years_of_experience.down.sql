@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP FUNCTION IF EXISTS client_years_of_experience(UUID);
+
+COMMIT;
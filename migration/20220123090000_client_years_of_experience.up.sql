@@ -0,0 +1,35 @@
+BEGIN;
+
+-- Years of experience of the client up to today from their positions, time in overlapping positions is
+-- only counted once. Clients without positions have none
+CREATE OR REPLACE FUNCTION client_years_of_experience(target UUID) RETURNS INT AS $$
+  WITH span AS (
+    SELECT start_date, LEAST(COALESCE(end_date, CURRENT_DATE), CURRENT_DATE) AS end_date
+    FROM work_position
+    WHERE client_id = target
+  ), ordered AS (
+    SELECT
+      start_date,
+      end_date,
+      MAX(end_date) OVER (ORDER BY start_date, end_date ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS previous_end
+    FROM span
+    WHERE start_date < end_date
+  ), island AS (
+    SELECT
+      start_date,
+      end_date,
+      COUNT(*) FILTER (WHERE previous_end IS NULL OR start_date > previous_end) OVER (ORDER BY start_date, end_date) AS island_id
+    FROM ordered
+  ), merged AS (
+    SELECT MIN(start_date) AS start_date, MAX(end_date) AS end_date
+    FROM island
+    GROUP BY island_id
+  )
+  SELECT CASE
+    WHEN EXISTS (SELECT 1 FROM work_position WHERE client_id = target)
+    THEN COALESCE(FLOOR(SUM(end_date - start_date) / 365.25)::INT, 0)
+  END
+  FROM merged;
+$$ LANGUAGE SQL STABLE;
+
+COMMIT;
@@ -0,0 +1,100 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// AccountDeletionGracePeriod is how long a client can cancel the deletion of their account
+const AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// Names given to clients once their account is deleted
+const (
+	DeletedClientFirstName = "Deleted"
+	DeletedClientLastName  = "User"
+)
+
+// AccountDeletionReason is the reason recorded on sessions cancelled by an account deletion
+const AccountDeletionReason = "Account was deleted"
+
+var (
+	ErrAccountDeletionScheduled = errors.New("Account deletion is already scheduled")
+	ErrAccountDeleted           = errors.New("Account is deleted")
+)
+
+// AccountDeletion is a client asking for their account to be deleted. The account is anonymized
+// once DeleteAfter passes, DeletedAt is set when that is done
+type AccountDeletion struct {
+	ClientID    string    `db:"client_id" json:"clientId"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	DeleteAfter time.Time `db:"delete_after" json:"deleteAfter"`
+	DeletedAt   null.Time `db:"deleted_at" json:"deletedAt"`
+}
+
+// NewAccountDeletion creates new AccountDeletion that can be cancelled until the grace period is over
+func NewAccountDeletion(clientID string, now time.Time) *AccountDeletion {
+	return &AccountDeletion{
+		ClientID:    clientID,
+		DeleteAfter: now.Add(AccountDeletionGracePeriod),
+	}
+}
+
+// AuthMetadata is what a client can see of the ways they log in
+type AuthMetadata struct {
+	CreatedAt  time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time   `db:"updated_at" json:"updatedAt"`
+	AuthType   null.String `db:"auth_type" json:"authType"`
+	Email      null.String `db:"email" json:"email"`
+	SignUpFlow SignUpFlow  `db:"sign_up_flow" json:"signUpStage"`
+	Blocked    bool        `db:"blocked" json:"blocked"`
+}
+
+// AccountExport bundles the personal data of a client
+type AccountExport struct {
+	ExportedAt        time.Time          `json:"exportedAt"`
+	Profile           Client             `json:"profile"`
+	Auth              []AuthMetadata     `json:"auth"`
+	Educations        []Education        `json:"educations"`
+	WorkPositions     []WorkPosition     `json:"workPositions"`
+	Tags              []ClientTag        `json:"tags"`
+	Documents         []Document         `json:"documents"`
+	Sessions          []Session          `json:"sessions"`
+	Events            []Event            `json:"events"`
+	Questions         []Question         `json:"questions"`
+	QuestionAnswers   []QuestionAnswer   `json:"questionAnswers"`
+	ReviewsWritten    []TutorReview      `json:"reviewsWritten"`
+	ReviewsReceived   []TutorReview      `json:"reviewsReceived"`
+	ReportsMade       []ReportClient     `json:"reportsMade"`
+	TutorApplications []TutorApplication `json:"tutorApplications"`
+	FeedbackGiven     []MenteeFeedback   `json:"feedbackGiven"`
+	FeedbackReceived  []MenteeFeedback   `json:"feedbackReceived"`
+}
+
+type AccountStore interface {
+	CreateAccountDeletion(db DB, deletion *AccountDeletion) (*AccountDeletion, error)
+	GetAccountDeletion(db DB, clientID string) (*AccountDeletion, error)
+	DeleteAccountDeletion(db DB, clientID string) error
+	GetDueAccountDeletions(db DB, now time.Time) ([]string, error)
+	GetAuthMetadata(db DB, clientID string) (*[]AuthMetadata, error)
+	GetClientDocuments(db DB, clientID string) (*[]Document, error)
+	GetClientSessions(db DB, clientID string) (*[]Session, error)
+	GetClientQuestions(db DB, clientID string) (*[]Question, error)
+	GetClientQuestionAnswers(db DB, clientID string) (*[]QuestionAnswer, error)
+	GetReviewsWritten(db DB, clientID string) (*[]TutorReview, error)
+	GetReviewsReceived(db DB, clientID string) (*[]TutorReview, error)
+	GetReportsMade(db DB, clientID string) (*[]ReportClient, error)
+	GetMenteeFeedbackGiven(db DB, clientID string) (*[]MenteeFeedback, error)
+	GetMenteeFeedbackReceived(db DB, clientID string) (*[]MenteeFeedback, error)
+	GetCancellableSessionIDs(db DB, clientID string, now time.Time) ([]int, error)
+	AnonymizeClient(db DB, clientID string) error
+}
+
+type AccountUseCase interface {
+	ExportAccount(clientID string) (*AccountExport, error)
+	GetAccountDeletion(clientID string) (*AccountDeletion, error)
+	ScheduleAccountDeletion(clientID string) (*AccountDeletion, error)
+	CancelAccountDeletion(clientID string) error
+	ProcessAccountDeletions() (int, error)
+}
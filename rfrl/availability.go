@@ -0,0 +1,288 @@
+package rfrl
+
+import (
+	"sort"
+	"time"
+
+	// Bundles the timezone database so tutor timezones load in slim containers
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+const minutesInDay = 24 * 60
+
+// MaxSlotSearchDays limits how far a single slot search can look ahead
+const MaxSlotSearchDays = 31
+
+// FreeSlotStep is the granularity of candidate slots when looking for a common free time
+const FreeSlotStep = 30 * time.Minute
+
+// Common free slots are only suggested between these local hours of every participant
+const (
+	SociableDayStartHour = 8
+	SociableDayEndHour   = 22
+)
+
+var ErrSlotNotAvailable = errors.New("Slot is not available")
+
+// TutorAvailability holds how a tutor wants their weekly availability to be booked
+type TutorAvailability struct {
+	TutorID             string               `db:"tutor_id" json:"tutorId"`
+	CreatedAt           time.Time            `db:"created_at" json:"createdAt"`
+	UpdatedAt           time.Time            `db:"updated_at" json:"updatedAt"`
+	Timezone            string               `db:"timezone" json:"timezone"`
+	SlotMinutes         int                  `db:"slot_minutes" json:"slotMinutes"`
+	BufferBeforeMinutes int                  `db:"buffer_before_minutes" json:"bufferBeforeMinutes"`
+	BufferAfterMinutes  int                  `db:"buffer_after_minutes" json:"bufferAfterMinutes"`
+	MaxSessionsPerDay   null.Int             `db:"max_sessions_per_day" json:"maxSessionsPerDay"`
+	Windows             []AvailabilityWindow `json:"windows"`
+}
+
+// AvailabilityWindow is a weekly recurring range, in minutes from midnight in the tutor's timezone
+type AvailabilityWindow struct {
+	ID          int    `db:"id" json:"id"`
+	TutorID     string `db:"tutor_id" json:"tutorId"`
+	DayOfWeek   int    `db:"day_of_week" json:"dayOfWeek"`
+	StartMinute int    `db:"start_minute" json:"startMinute"`
+	EndMinute   int    `db:"end_minute" json:"endMinute"`
+}
+
+// BookableSlot is a free range that can be booked with a tutor
+type BookableSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// NewTutorAvailability creates new TutorAvailability
+func NewTutorAvailability(
+	tutorID string,
+	timezone string,
+	slotMinutes int,
+	bufferBeforeMinutes int,
+	bufferAfterMinutes int,
+	maxSessionsPerDay null.Int,
+) *TutorAvailability {
+	return &TutorAvailability{
+		TutorID:             tutorID,
+		Timezone:            timezone,
+		SlotMinutes:         slotMinutes,
+		BufferBeforeMinutes: bufferBeforeMinutes,
+		BufferAfterMinutes:  bufferAfterMinutes,
+		MaxSessionsPerDay:   maxSessionsPerDay,
+	}
+}
+
+// NewAvailabilityWindow creates new AvailabilityWindow
+func NewAvailabilityWindow(dayOfWeek int, startMinute int, endMinute int) (*AvailabilityWindow, error) {
+	if dayOfWeek < int(time.Sunday) || dayOfWeek > int(time.Saturday) {
+		return nil, errors.Errorf("Day of week (%d) has to be between 0 and 6", dayOfWeek)
+	}
+
+	if startMinute < 0 || endMinute > minutesInDay || startMinute >= endMinute {
+		return nil, errors.Errorf("Availability window (%d-%d) is not a valid range within a day", startMinute, endMinute)
+	}
+
+	return &AvailabilityWindow{
+		DayOfWeek:   dayOfWeek,
+		StartMinute: startMinute,
+		EndMinute:   endMinute,
+	}, nil
+}
+
+func overlapsWithBuffer(events []Event, start time.Time, end time.Time, before time.Duration, after time.Duration) bool {
+	for i := 0; i < len(events); i++ {
+		if events[i].StartTime.Before(end.Add(after)) && events[i].EndTime.After(start.Add(-before)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeBookableSlots walks the tutor's weekly windows between start and end and
+// returns every slot that does not run into a busy event or a full day
+func (ta TutorAvailability) ComputeBookableSlots(
+	start time.Time,
+	end time.Time,
+	now time.Time,
+	busy []Event,
+	tutorSessions []Event,
+) ([]BookableSlot, error) {
+	slots := make([]BookableSlot, 0)
+
+	if ta.SlotMinutes <= 0 {
+		return slots, errors.New("Slot length has to be positive")
+	}
+
+	location, err := time.LoadLocation(ta.Timezone)
+
+	if err != nil {
+		return slots, errors.Wrap(err, "ComputeBookableSlots")
+	}
+
+	sessionsPerDay := make(map[string]int64)
+
+	for i := 0; i < len(tutorSessions); i++ {
+		sessionsPerDay[tutorSessions[i].StartTime.In(location).Format("2006-01-02")]++
+	}
+
+	windowsPerDay := make(map[int][]AvailabilityWindow)
+
+	for i := 0; i < len(ta.Windows); i++ {
+		window := ta.Windows[i]
+		windowsPerDay[window.DayOfWeek] = append(windowsPerDay[window.DayOfWeek], window)
+	}
+
+	for day := range windowsPerDay {
+		windows := windowsPerDay[day]
+		sort.Slice(windows, func(i, j int) bool {
+			return windows[i].StartMinute < windows[j].StartMinute
+		})
+	}
+
+	slotLength := time.Duration(ta.SlotMinutes) * time.Minute
+	before := time.Duration(ta.BufferBeforeMinutes) * time.Minute
+	after := time.Duration(ta.BufferAfterMinutes) * time.Minute
+
+	localStart := start.In(location)
+	day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, location)
+
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if ta.MaxSessionsPerDay.Valid && sessionsPerDay[day.Format("2006-01-02")] >= ta.MaxSessionsPerDay.Int64 {
+			continue
+		}
+
+		windows := windowsPerDay[int(day.Weekday())]
+
+		for i := 0; i < len(windows); i++ {
+			for minute := windows[i].StartMinute; minute+ta.SlotMinutes <= windows[i].EndMinute; minute += ta.SlotMinutes {
+				slotStart := wallClockTime(day, minute)
+				slotEnd := slotStart.Add(slotLength)
+
+				if slotStart.Before(start) || slotStart.Before(now) || slotEnd.After(end) {
+					continue
+				}
+
+				if overlapsWithBuffer(busy, slotStart, slotEnd, before, after) {
+					continue
+				}
+
+				slots = append(slots, BookableSlot{Start: slotStart.UTC(), End: slotEnd.UTC()})
+			}
+		}
+	}
+
+	return slots, nil
+}
+
+// wallClockTime is the time minute minutes into day on the clock of day's location, which is not
+// day.Add on days clocks change
+func wallClockTime(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+// CandidateSlot is a range where every requested client is free, scored by the
+// minutes of breathing room it leaves around their other events
+type CandidateSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Score int       `json:"score"`
+}
+
+// maxBreathingRoom caps how much free time around a slot counts towards its score
+const maxBreathingRoom = 2 * time.Hour
+
+func breathingRoom(events []Event, start time.Time, end time.Time) time.Duration {
+	room := maxBreathingRoom
+
+	for i := 0; i < len(events); i++ {
+		if !events[i].EndTime.After(start) && start.Sub(events[i].EndTime) < room {
+			room = start.Sub(events[i].EndTime)
+		}
+
+		if !events[i].StartTime.Before(end) && events[i].StartTime.Sub(end) < room {
+			room = events[i].StartTime.Sub(end)
+		}
+	}
+
+	return room
+}
+
+// withinSociableHours checks that the slot falls within the local day of every location
+func withinSociableHours(locations []*time.Location, start time.Time, end time.Time) bool {
+	for i := 0; i < len(locations); i++ {
+		localStart := start.In(locations[i])
+		dayStart := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), SociableDayStartHour, 0, 0, 0, locations[i])
+		dayEnd := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), SociableDayEndHour, 0, 0, 0, locations[i])
+
+		if localStart.Before(dayStart) || end.After(dayEnd) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindCommonFreeSlots steps through the range and returns the best free slots that fall
+// within sociable hours of every location, preferring the ones furthest from other events
+// and then the earliest ones
+func FindCommonFreeSlots(
+	busy []Event,
+	locations []*time.Location,
+	start time.Time,
+	end time.Time,
+	duration time.Duration,
+	step time.Duration,
+	limit int,
+) []CandidateSlot {
+	slots := make([]CandidateSlot, 0)
+
+	slotStart := start.Truncate(step)
+
+	if slotStart.Before(start) {
+		slotStart = slotStart.Add(step)
+	}
+
+	for ; !slotStart.Add(duration).After(end); slotStart = slotStart.Add(step) {
+		slotEnd := slotStart.Add(duration)
+
+		if overlapsWithBuffer(busy, slotStart, slotEnd, 0, 0) || !withinSociableHours(locations, slotStart, slotEnd) {
+			continue
+		}
+
+		slots = append(slots, CandidateSlot{
+			Start: slotStart.UTC(),
+			End:   slotEnd.UTC(),
+			Score: int(breathingRoom(busy, slotStart, slotEnd).Minutes()),
+		})
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		if slots[i].Score != slots[j].Score {
+			return slots[i].Score > slots[j].Score
+		}
+		return slots[i].Start.Before(slots[j].Start)
+	})
+
+	if len(slots) > limit {
+		slots = slots[:limit]
+	}
+
+	return slots
+}
+
+type AvailabilityStore interface {
+	GetTutorAvailability(db DB, tutorID string) (*TutorAvailability, error)
+	GetTutorAvailabilityForUpdate(db DB, tutorID string) (*TutorAvailability, error)
+	UpsertTutorAvailability(db DB, availability *TutorAvailability) (*TutorAvailability, error)
+	DeleteAvailabilityWindows(db DB, tutorID string) error
+	CreateAvailabilityWindows(db DB, tutorID string, windows []AvailabilityWindow) (*[]AvailabilityWindow, error)
+	GetTutorSessionEvents(db DB, tutorID string, start time.Time, end time.Time) (*[]Event, error)
+}
+
+type AvailabilityUseCase interface {
+	SetTutorAvailability(tutorID string, availability TutorAvailability, windows []AvailabilityWindow) (*TutorAvailability, error)
+	GetTutorAvailability(tutorID string) (*TutorAvailability, error)
+	GetBookableSlots(tutorID string, clientID string, start time.Time, end time.Time) ([]BookableSlot, error)
+	BookTutorSlot(clientID string, tutorID string, roomID string, start time.Time, title string) (*Session, error)
+}
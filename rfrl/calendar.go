@@ -0,0 +1,180 @@
+package rfrl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// CalendarAppURL is the frontend url that calendar events link back to
+var CalendarAppURL string = os.Getenv("APP_URL")
+
+const icsTimeLayout = "20060102T150405Z"
+
+// icsLineLimit is the maximum octets per content line before folding (RFC 5545 3.1)
+const icsLineLimit = 75
+
+// CalendarFeed holds the secret a client uses to subscribe to their sessions
+type CalendarFeed struct {
+	ClientID  string    `db:"client_id" json:"clientId"`
+	Secret    string    `db:"secret" json:"secret"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// CalendarEntry is an event with the session it belongs to, if any
+type CalendarEntry struct {
+	Event   Event
+	Session *Session
+}
+
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(text)
+}
+
+// foldICSLine splits long content lines without breaking multi-byte characters
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line + "\r\n"
+	}
+
+	var folded strings.Builder
+	lineLength := 0
+
+	for _, r := range line {
+		runeLength := len(string(r))
+
+		if lineLength+runeLength > icsLineLimit {
+			folded.WriteString("\r\n ")
+			lineLength = 1
+		}
+
+		folded.WriteRune(r)
+		lineLength += runeLength
+	}
+
+	folded.WriteString("\r\n")
+
+	return folded.String()
+}
+
+func sessionICSStatus(state SessionState) string {
+	switch state {
+	case SCHEDULED, COMPLETED:
+		return "CONFIRMED"
+	case CANCELLED, NO_SHOW:
+		return "CANCELLED"
+	default:
+		return "TENTATIVE"
+	}
+}
+
+// SessionConferenceURL is the link participants use to join the session
+func SessionConferenceURL(sessionID int) string {
+	return fmt.Sprintf("%s/session/%d/conference", strings.TrimRight(CalendarAppURL, "/"), sessionID)
+}
+
+func writeCalendarEntry(lines []string, entry CalendarEntry, now time.Time) []string {
+	event := entry.Event
+	summary := event.Title.String
+
+	if summary == "" {
+		summary = "rfrl event"
+	}
+
+	lines = append(lines,
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:event-%d@rfrl.ca", event.ID),
+		"DTSTAMP:"+now.UTC().Format(icsTimeLayout),
+		"DTSTART:"+event.StartTime.UTC().Format(icsTimeLayout),
+		"DTEND:"+event.EndTime.UTC().Format(icsTimeLayout),
+		"SUMMARY:"+escapeICSText(summary),
+	)
+
+	if entry.Session == nil {
+		return append(lines, "TRANSP:OPAQUE", "END:VEVENT")
+	}
+
+	session := entry.Session
+	conferenceURL := SessionConferenceURL(session.ID)
+	// Participants are only listed by name since anyone with the link of the feed can read it
+	participants := make([]string, 0, len(session.Clients))
+
+	for i := 0; i < len(session.Clients); i++ {
+		participants = append(participants, session.Clients[i].DisplayName())
+	}
+
+	description := fmt.Sprintf(
+		"Participants: %s\nJoin: %s",
+		strings.Join(participants, ", "),
+		conferenceURL,
+	)
+
+	lines = append(lines,
+		"DESCRIPTION:"+escapeICSText(description),
+		"URL:"+conferenceURL,
+		"STATUS:"+sessionICSStatus(session.State),
+	)
+
+	if session.State == PENDING || session.State == SCHEDULED {
+		for _, trigger := range []string{"-PT1H", "-PT10M"} {
+			lines = append(lines,
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"TRIGGER:"+trigger,
+				"DESCRIPTION:"+escapeICSText(summary),
+				"END:VALARM",
+			)
+		}
+	}
+
+	return append(lines, "END:VEVENT")
+}
+
+// BuildICalendar renders entries as an RFC 5545 calendar
+func BuildICalendar(name string, entries []CalendarEntry, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//rfrl//sessions//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escapeICSText(name),
+	}
+
+	for i := 0; i < len(entries); i++ {
+		lines = writeCalendarEntry(lines, entries[i], now)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	var calendar strings.Builder
+
+	for i := 0; i < len(lines); i++ {
+		calendar.WriteString(foldICSLine(lines[i]))
+	}
+
+	return calendar.String()
+}
+
+type CalendarStore interface {
+	GetCalendarFeed(db DB, clientID string) (*CalendarFeed, error)
+	GetCalendarFeedBySecret(db DB, secret string) (*CalendarFeed, error)
+	UpsertCalendarFeed(db DB, clientID string, secret string) (*CalendarFeed, error)
+	GetSessionsByEventIDs(db DB, clientID string, eventIDs []int) (*[]Session, error)
+}
+
+type CalendarUseCase interface {
+	GetCalendarFeed(clientID string) (*CalendarFeed, error)
+	RotateCalendarFeed(clientID string) (*CalendarFeed, error)
+	GetCalendarFeedICS(secret string) (string, error)
+	GetSessionICS(clientID string, sessionID int) (string, error)
+}
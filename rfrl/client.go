@@ -1,6 +1,7 @@
 package rfrl
 
 import (
+	"strings"
 	"time"
 
 	"gopkg.in/guregu/null.v4"
@@ -11,26 +12,6 @@ const (
 	UserEmail = "user"
 )
 
-// Education model
-type Education struct {
-	Institution     null.String `db:"institution" json:"institution"`
-	Degree          null.String `db:"degree" json:"degree"`
-	FieldOfStudy    null.String `db:"field_of_study" json:"fieldOfStudy"`
-	StartYear       null.Int    `db:"start_year" json:"startYear"`
-	EndYear         null.Int    `db:"end_year" json:"endYear"`
-	InstitutionLogo null.String
-}
-
-func NewEducation(institution string, degree string, fieldOfStudy string, startYear int, endYear int) Education {
-	return Education{
-		Institution:  null.NewString(institution, institution != ""),
-		FieldOfStudy: null.NewString(fieldOfStudy, fieldOfStudy != ""),
-		Degree:       null.NewString(degree, degree != ""),
-		StartYear:    null.NewInt(int64(startYear), startYear != 0),
-		EndYear:      null.NewInt(int64(endYear), endYear != 0),
-	}
-}
-
 // Client model
 type Client struct {
 	ID                   string      `db:"id" json:"id"`
@@ -52,7 +33,7 @@ type Client struct {
 	GithubProfile        null.String `db:"github_profile" json:"githubProfile"`
 	YearsOfExperience    null.Int    `db:"years_of_experience" json:"yearsOfExperience"`
 	WorkTitle            null.String `db:"work_title" json:"workTitle"`
-	Education
+	Timezone             null.String `db:"timezone" json:"timezone"`
 }
 
 // NewClient creates new client model struct
@@ -65,44 +46,122 @@ func NewClient(
 	isTutor null.Bool,
 	linkedInProfile string,
 	githubProfile string,
-	yearsOfExperience null.Int,
 	workTitle string,
+	timezone string,
 ) *Client {
 	client := Client{
-		FirstName:         null.NewString(firstName, firstName != ""),
-		LastName:          null.NewString(lastName, lastName != ""),
-		About:             null.NewString(about, about != ""),
-		Email:             null.NewString(email, email != ""),
-		Photo:             null.NewString(photo, photo != ""),
-		IsTutor:           isTutor,
-		LinkedInProfile:   null.NewString(linkedInProfile, linkedInProfile != ""),
-		GithubProfile:     null.NewString(githubProfile, githubProfile != ""),
-		YearsOfExperience: yearsOfExperience,
-		WorkTitle:         null.NewString(workTitle, workTitle != ""),
+		FirstName:       null.NewString(firstName, firstName != ""),
+		LastName:        null.NewString(lastName, lastName != ""),
+		About:           null.NewString(about, about != ""),
+		Email:           null.NewString(email, email != ""),
+		Photo:           null.NewString(photo, photo != ""),
+		IsTutor:         isTutor,
+		LinkedInProfile: null.NewString(linkedInProfile, linkedInProfile != ""),
+		GithubProfile:   null.NewString(githubProfile, githubProfile != ""),
+		WorkTitle:       null.NewString(workTitle, workTitle != ""),
+		Timezone:        null.NewString(timezone, timezone != ""),
 	}
 
 	return &client
 }
 
+// DisplayName is how the client is named to other members, clients without a name are still shown as someone
+func (c Client) DisplayName() string {
+	name := strings.TrimSpace(c.FirstName.String + " " + c.LastName.String)
+
+	if name == "" {
+		return "An rfrl member"
+	}
+
+	return name
+}
+
+// Proficiencies are the levels a client can claim in a tag, from lowest to highest
+var Proficiencies = []string{
+	"beginner",
+	"intermediate",
+	"advanced",
+	"expert",
+}
+
+// ProficienciesFrom returns the proficiencies at or above min
+func ProficienciesFrom(min string) []string {
+	for i, proficiency := range Proficiencies {
+		if proficiency == min {
+			return Proficiencies[i:]
+		}
+	}
+
+	return []string{}
+}
+
+// ClientTag is a tag a client declared expertise in
+type ClientTag struct {
+	Tags
+	Proficiency string `db:"proficiency" json:"proficiency"`
+}
+
+// NewClientTag creates new ClientTag
+func NewClientTag(tagID int, proficiency string) ClientTag {
+	return ClientTag{
+		Tags:        Tags{ID: tagID},
+		Proficiency: proficiency,
+	}
+}
+
 type GetClientsOptions struct {
-	IsTutor                  null.Bool
-	CompanyIds               []int
-	WantingReferralCompanyId null.Int
-	LastTutor                null.String
-	ExcludeClients           []string
+	IsTutor                     null.Bool
+	CompanyIds                  []int
+	WantingReferralCompanyId    null.Int
+	WantingReferralJobPostingId null.Int
+	TagIds                      []int
+	MinProficiency              null.String
+	ExcludeClients              []string
+	IncludeHiddenFromSearch     bool
+}
+
+const (
+	SEARCH_SORT_RELEVANCE = "relevance"
+	SEARCH_SORT_RATING    = "rating"
+	SEARCH_SORT_NEWEST    = "newest"
+)
+
+// SearchClientsOptions holds the full-text query and filters used to search clients
+type SearchClientsOptions struct {
+	Query                   null.String
+	IsTutor                 null.Bool
+	VerifiedWorkEmail       null.Bool
+	CompanyIds              []int
+	Institution             null.String
+	MinYearsOfExperience    null.Int
+	MaxYearsOfExperience    null.Int
+	TagIds                  []int
+	MinProficiency          null.String
+	Sort                    string
+	ExcludeClients          []string
+	IncludeHiddenFromSearch bool
+}
+
+// ClientSearchResult is a client matched by a search with how well it matched and its tutor rating
+type ClientSearchResult struct {
+	Client
+	Rank           float64    `db:"rank" json:"rank"`
+	AverageRating  null.Float `db:"average_rating" json:"averageRating"`
+	BayesianRating null.Float `db:"bayesian_rating" json:"bayesianRating"`
+	ReviewCount    int        `db:"review_count" json:"reviewCount"`
 }
 
 type UpdateClientPayload struct {
-	FirstName         string
-	LastName          string
-	About             string
-	Email             string
-	Photo             string
-	IsTutor           null.Bool
-	LinkedInProfile   string
-	GithubProfile     string
-	YearsOfExperience null.Int
-	WorkTitle         string
+	FirstName       string
+	LastName        string
+	About           string
+	Email           string
+	Photo           string
+	IsTutor         null.Bool
+	LinkedInProfile string
+	GithubProfile   string
+	WorkTitle       string
+	Timezone        string
 }
 
 type ClientStore interface {
@@ -110,29 +169,55 @@ type ClientStore interface {
 	CreateClient(db DB, client *Client) (*Client, error)
 	UpdateClient(db DB, ID string, client *Client) (*Client, error)
 	GetClientFromIDs(db DB, ID []string) (*[]Client, error)
-	GetClients(db DB, options GetClientsOptions) (*[]Client, error)
+	GetClients(db DB, options GetClientsOptions, page PageOptions) (*[]Client, *Cursor, error)
+	SearchClients(db DB, options SearchClientsOptions, page PageOptions) (*[]ClientSearchResult, *Cursor, error)
 	CreateEmailVerification(db DB, clientID string, email string, emailType string, passCode string) error
 	VerifyEmail(db DB, clientID string, email string, emailType string, passCode string) error
 	GetVerificationEmail(db DB, clientID string, emailType string) (string, error)
 	DeleteVerificationEmail(db DB, clientID string, emailType string) error
 	GetRelatedEventsByClientIDs(db DB, clientIDs []string, start null.Time, end null.Time, state null.String) (*[]Event, error)
-	CheckOverlapingEventsByClientIDs(db DB, clientIDs []string, events *[]Event) (bool, error)
-	CreateOrUpdateClientEducation(db DB, clientID string, education Education) error
+	GetRelatedEventsPageByClientIDs(db DB, clientIDs []string, start null.Time, end null.Time, state null.String, page PageOptions) (*[]Event, *Cursor, error)
+	GetOverlapingEventsByClientIDs(db DB, clientIDs []string, events *[]Event, excludeSessionIDs []int) (*[]EventConflict, error)
+	GetExternalBusyEventsByClientIDs(db DB, clientIDs []string, start time.Time, end time.Time) (*[]Event, error)
+	CreateEducation(db DB, education *Education) (*Education, error)
+	GetEducations(db DB, clientID string) (*[]Education, error)
+	UpdateEducation(db DB, education *Education) (*Education, error)
+	DeleteEducation(db DB, clientID string, ID int) error
+	CreateWorkPosition(db DB, position *WorkPosition) (*WorkPosition, error)
+	GetWorkPositions(db DB, clientID string) (*[]WorkPosition, error)
+	UpdateWorkPosition(db DB, position *WorkPosition) (*WorkPosition, error)
+	DeleteWorkPosition(db DB, clientID string, ID int) error
+	UpdateYearsOfExperience(db DB, clientID string, years null.Int) error
 	CreateClientWantingCompanyReferrals(db DB, clientID string, companyIDs []int) error
 	GetClientWantingCompanyReferrals(db DB, clientID string) ([]int, error)
+	CreateClientWantingJobPostingReferrals(db DB, clientID string, jobPostingIDs []int) error
+	GetClientWantingJobPostingReferrals(db DB, clientID string) ([]int, error)
+	SetClientTags(db DB, clientID string, tags []ClientTag) error
+	GetClientTags(db DB, clientID string) (*[]ClientTag, error)
 }
 
 type ClientUseCase interface {
 	CreateClient(firstName string, lastName string, about string, email string, photo string, isTutor null.Bool) (*Client, error)
 	UpdateClient(id string, updateParams UpdateClientPayload) (*Client, error)
 	GetClient(id string) (*Client, error)
-	GetClients(options GetClientsOptions) (*[]Client, error)
+	GetClients(options GetClientsOptions, page PageOptions) (*[]Client, *Cursor, error)
+	SearchClients(options SearchClientsOptions, page PageOptions) (*[]ClientSearchResult, *Cursor, error)
 	CreateEmailVerification(clientID string, email string, emailType string) error
 	VerifyEmail(clientID string, email string, emailType string, passCode string) (*Client, error)
 	GetVerificationEmail(clientID string, emailType string) (string, error)
 	DeleteVerificationEmail(clientID string, emailType string) error
-	GetClientEvents(clientID string, start null.Time, end null.Time, state null.String) (*[]Event, error)
-	CreateOrUpdateClientEducation(clientID string, institution string, degree string, fieldOfStudy string, startYear int, endYear int) error
-	CreateClientWantingCompanyReferrals(clientID string, IsLookingForReferral bool, companyIds []int) error
+	GetClientEvents(clientID string, start null.Time, end null.Time, state null.String, page PageOptions) (*[]Event, *Cursor, error)
+	CreateEducation(education *Education) (*Education, error)
+	GetEducations(clientID string) (*[]Education, error)
+	UpdateEducation(education *Education) (*Education, error)
+	DeleteEducation(clientID string, ID int) error
+	CreateWorkPosition(position *WorkPosition) (*WorkPosition, error)
+	GetWorkPositions(clientID string) (*[]WorkPosition, error)
+	UpdateWorkPosition(position *WorkPosition) (*WorkPosition, error)
+	DeleteWorkPosition(clientID string, ID int) error
+	CreateClientWantingCompanyReferrals(clientID string, IsLookingForReferral bool, companyIds []int, jobPostingIds []int) error
 	GetClientWantingCompanyReferrals(clientId string) ([]int, error)
+	GetClientWantingJobPostingReferrals(clientId string) ([]int, error)
+	SetClientTags(clientID string, tags []ClientTag) (*[]ClientTag, error)
+	GetClientTags(clientID string) (*[]ClientTag, error)
 }
@@ -0,0 +1,122 @@
+package rfrl
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+var ErrWorkPositionCompanyNotFound = errors.New("Company of the work position is not found")
+
+// Education is one school a client went to
+type Education struct {
+	ID              int         `db:"id" json:"id"`
+	CreatedAt       time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time   `db:"updated_at" json:"updatedAt"`
+	ClientID        string      `db:"client_id" json:"clientId"`
+	Institution     string      `db:"institution" json:"institution"`
+	Degree          null.String `db:"degree" json:"degree"`
+	FieldOfStudy    null.String `db:"field_of_study" json:"fieldOfStudy"`
+	StartYear       null.Int    `db:"start_year" json:"startYear"`
+	EndYear         null.Int    `db:"end_year" json:"endYear"`
+	InstitutionLogo null.String
+}
+
+// NewEducation creates new Education, years that are 0 are unknown
+func NewEducation(clientID string, institution string, degree string, fieldOfStudy string, startYear int, endYear int) *Education {
+	return &Education{
+		ClientID:     clientID,
+		Institution:  institution,
+		FieldOfStudy: null.NewString(fieldOfStudy, fieldOfStudy != ""),
+		Degree:       null.NewString(degree, degree != ""),
+		StartYear:    null.NewInt(int64(startYear), startYear != 0),
+		EndYear:      null.NewInt(int64(endYear), endYear != 0),
+	}
+}
+
+// WorkPosition is a role a client held at a company. Positions without an end date are current
+type WorkPosition struct {
+	ID          int         `db:"id" json:"id"`
+	CreatedAt   time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time   `db:"updated_at" json:"updatedAt"`
+	ClientID    string      `db:"client_id" json:"clientId"`
+	CompanyID   null.Int    `db:"company_id" json:"companyId"`
+	CompanyName string      `db:"company_name" json:"companyName"`
+	Title       string      `db:"title" json:"title"`
+	StartDate   time.Time   `db:"start_date" json:"startDate"`
+	EndDate     null.Time   `db:"end_date" json:"endDate"`
+	Description null.String `db:"description" json:"description"`
+}
+
+// NewWorkPosition creates new WorkPosition
+func NewWorkPosition(
+	clientID string,
+	companyID null.Int,
+	companyName string,
+	title string,
+	startDate time.Time,
+	endDate null.Time,
+	description string,
+) *WorkPosition {
+	return &WorkPosition{
+		ClientID:    clientID,
+		CompanyID:   companyID,
+		CompanyName: companyName,
+		Title:       title,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Description: null.NewString(description, description != ""),
+	}
+}
+
+// YearsOfExperience adds up the time spent in positions up to now in whole years.
+// Time in overlapping positions is only counted once
+func YearsOfExperience(positions []WorkPosition, now time.Time) int {
+	type span struct {
+		start time.Time
+		end   time.Time
+	}
+
+	spans := make([]span, 0)
+
+	for _, position := range positions {
+		end := now
+
+		if position.EndDate.Valid && position.EndDate.Time.Before(now) {
+			end = position.EndDate.Time
+		}
+
+		if position.StartDate.Before(end) {
+			spans = append(spans, span{position.StartDate, end})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	var total time.Duration
+	var current *span
+
+	for i := range spans {
+		if current != nil && !spans[i].start.After(current.end) {
+			if spans[i].end.After(current.end) {
+				current.end = spans[i].end
+			}
+			continue
+		}
+
+		if current != nil {
+			total += current.end.Sub(current.start)
+		}
+		current = &spans[i]
+	}
+
+	if current != nil {
+		total += current.end.Sub(current.start)
+	}
+
+	return int(total.Hours() / 24 / 365.25)
+}
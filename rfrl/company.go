@@ -37,9 +37,9 @@ type CompanyUseCase interface {
 	UpdateCompany(id int, name null.String, photo null.String, industry null.String, about null.String, active null.Bool) (*Company, error)
 	CreateCompany(name string, photo null.String, industry null.String, about null.String, active null.Bool) (*Company, error)
 	UpdateCompanyEmail(name null.String, emailDomain string, active bool) error
-	GetCompanies(active bool) (*[]Company, error)
+	GetCompanies(active bool, page PageOptions) (*[]Company, *Cursor, error)
 	GetCompany(id int) (*Company, error)
-	GetCompanyEmails(withCompany null.Bool) (*[]CompanyEmailDomain, error)
+	GetCompanyEmails(withCompany null.Bool, page PageOptions) (*[]CompanyEmailDomain, *Cursor, error)
 	GetCompanyEmail(companyEmail string) (*CompanyEmailDomain, error)
 }
 
@@ -50,9 +50,9 @@ type CompanyStore interface {
 	CreateCompany(db DB, company Company) (*Company, error)
 	UpdateCompany(db DB, company Company) (*Company, error)
 	UpdateOrCreateCompanyEmail(db DB, name null.String, emailDomain string, active bool) error
-	GetCompanies(db DB, active bool) (*[]Company, error)
+	GetCompanies(db DB, active bool, page PageOptions) (*[]Company, *Cursor, error)
 	GetCompanyIDFromEmailDomain(db DB, domain string) (null.Int, error)
 	GetCompany(db DB, id int) (*Company, error)
-	GetCompanyEmails(db DB, withCompany null.Bool) (*[]CompanyEmailDomain, error)
+	GetCompanyEmails(db DB, withCompany null.Bool, page PageOptions) (*[]CompanyEmailDomain, *Cursor, error)
 	GetCompanyEmail(db DB, companyEmail string) (*CompanyEmailDomain, error)
 }
@@ -18,4 +18,5 @@ var EmailTypeToEmailAttributes = map[string]VerificationEmailTypeAttributes{
 
 type EmailerUseCase interface {
 	SendEmailVerification(email string, emailType string) (string, error)
+	SendNotification(email string, subject string, heading string, description string, lines []string) error
 }
@@ -0,0 +1,496 @@
+package rfrl
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// ExternalBusyHorizon is how far ahead imported calendars are expanded
+const ExternalBusyHorizon = 180 * 24 * time.Hour
+
+// maxExpandedOccurrences caps how many busy blocks a single recurring event can create
+const maxExpandedOccurrences = 500
+
+// MaxExternalCalendarSize caps how many bytes of an .ics file are read
+const MaxExternalCalendarSize = 2 << 20
+
+// ErrExternalCalendarFetch hides why a linked calendar could not be fetched so the url cannot be used
+// to probe other hosts
+var ErrExternalCalendarFetch = errors.New("Calendar could not be fetched")
+
+// ExternalCalendar is an uploaded or linked calendar whose events block a client's time
+type ExternalCalendar struct {
+	ID           int         `db:"id" json:"id"`
+	CreatedAt    time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time   `db:"updated_at" json:"updatedAt"`
+	ClientID     string      `db:"client_id" json:"clientId"`
+	Name         string      `db:"name" json:"name"`
+	URL          null.String `db:"url" json:"url"`
+	LastSyncedAt null.Time   `db:"last_synced_at" json:"lastSyncedAt"`
+	BusyCount    int         `db:"busy_count" json:"busyCount"`
+}
+
+// ExternalBusyEvent is a busy block imported from an external calendar, it never keeps
+// the original title or description
+type ExternalBusyEvent struct {
+	StartTime time.Time `db:"start_time" json:"start"`
+	EndTime   time.Time `db:"end_time" json:"end"`
+}
+
+// NewExternalCalendar creates new ExternalCalendar
+func NewExternalCalendar(clientID string, name string, url string) *ExternalCalendar {
+	return &ExternalCalendar{
+		ClientID: clientID,
+		Name:     name,
+		URL:      null.NewString(url, url != ""),
+	}
+}
+
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icsEvent is a VEVENT, RecurrenceID is set on events replacing one occurrence of the recurring event
+// with the same UID
+type icsEvent struct {
+	UID          string
+	RecurrenceID null.Time
+	Start        time.Time
+	End          null.Time
+	Duration     time.Duration
+	AllDay       bool
+	Rule         string
+	ExDates      map[time.Time]bool
+	Transparent  bool
+	Cancelled    bool
+}
+
+// unfoldICSLines joins folded content lines (RFC 5545 3.1)
+func unfoldICSLines(reader io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), MaxExternalCalendarSize)
+	lines := make([]string, 0)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, errors.Wrap(scanner.Err(), "unfoldICSLines")
+}
+
+func parseICSProperty(line string) (icsProperty, bool) {
+	colon := strings.Index(line, ":")
+
+	if colon < 0 {
+		return icsProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	property := icsProperty{
+		Name:   strings.ToUpper(parts[0]),
+		Params: make(map[string]string),
+		Value:  line[colon+1:],
+	}
+
+	for _, param := range parts[1:] {
+		keyValue := strings.SplitN(param, "=", 2)
+
+		if len(keyValue) == 2 {
+			property.Params[strings.ToUpper(keyValue[0])] = strings.Trim(keyValue[1], `"`)
+		}
+	}
+
+	return property, true
+}
+
+// parseICSTime handles UTC, TZID-local, floating and date only values
+func parseICSTime(property icsProperty) (time.Time, bool, error) {
+	value := property.Value
+	location := time.UTC
+
+	if tzid, ok := property.Params["TZID"]; ok {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	if property.Params["VALUE"] == "DATE" || len(value) == 8 {
+		date, err := time.ParseInLocation("20060102", value, location)
+		return date, true, errors.Wrap(err, "parseICSTime")
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		utc, err := time.Parse("20060102T150405Z", value)
+		return utc, false, errors.Wrap(err, "parseICSTime")
+	}
+
+	local, err := time.ParseInLocation("20060102T150405", value, location)
+
+	return local, false, errors.Wrap(err, "parseICSTime")
+}
+
+// parseICSDuration handles the dur-value grammar, e.g. PT1H30M or P1D
+func parseICSDuration(value string) (time.Duration, error) {
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimLeft(value, "+-")
+
+	if !strings.HasPrefix(value, "P") {
+		return 0, errors.Errorf("Invalid duration (%s)", value)
+	}
+
+	var duration time.Duration
+	number := ""
+	inTime := false
+
+	for _, r := range value[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		amount, err := strconv.Atoi(number)
+
+		if err != nil {
+			return 0, errors.Wrap(err, "parseICSDuration")
+		}
+
+		number = ""
+
+		switch {
+		case r == 'W':
+			duration += time.Duration(amount) * 7 * 24 * time.Hour
+		case r == 'D':
+			duration += time.Duration(amount) * 24 * time.Hour
+		case r == 'H' && inTime:
+			duration += time.Duration(amount) * time.Hour
+		case r == 'M' && inTime:
+			duration += time.Duration(amount) * time.Minute
+		case r == 'S' && inTime:
+			duration += time.Duration(amount) * time.Second
+		default:
+			return 0, errors.Errorf("Invalid duration (%s)", value)
+		}
+	}
+
+	if negative {
+		duration = -duration
+	}
+
+	return duration, nil
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// expandICSRule lists the starts of a recurring event between from and until, supporting
+// FREQ, INTERVAL, COUNT, UNTIL and BYDAY on weekly rules
+func expandICSRule(start time.Time, rule string, from time.Time, until time.Time) ([]time.Time, error) {
+	frequency := ""
+	interval := 1
+	count := -1
+	byDay := make([]time.Weekday, 0)
+
+	for _, part := range strings.Split(rule, ";") {
+		keyValue := strings.SplitN(part, "=", 2)
+
+		if len(keyValue) != 2 {
+			continue
+		}
+
+		switch strings.ToUpper(keyValue[0]) {
+		case "FREQ":
+			frequency = strings.ToUpper(keyValue[1])
+		case "INTERVAL":
+			parsed, err := strconv.Atoi(keyValue[1])
+
+			if err != nil || parsed < 1 {
+				return nil, errors.Errorf("Invalid recurrence interval (%s)", keyValue[1])
+			}
+			interval = parsed
+		case "COUNT":
+			parsed, err := strconv.Atoi(keyValue[1])
+
+			if err != nil {
+				return nil, errors.Wrap(err, "expandICSRule")
+			}
+			count = parsed
+		case "UNTIL":
+			parsed, _, err := parseICSTime(icsProperty{Value: keyValue[1], Params: map[string]string{}})
+
+			if err != nil {
+				return nil, err
+			}
+
+			if parsed.Before(until) {
+				until = parsed
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(keyValue[1], ",") {
+				if len(day) < 2 {
+					continue
+				}
+
+				// Ordinal prefixes like 1MO are only meaningful for monthly rules which keep the start weekday
+				weekday, ok := icsWeekdays[strings.ToUpper(day[len(day)-2:])]
+
+				if ok {
+					byDay = append(byDay, weekday)
+				}
+			}
+		}
+	}
+
+	starts := make([]time.Time, 0)
+	add := func(occurrence time.Time) bool {
+		if occurrence.Before(start) {
+			return true
+		}
+
+		if occurrence.After(until) || count == 0 || len(starts) >= maxExpandedOccurrences {
+			return false
+		}
+
+		if count > 0 {
+			count--
+		}
+
+		// Past occurrences still use up COUNT but are not kept
+		if !occurrence.Before(from) {
+			starts = append(starts, occurrence)
+		}
+
+		return true
+	}
+
+	for period := 0; ; period++ {
+		var next time.Time
+
+		switch frequency {
+		case "DAILY":
+			next = start.AddDate(0, 0, period*interval)
+		case "WEEKLY":
+			next = start.AddDate(0, 0, 7*period*interval)
+		case "MONTHLY":
+			next = start.AddDate(0, period*interval, 0)
+		case "YEARLY":
+			next = start.AddDate(period*interval, 0, 0)
+		default:
+			return nil, errors.Errorf("Unsupported recurrence frequency (%s)", frequency)
+		}
+
+		if next.After(until) || count == 0 || len(starts) >= maxExpandedOccurrences {
+			break
+		}
+
+		if frequency != "WEEKLY" || len(byDay) == 0 {
+			add(next)
+			continue
+		}
+
+		weekStart := next.AddDate(0, 0, -int(next.Weekday()))
+		sort.Slice(byDay, func(i, j int) bool { return byDay[i] < byDay[j] })
+
+		for _, weekday := range byDay {
+			if !add(weekStart.AddDate(0, 0, int(weekday))) {
+				break
+			}
+		}
+	}
+
+	return starts, nil
+}
+
+func (event icsEvent) busyBlocks(from time.Time, until time.Time) ([]ExternalBusyEvent, error) {
+	duration := event.Duration
+
+	if event.End.Valid {
+		duration = event.End.Time.Sub(event.Start)
+	}
+
+	if duration <= 0 {
+		if !event.AllDay {
+			return nil, nil
+		}
+		duration = 24 * time.Hour
+	}
+
+	starts := []time.Time{event.Start}
+
+	if event.Rule != "" {
+		expanded, err := expandICSRule(event.Start, event.Rule, from.Add(-duration), until)
+
+		if err != nil {
+			return nil, err
+		}
+		starts = expanded
+	}
+
+	blocks := make([]ExternalBusyEvent, 0, len(starts))
+
+	for _, start := range starts {
+		end := start.Add(duration)
+
+		if event.ExDates[start.UTC()] || !end.After(from) || start.After(until) {
+			continue
+		}
+
+		blocks = append(blocks, ExternalBusyEvent{StartTime: start.UTC(), EndTime: end.UTC()})
+	}
+
+	return blocks, nil
+}
+
+// ParseExternalBusyEvents reads an iCalendar file and returns the busy blocks between from and until
+func ParseExternalBusyEvents(reader io.Reader, from time.Time, until time.Time) ([]ExternalBusyEvent, error) {
+	lines, err := unfoldICSLines(io.LimitReader(reader, MaxExternalCalendarSize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]icsEvent, 0)
+	var current *icsEvent
+	foundCalendar := false
+
+	for _, line := range lines {
+		property, ok := parseICSProperty(line)
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case property.Name == "BEGIN" && strings.EqualFold(property.Value, "VCALENDAR"):
+			foundCalendar = true
+		case property.Name == "BEGIN" && strings.EqualFold(property.Value, "VEVENT"):
+			current = &icsEvent{ExDates: make(map[time.Time]bool)}
+		case property.Name == "END" && strings.EqualFold(property.Value, "VEVENT") && current != nil:
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case property.Name == "UID":
+			current.UID = property.Value
+		case property.Name == "RECURRENCE-ID":
+			var recurrenceID time.Time
+			recurrenceID, _, err = parseICSTime(property)
+			current.RecurrenceID = null.TimeFrom(recurrenceID)
+		case property.Name == "DTSTART":
+			current.Start, current.AllDay, err = parseICSTime(property)
+		case property.Name == "DTEND":
+			var end time.Time
+			end, _, err = parseICSTime(property)
+			current.End = null.TimeFrom(end)
+		case property.Name == "DURATION":
+			current.Duration, err = parseICSDuration(property.Value)
+		case property.Name == "RRULE":
+			current.Rule = property.Value
+		case property.Name == "EXDATE":
+			for _, value := range strings.Split(property.Value, ",") {
+				exDate, _, exErr := parseICSTime(icsProperty{Value: value, Params: property.Params})
+
+				if exErr != nil {
+					err = exErr
+					break
+				}
+				current.ExDates[exDate.UTC()] = true
+			}
+		case property.Name == "TRANSP":
+			current.Transparent = strings.EqualFold(property.Value, "TRANSPARENT")
+		case property.Name == "STATUS":
+			current.Cancelled = strings.EqualFold(property.Value, "CANCELLED")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !foundCalendar {
+		return nil, errors.New("File is not an iCalendar file")
+	}
+
+	return icsBusyBlocks(events, from, until), nil
+}
+
+// icsBusyBlocks expands the events into busy blocks. Occurrences replaced by another event through its
+// RECURRENCE-ID are left out of their recurring event, and recurring events whose rule cannot be
+// expanded are skipped instead of failing the whole calendar
+func icsBusyBlocks(events []icsEvent, from time.Time, until time.Time) []ExternalBusyEvent {
+	overridden := make(map[string][]time.Time)
+
+	for _, event := range events {
+		if event.UID != "" && event.RecurrenceID.Valid {
+			overridden[event.UID] = append(overridden[event.UID], event.RecurrenceID.Time.UTC())
+		}
+	}
+
+	blocks := make([]ExternalBusyEvent, 0)
+
+	for _, event := range events {
+		if event.Transparent || event.Cancelled || event.Start.IsZero() {
+			continue
+		}
+
+		if event.Rule != "" && !event.RecurrenceID.Valid {
+			for _, recurrenceID := range overridden[event.UID] {
+				event.ExDates[recurrenceID] = true
+			}
+		}
+
+		eventBlocks, err := event.busyBlocks(from, until)
+
+		if err != nil {
+			log.Errorj(log.JSON{"error": err.Error(), "uid": event.UID, "rule": event.Rule})
+			continue
+		}
+
+		blocks = append(blocks, eventBlocks...)
+	}
+
+	return blocks
+}
+
+type ExternalCalendarStore interface {
+	CreateExternalCalendar(db DB, calendar *ExternalCalendar) (*ExternalCalendar, error)
+	GetExternalCalendars(db DB, clientID string) (*[]ExternalCalendar, error)
+	GetExternalCalendar(db DB, clientID string, ID int) (*ExternalCalendar, error)
+	DeleteExternalCalendar(db DB, clientID string, ID int) error
+	MarkExternalCalendarSynced(db DB, ID int) (*ExternalCalendar, error)
+	ReplaceExternalBusyEvents(db DB, calendar ExternalCalendar, events []ExternalBusyEvent) error
+}
+
+type ExternalCalendarUseCase interface {
+	ImportExternalCalendar(clientID string, name string, url string, file io.Reader) (*ExternalCalendar, error)
+	SyncExternalCalendar(clientID string, ID int) (*ExternalCalendar, error)
+	GetExternalCalendars(clientID string) (*[]ExternalCalendar, error)
+	DeleteExternalCalendar(clientID string, ID int) error
+}
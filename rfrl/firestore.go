@@ -8,4 +8,5 @@ type FireStoreClient interface {
 	UpdateClient(id string, photo null.String, firstName null.String, lastName null.String) error
 	UpdateCode(sessionID int, codeID int, result string) error
 	CreateCode(sessionID int, codeID int) error
+	DeleteClient(id string) error
 }
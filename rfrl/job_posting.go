@@ -0,0 +1,124 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// JobPostingLevels are the seniority levels a posting can be tagged with
+var JobPostingLevels = []string{
+	"intern",
+	"entry",
+	"mid",
+	"senior",
+	"staff",
+	"principal",
+	"manager",
+	"director",
+	"executive",
+}
+
+var (
+	ErrJobPostingNotAllowed   = errors.New("Only verified employees of the company can manage its job postings")
+	ErrJobPostingNotPoster    = errors.New("Only the client who posted the job posting can manage it")
+	ErrJobPostingExpired      = errors.New("Job posting has expired")
+	ErrJobPostingWrongCompany = errors.New("Job posting does not belong to this company")
+)
+
+// JobPosting is an opening at a company that seekers can ask to be referred for
+type JobPosting struct {
+	ID        int         `db:"id" json:"id"`
+	CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time   `db:"updated_at" json:"updatedAt"`
+	CompanyID int         `db:"company_id" json:"companyId"`
+	PostedBy  null.String `db:"posted_by" json:"postedBy"`
+	Title     string      `db:"title" json:"title"`
+	Location  null.String `db:"location" json:"location"`
+	Remote    bool        `db:"remote" json:"remote"`
+	Level     null.String `db:"level" json:"level"`
+	URL       string      `db:"url" json:"url"`
+	ExpiresOn time.Time   `db:"expires_on" json:"expiresOn"`
+}
+
+// NewJobPosting creates new JobPosting
+func NewJobPosting(
+	companyID int,
+	postedBy string,
+	title string,
+	location string,
+	remote bool,
+	level string,
+	url string,
+	expiresOn time.Time,
+) *JobPosting {
+	return &JobPosting{
+		CompanyID: companyID,
+		PostedBy:  null.NewString(postedBy, postedBy != ""),
+		Title:     title,
+		Location:  null.NewString(location, location != ""),
+		Remote:    remote,
+		Level:     null.NewString(level, level != ""),
+		URL:       url,
+		ExpiresOn: expiresOn,
+	}
+}
+
+// IsExpired checks if the posting stopped taking referrals before now, postings last the whole expiry day
+func (jp JobPosting) IsExpired(now time.Time) bool {
+	return !now.Before(jp.ExpiresOn.AddDate(0, 0, 1))
+}
+
+// CanPost checks that the client works at the posting's company, admins can post for every company
+func (jp JobPosting) CanPost(client Client, admin bool) error {
+	if admin {
+		return nil
+	}
+
+	if !client.VerifiedWorkEmail.Valid || !client.VerifiedWorkEmail.Bool ||
+		!client.CompanyID.Valid || client.CompanyID.Int64 != int64(jp.CompanyID) {
+		return ErrJobPostingNotAllowed
+	}
+
+	return nil
+}
+
+// CanManage checks that the client posted the posting, admins can manage every posting
+func (jp JobPosting) CanManage(clientID string, admin bool) error {
+	if admin {
+		return nil
+	}
+
+	if !jp.PostedBy.Valid || jp.PostedBy.String != clientID {
+		return ErrJobPostingNotPoster
+	}
+
+	return nil
+}
+
+// GetJobPostingsOptions filters searched job postings
+type GetJobPostingsOptions struct {
+	CompanyIDs     []int
+	Title          null.String
+	Location       null.String
+	Remote         null.Bool
+	Level          null.String
+	IncludeExpired bool
+}
+
+type JobPostingStore interface {
+	CreateJobPosting(db DB, posting *JobPosting) (*JobPosting, error)
+	GetJobPosting(db DB, ID int) (*JobPosting, error)
+	GetJobPostings(db DB, options GetJobPostingsOptions, page PageOptions) (*[]JobPosting, *Cursor, error)
+	UpdateJobPosting(db DB, posting *JobPosting) (*JobPosting, error)
+	DeleteJobPosting(db DB, ID int) error
+}
+
+type JobPostingUseCase interface {
+	CreateJobPosting(clientID string, admin bool, posting *JobPosting) (*JobPosting, error)
+	GetJobPosting(ID int) (*JobPosting, error)
+	GetJobPostings(options GetJobPostingsOptions, page PageOptions) (*[]JobPosting, *Cursor, error)
+	UpdateJobPosting(clientID string, admin bool, posting *JobPosting) (*JobPosting, error)
+	DeleteJobPosting(clientID string, admin bool, ID int) error
+}
@@ -0,0 +1,82 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrMenteeFeedbackNotAllowed = errors.New("Feedback can only be given by the tutor of a completed session to its mentees")
+	ErrMenteeFeedbackExists     = errors.New("Feedback was already given to this mentee for this session")
+)
+
+// MenteeFeedback is what a tutor thought of a mentee during a session. It is private to the tutor
+// unless VisibleToMentee is set
+type MenteeFeedback struct {
+	ID              int         `db:"id" json:"id"`
+	CreatedAt       time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time   `db:"updated_at" json:"updatedAt"`
+	SessionID       int         `db:"session_id" json:"sessionId"`
+	TutorID         string      `db:"tutor_id" json:"tutorId"`
+	MenteeID        string      `db:"mentee_id" json:"menteeId"`
+	Communication   int         `db:"communication" json:"communication"`
+	ProblemSolving  int         `db:"problem_solving" json:"problemSolving"`
+	Preparation     int         `db:"preparation" json:"preparation"`
+	Notes           null.String `db:"notes" json:"notes"`
+	VisibleToMentee bool        `db:"visible_to_mentee" json:"visibleToMentee"`
+}
+
+// NewMenteeFeedback creates new MenteeFeedback
+func NewMenteeFeedback(
+	sessionID int,
+	tutorID string,
+	menteeID string,
+	communication int,
+	problemSolving int,
+	preparation int,
+	notes string,
+	visibleToMentee bool,
+) *MenteeFeedback {
+	return &MenteeFeedback{
+		SessionID:       sessionID,
+		TutorID:         tutorID,
+		MenteeID:        menteeID,
+		Communication:   communication,
+		ProblemSolving:  problemSolving,
+		Preparation:     preparation,
+		Notes:           null.NewString(notes, notes != ""),
+		VisibleToMentee: visibleToMentee,
+	}
+}
+
+// CanBeSeenBy checks if the client wrote the feedback or is the mentee it was shared with
+func (mf MenteeFeedback) CanBeSeenBy(clientID string) bool {
+	return mf.TutorID == clientID || (mf.MenteeID == clientID && mf.VisibleToMentee)
+}
+
+// GetMenteeFeedbacksOptions filters listed feedback
+type GetMenteeFeedbacksOptions struct {
+	TutorID     null.String
+	MenteeID    null.String
+	VisibleOnly bool
+}
+
+type MenteeFeedbackStore interface {
+	CreateMenteeFeedback(db DB, feedback *MenteeFeedback) (*MenteeFeedback, error)
+	GetMenteeFeedback(db DB, ID int) (*MenteeFeedback, error)
+	UpdateMenteeFeedback(db DB, feedback *MenteeFeedback) (*MenteeFeedback, error)
+	DeleteMenteeFeedback(db DB, ID int) error
+	GetMenteeFeedbacks(db DB, options GetMenteeFeedbacksOptions, page PageOptions) (*[]MenteeFeedback, *Cursor, error)
+	CheckMenteeAttendedSession(db DB, sessionID int, tutorID string, menteeID string) (bool, error)
+	CheckMenteeFeedbackExists(db DB, sessionID int, menteeID string) (bool, error)
+}
+
+type MenteeFeedbackUseCase interface {
+	CreateMenteeFeedback(feedback *MenteeFeedback) (*MenteeFeedback, error)
+	UpdateMenteeFeedback(clientID string, feedback *MenteeFeedback) (*MenteeFeedback, error)
+	DeleteMenteeFeedback(clientID string, ID int) error
+	GetMenteeFeedback(clientID string, ID int) (*MenteeFeedback, error)
+	GetMenteeFeedbacks(options GetMenteeFeedbacksOptions, page PageOptions) (*[]MenteeFeedback, *Cursor, error)
+}
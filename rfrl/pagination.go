@@ -0,0 +1,174 @@
+package rfrl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("Cursor is not valid")
+
+// Cursor points right after the last item of a page. Key is the value of the sort key and ID
+// breaks ties between items with the same key. Lists ranked by computed values use Offset instead
+type Cursor struct {
+	Key    string `json:"k,omitempty"`
+	ID     string `json:"i,omitempty"`
+	Offset int    `json:"o,omitempty"`
+}
+
+// NewIDCursor creates a cursor for lists only sorted by a unique id
+func NewIDCursor(ID string) *Cursor {
+	return &Cursor{ID: ID}
+}
+
+// NewSerialIDCursor creates a cursor for lists only sorted by a serial id
+func NewSerialIDCursor(ID int) *Cursor {
+	return &Cursor{ID: strconv.Itoa(ID)}
+}
+
+// NewTimeCursor creates a cursor for lists sorted by a time and then by id
+func NewTimeCursor(key time.Time, ID int) *Cursor {
+	return &Cursor{Key: key.Format(time.RFC3339Nano), ID: strconv.Itoa(ID)}
+}
+
+// NewTimeUUIDCursor creates a cursor for lists sorted by a time and then by a uuid
+func NewTimeUUIDCursor(key time.Time, ID string) *Cursor {
+	return &Cursor{Key: key.Format(time.RFC3339Nano), ID: ID}
+}
+
+// Encode makes the cursor opaque to clients
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor reads a cursor made by Encode
+func DecodeCursor(cursor string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+
+	if err = json.Unmarshal(b, &c); err != nil || c.Offset < 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// IntID reads the tie breaking id of tables with serial ids
+func (c Cursor) IntID() (int, error) {
+	ID, err := strconv.Atoi(c.ID)
+
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	return ID, nil
+}
+
+// UUID reads the tie breaking id of tables with uuid ids
+func (c Cursor) UUID() (string, error) {
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return "", ErrInvalidCursor
+	}
+
+	return c.ID, nil
+}
+
+// TimeKey reads the key of a cursor made by NewTimeCursor
+func (c Cursor) TimeKey() (time.Time, error) {
+	key, err := time.Parse(time.RFC3339Nano, c.Key)
+
+	if err != nil {
+		return key, ErrInvalidCursor
+	}
+
+	return key, nil
+}
+
+// PageOptions is the page a list endpoint is asked for, After is nil for the first page
+type PageOptions struct {
+	After *Cursor
+	Size  int
+}
+
+// NewPageOptions decodes the cursor and keeps the size between 1 and MaxPageSize
+func NewPageOptions(cursor string, size int) (PageOptions, error) {
+	page := PageOptions{Size: size}
+
+	if size <= 0 {
+		page.Size = DefaultPageSize
+	} else if size > MaxPageSize {
+		page.Size = MaxPageSize
+	}
+
+	if cursor == "" {
+		return page, nil
+	}
+
+	after, err := DecodeCursor(cursor)
+
+	if err != nil {
+		return page, err
+	}
+
+	page.After = after
+
+	return page, nil
+}
+
+// Limit fetches one more item than the page size to know if there is a next page
+func (p PageOptions) Limit() uint64 {
+	return uint64(p.Size + 1)
+}
+
+// HasNext checks if a query limited by Limit found more items than fit in the page
+func (p PageOptions) HasNext(count int) bool {
+	return count > p.Size
+}
+
+// Offset is where a page of an offset paginated list starts
+func (p PageOptions) Offset() uint64 {
+	if p.After == nil {
+		return 0
+	}
+
+	return uint64(p.After.Offset)
+}
+
+// NextOffset creates the cursor of the page after this one for offset paginated lists
+func (p PageOptions) NextOffset() *Cursor {
+	return &Cursor{Offset: int(p.Offset()) + p.Size}
+}
+
+// Page is the response of every list endpoint, NextCursor is null on the last page
+type Page struct {
+	Results    interface{} `json:"results"`
+	NextCursor null.String `json:"nextCursor"`
+}
+
+// NewPage creates new Page
+func NewPage(results interface{}, next *Cursor) Page {
+	page := Page{Results: results}
+
+	if next != nil {
+		page.NextCursor = null.StringFrom(next.Encode())
+	}
+
+	return page
+}
@@ -0,0 +1,95 @@
+package rfrl
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// Relationships a viewer can have with the client whose profile they are viewing
+const (
+	RELATIONSHIP_SELF        = "self"
+	RELATIONSHIP_ADMIN       = "admin"
+	RELATIONSHIP_PARTICIPANT = "participant"
+	RELATIONSHIP_STRANGER    = "stranger"
+)
+
+// PrivacySetting holds what a client hides from others, clients without one hide nothing
+type PrivacySetting struct {
+	ClientID            string    `db:"client_id" json:"clientId"`
+	UpdatedAt           time.Time `db:"updated_at" json:"updatedAt"`
+	HideEmail           bool      `db:"hide_email" json:"hideEmail"`
+	HideWorkEmail       bool      `db:"hide_work_email" json:"hideWorkEmail"`
+	HideLinkedInProfile bool      `db:"hide_linkedin_profile" json:"hideLinkedInProfile"`
+	HiddenFromSearch    bool      `db:"hidden_from_search" json:"hiddenFromSearch"`
+}
+
+// NewPrivacySetting creates the PrivacySetting of clients who never changed it
+func NewPrivacySetting(clientID string) *PrivacySetting {
+	return &PrivacySetting{ClientID: clientID}
+}
+
+// Viewer is the client a profile is being shown to
+type Viewer struct {
+	ClientID string
+	Admin    bool
+}
+
+// RelationshipTo finds how the viewer is related to the client, participants are the clients
+// the viewer shares a session with
+func (v Viewer) RelationshipTo(clientID string, participants map[string]bool) string {
+	switch {
+	case v.ClientID == clientID:
+		return RELATIONSHIP_SELF
+	case v.Admin:
+		return RELATIONSHIP_ADMIN
+	case participants[clientID]:
+		return RELATIONSHIP_PARTICIPANT
+	default:
+		return RELATIONSHIP_STRANGER
+	}
+}
+
+// VisibleTo clears the contact details the relationship is not allowed to see. Strangers never see
+// emails, participants see what the client did not hide
+func (c Client) VisibleTo(relationship string, setting PrivacySetting) Client {
+	switch relationship {
+	case RELATIONSHIP_SELF, RELATIONSHIP_ADMIN:
+		return c
+	case RELATIONSHIP_PARTICIPANT:
+		if setting.HideEmail {
+			c.Email = null.String{}
+		}
+		if setting.HideWorkEmail {
+			c.WorkEmail = null.String{}
+		}
+	default:
+		c.Email = null.String{}
+		c.WorkEmail = null.String{}
+	}
+
+	if setting.HideLinkedInProfile {
+		c.LinkedInProfile = null.String{}
+	}
+
+	return c
+}
+
+type PrivacyStore interface {
+	GetPrivacySetting(db DB, clientID string) (*PrivacySetting, error)
+	GetPrivacySettings(db DB, clientIDs []string) (map[string]PrivacySetting, error)
+	UpsertPrivacySetting(db DB, setting *PrivacySetting) (*PrivacySetting, error)
+	GetSessionParticipants(db DB, clientID string, otherClientIDs []string) (map[string]bool, error)
+}
+
+type PrivacyUseCase interface {
+	GetPrivacySetting(clientID string) (*PrivacySetting, error)
+	UpdatePrivacySetting(
+		clientID string,
+		hideEmail null.Bool,
+		hideWorkEmail null.Bool,
+		hideLinkedInProfile null.Bool,
+		hiddenFromSearch null.Bool,
+	) (*PrivacySetting, error)
+	HideClientFields(viewer Viewer, clients ...*Client) error
+}
@@ -3,6 +3,7 @@ package rfrl
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
 
@@ -24,6 +25,8 @@ type Question struct {
 	From       Client    `json:"from"`
 	Applicants int       `db:"applicants" json:"applicants"`
 	Resolved   bool      `db:"resolved" json:"resolved"`
+	Answers    int       `db:"answers" json:"answers"`
+	Comments   int       `db:"comments" json:"comments"`
 }
 
 func NewQuestion(title string, body string, tags []int, fromClient string) Question {
@@ -35,14 +38,76 @@ func NewQuestion(title string, body string, tags []int, fromClient string) Quest
 	}
 }
 
+// Applicants are shortlisted or declined by the author of the question until one of them is accepted,
+// which declines every other applicant
+const (
+	APPLICANT_APPLIED     string = "applied"
+	APPLICANT_SHORTLISTED string = "shortlisted"
+	APPLICANT_DECLINED    string = "declined"
+	APPLICANT_ACCEPTED    string = "accepted"
+)
+
+// QuestionApplicantTransitions maps an applicant state to the states it can move to
+var QuestionApplicantTransitions = map[string]map[string]bool{
+	APPLICANT_APPLIED: {
+		APPLICANT_SHORTLISTED: true,
+		APPLICANT_DECLINED:    true,
+		APPLICANT_ACCEPTED:    true,
+	},
+	APPLICANT_SHORTLISTED: {
+		APPLICANT_DECLINED: true,
+		APPLICANT_ACCEPTED: true,
+	},
+}
+
+var (
+	ErrNotQuestionAuthor          = errors.New("Only the author of the question can do this")
+	ErrInvalidApplicantTransition = errors.New("Applicant cannot move to this state")
+	ErrNotAnApplicant             = errors.New("Client did not apply to this question")
+)
+
+// QuestionApplicant is a client who applied to a question with their rating as a tutor. SessionID is
+// the session created when the applicant is accepted
+type QuestionApplicant struct {
+	QuestionID     int        `db:"question_id" json:"questionId"`
+	ApplicantID    string     `db:"applicant_id" json:"-"`
+	Applicant      Client     `json:"applicant"`
+	CreatedAt      time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updatedAt"`
+	State          string     `db:"state" json:"state"`
+	SessionID      null.Int   `db:"session_id" json:"sessionId"`
+	AverageRating  null.Float `db:"average_rating" json:"averageRating"`
+	BayesianRating null.Float `db:"bayesian_rating" json:"bayesianRating"`
+	ReviewCount    int        `db:"review_count" json:"reviewCount"`
+}
+
+// CanMoveTo checks if the author of the question can move the applicant to the state
+func (qa QuestionApplicant) CanMoveTo(state string) bool {
+	return QuestionApplicantTransitions[qa.State][state]
+}
+
+// SuggestedTutorsLimit caps how many tutors are suggested for a question
+const SuggestedTutorsLimit = 10
+
+// SuggestedTutor is a tutor sharing tags with a question. Score adds up the tutor's proficiency in each shared tag
+type SuggestedTutor struct {
+	Client
+	MatchingTags int `db:"matching_tags" json:"matchingTags"`
+	Score        int `db:"score" json:"score"`
+}
+
 type QuestionUseCase interface {
 	CreateQuestion(clientID string, title string, body string, tags []int) (*Question, error)
 	UpdateQuestion(clientID string, id int, title string, body string, tags []int, resolved null.Bool) (*Question, error)
 	DeleteQuestion(clientID string, id int) error
 	GetQuestion(id int) (*Question, error)
-	GetQuestions(lastQuestion null.Int, resolved null.Bool) (*[]Question, error)
-	GetQuestionsForClient(clientID string, resolved null.Bool) (*[]Question, error)
+	GetQuestions(resolved null.Bool, page PageOptions) (*[]Question, *Cursor, error)
+	GetQuestionsForClient(clientID string, resolved null.Bool, page PageOptions) (*[]Question, *Cursor, error)
 	ApplyToQuestion(clientID string, id int) error
+	GetSuggestedTutors(id int) (*[]SuggestedTutor, error)
+	GetQuestionApplicants(clientID string, id int, state null.String, page PageOptions) (*[]QuestionApplicant, *Cursor, error)
+	UpdateQuestionApplicant(clientID string, id int, applicantID string, state string) (*QuestionApplicant, error)
+	AcceptQuestionApplicant(clientID string, id int, applicantID string, roomID string) (*QuestionApplicant, *Session, error)
 }
 
 type QuestionStore interface {
@@ -50,7 +115,13 @@ type QuestionStore interface {
 	UpdateQuestion(db DB, clientID string, id int, title string, body string, tags []int, resolved null.Bool) (*Question, error)
 	DeleteQuestion(db DB, id int) error
 	GetQuestion(db DB, id int) (*Question, error)
-	GetQuestions(db DB, lastQuestion null.Int, resolved null.Bool) (*[]Question, error)
-	GetQuestionsForClient(db DB, clientID string, resolved null.Bool) (*[]Question, error)
+	GetQuestionForUpdate(db DB, id int) (*Question, error)
+	GetQuestions(db DB, resolved null.Bool, page PageOptions) (*[]Question, *Cursor, error)
+	GetQuestionsForClient(db DB, clientID string, resolved null.Bool, page PageOptions) (*[]Question, *Cursor, error)
 	ApplyToQuestion(db DB, clientID string, id int) error
+	GetSuggestedTutors(db DB, id int, limit int) (*[]SuggestedTutor, error)
+	GetQuestionApplicants(db DB, id int, state null.String, page PageOptions) (*[]QuestionApplicant, *Cursor, error)
+	GetQuestionApplicant(db DB, id int, applicantID string) (*QuestionApplicant, error)
+	UpdateQuestionApplicantState(db DB, id int, applicantID string, from []string, state string, sessionID null.Int) (*QuestionApplicant, error)
+	DeclineOtherQuestionApplicants(db DB, id int, acceptedID string) ([]string, error)
 }
@@ -0,0 +1,105 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrQuestionAnswerNotForClient = errors.New("Answer does not belong to this client")
+	ErrCommentNotAcceptable       = errors.New("Only answers can be accepted, not comments")
+	ErrQuestionAnswerParent       = errors.New("Comments can only reply to answers of the same question")
+)
+
+// QuestionAnswer is an answer to a question when it has no parent and a comment otherwise. Comments
+// reply to an answer or to another comment and RootID is the answer the thread starts from. The body
+// is markdown and rendered by the client
+type QuestionAnswer struct {
+	ID         int              `db:"id" json:"id"`
+	CreatedAt  time.Time        `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time        `db:"updated_at" json:"updatedAt"`
+	QuestionID int              `db:"question_id" json:"questionId"`
+	ParentID   null.Int         `db:"parent_id" json:"parentId"`
+	RootID     null.Int         `db:"root_id" json:"-"`
+	FromID     string           `db:"from_id" json:"-"`
+	From       Client           `json:"from"`
+	Body       string           `db:"body" json:"body"`
+	Accepted   bool             `db:"accepted" json:"accepted"`
+	Replies    []QuestionAnswer `json:"replies"`
+}
+
+// NewQuestionAnswer creates an answer to the question, or a comment on parent when it is given
+func NewQuestionAnswer(questionID int, fromID string, body string, parent *QuestionAnswer) *QuestionAnswer {
+	answer := QuestionAnswer{
+		QuestionID: questionID,
+		FromID:     fromID,
+		Body:       body,
+	}
+
+	if parent != nil {
+		answer.ParentID = null.IntFrom(int64(parent.ID))
+		answer.RootID = parent.RootID
+
+		if !parent.RootID.Valid {
+			answer.RootID = null.IntFrom(int64(parent.ID))
+		}
+	}
+
+	return &answer
+}
+
+// IsComment checks if it replies to another answer or comment
+func (qa QuestionAnswer) IsComment() bool {
+	return qa.ParentID.Valid
+}
+
+// NewQuestionAnswerThreads nests the comments under what they reply to and keeps the answers and
+// comments in the order they are given
+func NewQuestionAnswerThreads(answers []QuestionAnswer, comments []QuestionAnswer) []QuestionAnswer {
+	parentToComments := make(map[int][]QuestionAnswer)
+
+	for _, comment := range comments {
+		parentID := int(comment.ParentID.Int64)
+		parentToComments[parentID] = append(parentToComments[parentID], comment)
+	}
+
+	var addReplies func(answer QuestionAnswer) QuestionAnswer
+	addReplies = func(answer QuestionAnswer) QuestionAnswer {
+		replies := parentToComments[answer.ID]
+		answer.Replies = make([]QuestionAnswer, len(replies))
+
+		for i, reply := range replies {
+			answer.Replies[i] = addReplies(reply)
+		}
+
+		return answer
+	}
+
+	threads := make([]QuestionAnswer, len(answers))
+
+	for i, answer := range answers {
+		threads[i] = addReplies(answer)
+	}
+
+	return threads
+}
+
+type QuestionAnswerStore interface {
+	CreateQuestionAnswer(db DB, answer *QuestionAnswer) (*QuestionAnswer, error)
+	GetQuestionAnswer(db DB, id int) (*QuestionAnswer, error)
+	UpdateQuestionAnswer(db DB, id int, body string) (*QuestionAnswer, error)
+	DeleteQuestionAnswer(db DB, id int) error
+	SetQuestionAnswerAccepted(db DB, questionID int, id int, accepted bool) (*QuestionAnswer, error)
+	GetQuestionAnswers(db DB, questionID int, page PageOptions) (*[]QuestionAnswer, *Cursor, error)
+	GetQuestionAnswerComments(db DB, answerIDs []int) (*[]QuestionAnswer, error)
+}
+
+type QuestionAnswerUseCase interface {
+	CreateQuestionAnswer(clientID string, questionID int, parentID null.Int, body string) (*QuestionAnswer, error)
+	UpdateQuestionAnswer(clientID string, id int, body string) (*QuestionAnswer, error)
+	DeleteQuestionAnswer(clientID string, id int) error
+	AcceptQuestionAnswer(clientID string, id int, accepted bool) (*QuestionAnswer, error)
+	GetQuestionAnswers(questionID int, page PageOptions) (*[]QuestionAnswer, *Cursor, error)
+}
@@ -0,0 +1,328 @@
+package rfrl
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ReferralState string
+
+const (
+	REFERRAL_REQUESTED ReferralState = "requested"
+	REFERRAL_ACCEPTED  ReferralState = "accepted"
+	REFERRAL_DECLINED  ReferralState = "declined"
+	REFERRAL_SUBMITTED ReferralState = "submitted"
+	REFERRAL_WITHDRAWN ReferralState = "withdrawn"
+)
+
+var referralStates = map[ReferralState]bool{
+	REFERRAL_REQUESTED: true,
+	REFERRAL_ACCEPTED:  true,
+	REFERRAL_DECLINED:  true,
+	REFERRAL_SUBMITTED: true,
+	REFERRAL_WITHDRAWN: true,
+}
+
+func (s ReferralState) IsValid() bool {
+	return referralStates[s]
+}
+
+func (s *ReferralState) UnmarshalJSON(b []byte) error {
+	var state string
+	err := json.Unmarshal(b, &state)
+
+	if err != nil {
+		return errors.Wrap(err, "UnmarshalJSON")
+	}
+
+	if !ReferralState(state).IsValid() {
+		return errors.Errorf("Invalid for ReferralState (%s)", string(b))
+	}
+	*s = ReferralState(state)
+
+	return nil
+}
+
+func (s *ReferralState) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		*s = ReferralState(value)
+	case []byte:
+		*s = ReferralState(value)
+	default:
+		return errors.New("Invalid type for ReferralState")
+	}
+	return nil
+}
+
+func (s ReferralState) Value() (driver.Value, error) {
+	if !s.IsValid() {
+		return nil, errors.New("Wrong value for ReferralState")
+	}
+
+	return string(s), nil
+}
+
+// ReferralStateTransition holds who can move a referral from one state to another
+type ReferralStateTransition struct {
+	ReferrerOnly bool
+	SeekerOnly   bool
+}
+
+// ReferralStateTransitions maps a state to the states it can move to
+var ReferralStateTransitions = map[ReferralState]map[ReferralState]ReferralStateTransition{
+	REFERRAL_REQUESTED: {
+		REFERRAL_ACCEPTED:  {ReferrerOnly: true},
+		REFERRAL_DECLINED:  {ReferrerOnly: true},
+		REFERRAL_WITHDRAWN: {SeekerOnly: true},
+	},
+	REFERRAL_ACCEPTED: {
+		REFERRAL_SUBMITTED: {ReferrerOnly: true},
+		REFERRAL_DECLINED:  {ReferrerOnly: true},
+		REFERRAL_WITHDRAWN: {SeekerOnly: true},
+	},
+}
+
+var (
+	ErrInvalidReferralStateTransition    = errors.New("Invalid referral state transition")
+	ErrReferralStateTransitionNotAllowed = errors.New("Client is not allowed to make this referral state transition")
+	ErrReferralAlreadyRequested          = errors.New("Referral for this job was already requested from this referrer")
+	ErrReferrerNotVerified               = errors.New("Referrer has not verified their work email at this company")
+)
+
+// Referral is a request from a job seeker to an employee to be referred for a job
+type Referral struct {
+	ID             int                   `db:"id" json:"id"`
+	CreatedAt      time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time             `db:"updated_at" json:"updatedAt"`
+	SeekerID       string                `db:"seeker_id" json:"seekerId"`
+	ReferrerID     string                `db:"referrer_id" json:"referrerId"`
+	CompanyID      int                   `db:"company_id" json:"companyId"`
+	JobPostingID   null.Int              `db:"job_posting_id" json:"jobPostingId"`
+	JobURL         string                `db:"job_url" json:"jobUrl"`
+	DocumentID     null.Int              `db:"document_id" json:"documentId"`
+	Document       *Document             `json:"document,omitempty"`
+	Message        null.String           `db:"message" json:"message"`
+	State          ReferralState         `db:"state" json:"state"`
+	UpdatedBy      string                `db:"updated_by" json:"updatedBy"`
+	StateChangedAt null.Time             `db:"state_changed_at" json:"stateChangedAt"`
+	StateReason    null.String           `db:"state_reason" json:"stateReason"`
+	Outcome        null.String           `db:"outcome" json:"outcome"`
+	OutcomeOn      null.Time             `db:"outcome_on" json:"outcomeOn"`
+	History        []ReferralStateChange `json:"history,omitempty"`
+	Outcomes       []ReferralOutcome     `json:"outcomes,omitempty"`
+}
+
+// NewReferral creates new Referral
+func NewReferral(
+	seekerID string,
+	referrerID string,
+	companyID int,
+	jobPostingID null.Int,
+	jobURL string,
+	documentID int,
+	message string,
+) *Referral {
+	return &Referral{
+		SeekerID:     seekerID,
+		ReferrerID:   referrerID,
+		CompanyID:    companyID,
+		JobPostingID: jobPostingID,
+		JobURL:       jobURL,
+		DocumentID:   null.IntFrom(int64(documentID)),
+		Message:      null.NewString(message, message != ""),
+		State:        REFERRAL_REQUESTED,
+		UpdatedBy:    seekerID,
+	}
+}
+
+// CanTransition checks that the client can move the referral to state
+func (r Referral) CanTransition(clientID string, state ReferralState) error {
+	transition, ok := ReferralStateTransitions[r.State][state]
+
+	if !ok {
+		return errors.Wrapf(ErrInvalidReferralStateTransition, "Cannot move referral from %s to %s", r.State, state)
+	}
+
+	if transition.ReferrerOnly && clientID != r.ReferrerID {
+		return errors.Wrapf(ErrReferralStateTransitionNotAllowed, "Only the referrer can move referral to %s", state)
+	}
+
+	if transition.SeekerOnly && clientID != r.SeekerID {
+		return errors.Wrapf(ErrReferralStateTransitionNotAllowed, "Only the seeker can move referral to %s", state)
+	}
+
+	return nil
+}
+
+// ReferralStateChange records a single transition of a referral state
+type ReferralStateChange struct {
+	ID         int           `db:"id" json:"id"`
+	CreatedAt  time.Time     `db:"created_at" json:"createdAt"`
+	ReferralID int           `db:"referral_id" json:"referralId"`
+	FromState  null.String   `db:"from_state" json:"from"`
+	ToState    ReferralState `db:"to_state" json:"to"`
+	ChangedBy  null.String   `db:"changed_by" json:"changedBy"`
+	Reason     null.String   `db:"reason" json:"reason"`
+}
+
+// NewReferralStateChange creates new ReferralStateChange, from is empty when the referral is created
+func NewReferralStateChange(
+	referralID int,
+	from ReferralState,
+	to ReferralState,
+	changedBy string,
+	reason string,
+) *ReferralStateChange {
+	return &ReferralStateChange{
+		ReferralID: referralID,
+		FromState:  null.NewString(string(from), from != ""),
+		ToState:    to,
+		ChangedBy:  null.NewString(changedBy, changedBy != ""),
+		Reason:     null.NewString(reason, reason != ""),
+	}
+}
+
+const (
+	OUTCOME_INTERVIEW string = "interview"
+	OUTCOME_OFFER     string = "offer"
+	OUTCOME_HIRED     string = "hired"
+	OUTCOME_REJECTED  string = "rejected"
+)
+
+// referralOutcomeRanks orders the outcome stages, a referral can only move forward
+var referralOutcomeRanks = map[string]int{
+	OUTCOME_INTERVIEW: 1,
+	OUTCOME_OFFER:     2,
+	OUTCOME_HIRED:     3,
+	OUTCOME_REJECTED:  3,
+}
+
+var (
+	ErrReferralNotSubmitted   = errors.New("Outcomes can only be reported once the referral is submitted")
+	ErrInvalidReferralOutcome = errors.New("Invalid referral outcome")
+)
+
+// ReferralOutcome is a stage of the hiring process reported by the seeker after a referral
+type ReferralOutcome struct {
+	ID         int         `db:"id" json:"id"`
+	CreatedAt  time.Time   `db:"created_at" json:"createdAt"`
+	ReferralID int         `db:"referral_id" json:"referralId"`
+	Stage      string      `db:"stage" json:"stage"`
+	OccurredOn time.Time   `db:"occurred_on" json:"occurredOn"`
+	Note       null.String `db:"note" json:"note"`
+}
+
+// NewReferralOutcome creates new ReferralOutcome
+func NewReferralOutcome(referralID int, stage string, occurredOn time.Time, note string) *ReferralOutcome {
+	return &ReferralOutcome{
+		ReferralID: referralID,
+		Stage:      stage,
+		OccurredOn: occurredOn,
+		Note:       null.NewString(note, note != ""),
+	}
+}
+
+// CanReportOutcome checks that the seeker can report stage after the outcomes already reported
+func (r Referral) CanReportOutcome(clientID string, stage string) error {
+	rank, ok := referralOutcomeRanks[stage]
+
+	if !ok {
+		return errors.Wrapf(ErrInvalidReferralOutcome, "Outcome (%s) is not a valid stage", stage)
+	}
+
+	if clientID != r.SeekerID {
+		return errors.New("Only the seeker can report the outcome of a referral")
+	}
+
+	if r.State != REFERRAL_SUBMITTED {
+		return ErrReferralNotSubmitted
+	}
+
+	if !r.Outcome.Valid {
+		return nil
+	}
+
+	current := referralOutcomeRanks[r.Outcome.String]
+
+	if r.Outcome.String == OUTCOME_HIRED || r.Outcome.String == OUTCOME_REJECTED || rank < current {
+		return errors.Wrapf(ErrInvalidReferralOutcome, "Cannot report %s after %s", stage, r.Outcome.String)
+	}
+
+	return nil
+}
+
+// ReferralStats aggregates the submitted referrals of a referrer or a company
+type ReferralStats struct {
+	ReferralsMade  int     `db:"referrals_made" json:"referralsMade"`
+	Interviews     int     `db:"interviews" json:"interviews"`
+	Offers         int     `db:"offers" json:"offers"`
+	Hires          int     `db:"hires" json:"hires"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+// ComputeConversionRate sets the share of submitted referrals that ended up hired
+func (rs *ReferralStats) ComputeConversionRate() {
+	if rs.ReferralsMade == 0 {
+		rs.ConversionRate = 0
+		return
+	}
+	rs.ConversionRate = float64(rs.Hires) / float64(rs.ReferralsMade)
+}
+
+// ReferrerLeaderboardEntry is a referrer ranked by their referral stats
+type ReferrerLeaderboardEntry struct {
+	ReferralStats
+	ReferrerID string      `db:"referrer_id" json:"referrerId"`
+	FirstName  null.String `db:"first_name" json:"firstName"`
+	LastName   null.String `db:"last_name" json:"lastName"`
+	Photo      null.String `db:"photo" json:"photo"`
+	CompanyID  null.Int    `db:"company_id" json:"companyId"`
+}
+
+// ReferrerProfileStats is shown on a referrer's public profile
+type ReferrerProfileStats struct {
+	Referrals ReferralStats        `json:"referrals"`
+	Reviews   TutorReviewAggregate `json:"reviews"`
+}
+
+// MaxLeaderboardSize caps how many referrers the leaderboard returns
+const MaxLeaderboardSize = 50
+
+// GetReferralsOptions filters the referrals a client is part of
+type GetReferralsOptions struct {
+	AsSeeker null.Bool
+	State    null.String
+}
+
+type ReferralStore interface {
+	CreateReferral(db DB, referral *Referral) (*Referral, error)
+	GetReferral(db DB, clientID string, ID int) (*Referral, error)
+	GetReferralForUpdate(db DB, clientID string, ID int) (*Referral, error)
+	GetReferrals(db DB, clientID string, options GetReferralsOptions, page PageOptions) (*[]Referral, *Cursor, error)
+	CheckOpenReferralExists(db DB, seekerID string, referrerID string, jobURL string) (bool, error)
+	UpdateReferralState(db DB, ID int, by string, state ReferralState, reason null.String) (*Referral, error)
+	CreateReferralStateChange(db DB, change *ReferralStateChange) (*ReferralStateChange, error)
+	GetReferralStateChanges(db DB, referralID int) (*[]ReferralStateChange, error)
+	CreateReferralOutcome(db DB, outcome *ReferralOutcome) (*ReferralOutcome, error)
+	UpdateReferralOutcome(db DB, ID int, stage string, occurredOn time.Time) (*Referral, error)
+	GetReferralOutcomes(db DB, referralID int) (*[]ReferralOutcome, error)
+	GetReferrerStats(db DB, referrerID string) (*ReferralStats, error)
+	GetCompanyReferralStats(db DB, companyID int) (*ReferralStats, error)
+	GetReferrerLeaderboard(db DB, companyID null.Int, limit int) (*[]ReferrerLeaderboardEntry, error)
+}
+
+type ReferralUseCase interface {
+	CreateReferral(seekerID string, referrerID string, companyID int, jobPostingID null.Int, jobURL string, documentID int, message string) (*Referral, error)
+	GetReferral(clientID string, ID int) (*Referral, error)
+	GetReferrals(clientID string, options GetReferralsOptions, page PageOptions) (*[]Referral, *Cursor, error)
+	UpdateReferralState(clientID string, ID int, state ReferralState, reason string) (*Referral, error)
+	ReportReferralOutcome(clientID string, ID int, stage string, occurredOn time.Time, note string) (*Referral, error)
+	GetReferrerProfileStats(referrerID string) (*ReferrerProfileStats, error)
+	GetCompanyReferralStats(companyID int) (*ReferralStats, error)
+	GetReferrerLeaderboard(companyID null.Int, limit int) (*[]ReferrerLeaderboardEntry, error)
+}
@@ -0,0 +1,73 @@
+package rfrl
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	NOTIFICATION_REFERRER_AVAILABLE     = "referrer_available"
+	NOTIFICATION_PENDING_SEEKERS_DIGEST = "pending_seekers_digest"
+)
+
+// Rate limits for referral notifications, a seeker hears about a company at most once per
+// ReferrerAvailableCooldown and gets at most ReferrerAvailableDailyLimit emails per day
+const (
+	ReferrerAvailableCooldown    = 7 * 24 * time.Hour
+	ReferrerAvailableDailyLimit  = 3
+	PendingSeekersDigestCooldown = 7 * 24 * time.Hour
+	PendingSeekersDigestSize     = 20
+)
+
+// NotificationSetting holds what a client wants to be emailed about, clients without one get every email
+type NotificationSetting struct {
+	ClientID             string    `db:"client_id" json:"clientId"`
+	UpdatedAt            time.Time `db:"updated_at" json:"updatedAt"`
+	ReferrerAvailable    bool      `db:"referrer_available" json:"referrerAvailable"`
+	PendingSeekersDigest bool      `db:"pending_seekers_digest" json:"pendingSeekersDigest"`
+}
+
+// NewNotificationSetting creates a NotificationSetting with every email turned on
+func NewNotificationSetting(clientID string) *NotificationSetting {
+	return &NotificationSetting{
+		ClientID:             clientID,
+		ReferrerAvailable:    true,
+		PendingSeekersDigest: true,
+	}
+}
+
+// ReferralNotification records an email that was sent, it is used to rate limit them
+type ReferralNotification struct {
+	ID            int         `db:"id" json:"id"`
+	CreatedAt     time.Time   `db:"created_at" json:"createdAt"`
+	ClientID      string      `db:"client_id" json:"clientId"`
+	Kind          string      `db:"kind" json:"kind"`
+	CompanyID     null.Int    `db:"company_id" json:"companyId"`
+	AboutClientID null.String `db:"about_client_id" json:"aboutClientId"`
+}
+
+// NewReferralNotification creates new ReferralNotification
+func NewReferralNotification(clientID string, kind string, companyID int, aboutClientID string) *ReferralNotification {
+	return &ReferralNotification{
+		ClientID:      clientID,
+		Kind:          kind,
+		CompanyID:     null.NewInt(int64(companyID), companyID != 0),
+		AboutClientID: null.NewString(aboutClientID, aboutClientID != ""),
+	}
+}
+
+type ReferralNotificationStore interface {
+	GetNotificationSetting(db DB, clientID string) (*NotificationSetting, error)
+	UpsertNotificationSetting(db DB, setting *NotificationSetting) (*NotificationSetting, error)
+	GetSeekersToNotifyOfReferrer(db DB, companyID int, referrerID string) (*[]Client, error)
+	GetPendingSeekers(db DB, companyID int, referrerID string, limit int) (*[]Client, error)
+	CanSendPendingSeekersDigest(db DB, referrerID string) (bool, error)
+	CreateReferralNotification(db DB, notification *ReferralNotification) (*ReferralNotification, error)
+}
+
+type ReferralNotificationUseCase interface {
+	NotifyReferrerVerified(referrerID string, companyID int) error
+	GetNotificationSetting(clientID string) (*NotificationSetting, error)
+	UpdateNotificationSetting(clientID string, referrerAvailable null.Bool, pendingSeekersDigest null.Bool) (*NotificationSetting, error)
+}
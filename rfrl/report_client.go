@@ -22,11 +22,11 @@ func NewReportClient(reporter string, accused string, cause string) ReportClient
 type ReportClientUseCase interface {
 	CreateReport(report ReportClient) error
 	DeleteReport(report ReportClient) error
-	GetReports() (*[]ReportClient, error)
+	GetReports(page PageOptions) (*[]ReportClient, *Cursor, error)
 }
 
 type ReportClientStore interface {
 	CreateReport(db DB, report ReportClient) error
 	DeleteReport(db DB, reporter string, accused string) error
-	GetReports(db DB) (*[]ReportClient, error)
+	GetReports(db DB, page PageOptions) (*[]ReportClient, *Cursor, error)
 }
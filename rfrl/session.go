@@ -1,25 +1,33 @@
 package rfrl
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
 
 type Session struct {
-	ID              int         `db:"id" json:"id"`
-	CreatedAt       time.Time   `db:"created_at" json:"createdAt"`
-	UpdatedAt       time.Time   `db:"updated_at" json:"updatedAt"`
-	TutorID         string      `db:"tutor_id" json:"tutorId"`
-	Tutor           Client      `json:"tutor"`
-	UpdatedBy       string      `db:"updated_by" json:"updatedBy"`
-	RoomID          string      `db:"room_id" json:"roomId"`
-	Clients         []Client    `json:"clients"`
-	State           string      `db:"state" json:"state"`
-	TargetedEventID null.Int    `db:"event_id" json:"eventId"`
-	CanAttend       null.Bool   `db:"can_attend" json:"canAttend"`
-	ConferenceID    null.String `db:"conference_id" json:"-"`
-	Event           *Event      `json:"event"`
+	ID              int          `db:"id" json:"id"`
+	CreatedAt       time.Time    `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time    `db:"updated_at" json:"updatedAt"`
+	TutorID         string       `db:"tutor_id" json:"tutorId"`
+	Tutor           Client       `json:"tutor"`
+	UpdatedBy       string       `db:"updated_by" json:"updatedBy"`
+	RoomID          string       `db:"room_id" json:"roomId"`
+	Clients         []Client     `json:"clients"`
+	State           SessionState `db:"state" json:"state"`
+	TargetedEventID null.Int     `db:"event_id" json:"eventId"`
+	CanAttend       null.Bool    `db:"can_attend" json:"canAttend"`
+	ConferenceID    null.String  `db:"conference_id" json:"-"`
+	StateChangedAt  null.Time    `db:"state_changed_at" json:"stateChangedAt"`
+	StateReason     null.String  `db:"state_reason" json:"stateReason"`
+	SeriesID        null.Int     `db:"series_id" json:"seriesId"`
+	QuestionID      null.Int     `db:"question_id" json:"questionId"`
+	Event           *Event       `json:"event"`
 }
 
 type Event struct {
@@ -29,19 +37,271 @@ type Event struct {
 	StartTime time.Time   `db:"start_time" json:"start"`
 	EndTime   time.Time   `db:"end_time" json:"end"`
 	Title     null.String `db:"title" json:"title"`
+	Timezone  string      `db:"timezone" json:"timezone"`
 }
 
+// localizedEvent has the fields of Event without its MarshalJSON
+type localizedEvent Event
+
+// localized moves the event times into the event's timezone so they are
+// rendered with its offset
+func (e Event) localized() localizedEvent {
+	location := LoadTimezone(e.Timezone)
+
+	localized := localizedEvent(e)
+	localized.StartTime = e.StartTime.In(location)
+	localized.EndTime = e.EndTime.In(location)
+	localized.Timezone = location.String()
+
+	return localized
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.localized())
+}
+
+// EventConflict is an existing event of a client that overlaps with a requested event
+type EventConflict struct {
+	Event
+	ClientID  string   `db:"client_id" json:"clientId"`
+	SessionID null.Int `db:"session_id" json:"sessionId"`
+	External  bool     `db:"external" json:"external"`
+	// busyOnly is set on events of other clients so that only when they are busy is shown
+	busyOnly bool
+}
+
+// busyConflictJSON is what is shown of a conflict with the event of another client
+type busyConflictJSON struct {
+	StartTime time.Time `json:"start"`
+	EndTime   time.Time `json:"end"`
+	ClientID  string    `json:"clientId"`
+}
+
+// MarshalJSON keeps the conflict fields that would be hidden by the embedded Event's MarshalJSON
+func (ec EventConflict) MarshalJSON() ([]byte, error) {
+	if ec.busyOnly {
+		return json.Marshal(busyConflictJSON{ec.StartTime.UTC(), ec.EndTime.UTC(), ec.ClientID})
+	}
+
+	return json.Marshal(struct {
+		localizedEvent
+		ClientID  string   `json:"clientId"`
+		SessionID null.Int `json:"sessionId"`
+		External  bool     `json:"external"`
+	}{ec.Event.localized(), ec.ClientID, ec.SessionID, ec.External})
+}
+
+// EventsOverlapError lists the existing events that block the requested events
+type EventsOverlapError struct {
+	Conflicts []EventConflict
+}
+
+// NewEventsOverlapError creates the error for clientID, who only sees when the other clients are busy
+// and not what their events are
+func NewEventsOverlapError(clientID string, conflicts []EventConflict) *EventsOverlapError {
+	for i := range conflicts {
+		conflicts[i].busyOnly = conflicts[i].ClientID != clientID
+	}
+
+	return &EventsOverlapError{Conflicts: conflicts}
+}
+
+func (e *EventsOverlapError) Error() string {
+	return fmt.Sprintf("Events overlap with %d existing events", len(e.Conflicts))
+}
+
+type SessionState string
+
 const (
-	SCHEDULED string = "scheduled"
-	PENDING   string = "pending"
+	PENDING   SessionState = "pending"
+	SCHEDULED SessionState = "scheduled"
+	COMPLETED SessionState = "completed"
+	CANCELLED SessionState = "cancelled"
+	NO_SHOW   SessionState = "no_show"
 )
 
+var sessionStates = map[SessionState]bool{
+	PENDING:   true,
+	SCHEDULED: true,
+	COMPLETED: true,
+	CANCELLED: true,
+	NO_SHOW:   true,
+}
+
+func (s SessionState) IsValid() bool {
+	return sessionStates[s]
+}
+
+func (s *SessionState) UnmarshalJSON(b []byte) error {
+	var state string
+	err := json.Unmarshal(b, &state)
+
+	if err != nil {
+		return errors.Wrap(err, "UnmarshalJSON")
+	}
+
+	if !SessionState(state).IsValid() {
+		return errors.Errorf("Invalid for SessionState (%s)", string(b))
+	}
+	*s = SessionState(state)
+
+	return nil
+}
+
+func (s *SessionState) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		*s = SessionState(value)
+	case []byte:
+		*s = SessionState(value)
+	default:
+		return errors.New("Invalid type for SessionState")
+	}
+	return nil
+}
+
+func (s SessionState) Value() (driver.Value, error) {
+	if !s.IsValid() {
+		return nil, errors.New("Wrong value for SessionState")
+	}
+
+	return string(s), nil
+}
+
+// SessionStateTransition holds the rules for moving a session from one state to another.
+// RequiresAllAttending transitions only happen once every client said they can attend
+type SessionStateTransition struct {
+	TutorOnly            bool
+	RequiresEvent        bool
+	RequiresAllAttending bool
+	BeforeEventStart     bool
+	AfterEventStart      bool
+}
+
+// SessionStateTransitions maps a state to the states it can move to
+var SessionStateTransitions = map[SessionState]map[SessionState]SessionStateTransition{
+	PENDING: {
+		SCHEDULED: {RequiresEvent: true, RequiresAllAttending: true},
+		CANCELLED: {},
+	},
+	SCHEDULED: {
+		COMPLETED: {RequiresEvent: true, AfterEventStart: true},
+		CANCELLED: {RequiresEvent: true, BeforeEventStart: true},
+		NO_SHOW:   {TutorOnly: true, RequiresEvent: true, AfterEventStart: true},
+	},
+}
+
+var (
+	ErrInvalidSessionStateTransition    = errors.New("Invalid session state transition")
+	ErrSessionStateTransitionNotAllowed = errors.New("Client is not allowed to make this session state transition")
+	ErrFreeSlotsNotAllowed              = errors.New("Free time can only be searched with tutors and clients you had sessions with")
+)
+
+// SessionStateChange records a single transition of a session state
+type SessionStateChange struct {
+	ID        int          `db:"id" json:"id"`
+	CreatedAt time.Time    `db:"created_at" json:"createdAt"`
+	SessionID int          `db:"session_id" json:"sessionId"`
+	FromState SessionState `db:"from_state" json:"from"`
+	ToState   SessionState `db:"to_state" json:"to"`
+	ChangedBy null.String  `db:"changed_by" json:"changedBy"`
+	Reason    null.String  `db:"reason" json:"reason"`
+}
+
+// NewSessionStateChange creates new SessionStateChange
+func NewSessionStateChange(
+	sessionID int,
+	from SessionState,
+	to SessionState,
+	changedBy string,
+	reason string,
+) *SessionStateChange {
+	return &SessionStateChange{
+		SessionID: sessionID,
+		FromState: from,
+		ToState:   to,
+		ChangedBy: null.NewString(changedBy, changedBy != ""),
+		Reason:    null.NewString(reason, reason != ""),
+	}
+}
+
+const (
+	PROPOSAL_OPEN      string = "open"
+	PROPOSAL_ACCEPTED  string = "accepted"
+	PROPOSAL_DECLINED  string = "declined"
+	PROPOSAL_COUNTERED string = "countered"
+	PROPOSAL_WITHDRAWN string = "withdrawn"
+)
+
+var ErrSessionProposalClosed = errors.New("Session proposal is no longer open")
+
+// SessionProposal is an alternative time proposed by a participant of a session
+type SessionProposal struct {
+	ID         int                       `db:"id" json:"id"`
+	CreatedAt  time.Time                 `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time                 `db:"updated_at" json:"updatedAt"`
+	SessionID  int                       `db:"session_id" json:"sessionId"`
+	ProposedBy string                    `db:"proposed_by" json:"proposedBy"`
+	StartTime  time.Time                 `db:"start_time" json:"start"`
+	EndTime    time.Time                 `db:"end_time" json:"end"`
+	Title      null.String               `db:"title" json:"title"`
+	Reason     null.String               `db:"reason" json:"reason"`
+	State      string                    `db:"state" json:"state"`
+	CounterTo  null.Int                  `db:"counter_to" json:"counterTo"`
+	Responses  []SessionProposalResponse `json:"responses"`
+}
+
+// SessionProposalResponse is a participant's answer to a SessionProposal
+type SessionProposalResponse struct {
+	ProposalID int       `db:"proposal_id" json:"proposalId"`
+	ClientID   string    `db:"client_id" json:"clientId"`
+	Accepted   bool      `db:"accepted" json:"accepted"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+}
+
+// NewSessionProposal creates new SessionProposal
+func NewSessionProposal(
+	sessionID int,
+	proposedBy string,
+	event Event,
+	reason string,
+	counterTo null.Int,
+) *SessionProposal {
+	return &SessionProposal{
+		SessionID:  sessionID,
+		ProposedBy: proposedBy,
+		StartTime:  event.StartTime,
+		EndTime:    event.EndTime,
+		Title:      event.Title,
+		Reason:     null.NewString(reason, reason != ""),
+		State:      PROPOSAL_OPEN,
+		CounterTo:  counterTo,
+	}
+}
+
+// AttendedBy checks the mentee could attend the session and that it took place, either it was completed
+// or it was scheduled and its event has started. The session event has to be loaded
+func (s Session) AttendedBy(clientID string, now time.Time) bool {
+	if clientID == s.TutorID || !s.CanAttend.Valid || !s.CanAttend.Bool {
+		return false
+	}
+
+	switch s.State {
+	case COMPLETED:
+		return true
+	case SCHEDULED:
+		return s.Event != nil && s.Event.StartTime.Before(now)
+	default:
+		return false
+	}
+}
+
 // NewSession creates new Session
 func NewSession(
 	tutorID string,
 	updatedBy string,
 	roomID string,
-	state string,
+	state SessionState,
 ) *Session {
 	return &Session{
 		TutorID:   tutorID,
@@ -66,15 +326,18 @@ func NewEvent(
 }
 
 type SessionStore interface {
-	GetSessionByClientID(db DB, clientID string, state string) (*[]Session, error)
-	GetSessionByRoomID(db DB, clientID string, roomID string, state string) (*[]Session, error)
+	GetSessionByClientID(db DB, clientID string, state SessionState, page PageOptions) (*[]Session, *Cursor, error)
+	GetSessionByRoomID(db DB, clientID string, roomID string, state SessionState) (*[]Session, error)
 	GetSessionByID(db DB, clientID string, ID int) (*Session, error)
 	GetSessionEventFromSessionID(db DB, ID int) (*Event, error)
 	GetSessionByIDForUpdate(db DB, clientID string, ID int) (*Session, error)
 	GetSessionEventByID(db DB, sessionID int, ID int) (*Event, error)
 	CheckSessionsIsForClient(db DB, client string, sessionIDs []int) (bool, error)
 	DeleteSession(db DB, ID int) error
-	UpdateSession(db DB, ID int, by string, state string, EventID null.Int, ConferenceID null.String) (*Session, error)
+	UpdateSession(db DB, ID int, by string, EventID null.Int, ConferenceID null.String) (*Session, error)
+	UpdateSessionState(db DB, ID int, by string, state SessionState, reason null.String) (*Session, error)
+	CreateSessionStateChange(db DB, change *SessionStateChange) (*SessionStateChange, error)
+	GetSessionStateChanges(db DB, sessionID int, page PageOptions) (*[]SessionStateChange, *Cursor, error)
 	CreateSession(db DB, session *Session) (*Session, error)
 	CreateSessionClients(db DB, sessionID int, clientIDs []string) (*[]Client, error)
 	CreateSessionEvents(db DB, events []Event) (*[]Event, error)
@@ -82,23 +345,37 @@ type SessionStore interface {
 	DeleteSessionEvents(db DB, eventIds []int) error
 	CheckClientsAttendedTutorSession(db DB, tutorID string, clientIDs []string) (bool, error)
 	CheckAllClientSessionHasResponded(db DB, ID int) (bool, error)
+	CheckAllClientsCanAttendSession(db DB, ID int) (bool, error)
 	GetSessionsEvent(db DB, sessionID []int) (map[int]*Event, error)
 	GetSessionFromConferenceID(db DB, conferenceID string) (*Session, error)
+	CreateSessionProposal(db DB, proposal *SessionProposal) (*SessionProposal, error)
+	GetSessionProposalForUpdate(db DB, sessionID int, ID int) (*SessionProposal, error)
+	GetSessionProposals(db DB, sessionID int, page PageOptions) (*[]SessionProposal, *Cursor, error)
+	UpdateSessionProposalState(db DB, ID int, state string) (*SessionProposal, error)
+	CloseOpenSessionProposals(db DB, sessionID int, clientID string) error
+	CreateSessionProposalResponse(db DB, proposalID int, clientID string, accepted bool) error
+	CheckAllClientsAcceptedSessionProposal(db DB, proposalID int) (bool, error)
 }
 
 type SessionUseCase interface {
-	CreateSession(tutorID string, updatedBy string, roomID string, clients []string, state string) (*Session, error)
-	UpdateSession(ID int, updatedBy string, state string) (*Session, error)
+	CreateSession(tutorID string, updatedBy string, roomID string, clients []string, state SessionState, questionID null.Int) (*Session, error)
+	UpdateSession(ID int, updatedBy string, state SessionState, reason string) (*Session, error)
 	DeleteSession(clientID string, ID int) error
 	GetSessionByID(clientID string, ID int) (*Session, error)
-	GetSessionByRoomId(clientID string, roomID string, state string) (*[]Session, error)
-	GetSessionByClientID(clientID string, state string) (*[]Session, error)
+	GetSessionByRoomId(clientID string, roomID string, state SessionState) (*[]Session, error)
+	GetSessionByClientID(clientID string, state SessionState, page PageOptions) (*[]Session, *Cursor, error)
+	GetSessionStateChanges(sessionID int, page PageOptions) (*[]SessionStateChange, *Cursor, error)
 	GetSessionEventByID(sessionID int, ID int) (*Event, error)
 	CreateSessionEvent(clientID string, ID int, event Event) (*Event, error)
 	ClientActionOnSessionEvent(clientID string, sessionID int, canAttend bool) error
-	GetSessionRelatedEvents(clientID string, sessionID int, start null.Time, end null.Time, state null.String) (*[]Event, error)
+	GetSessionRelatedEvents(clientID string, sessionID int, start null.Time, end null.Time, state null.String, page PageOptions) (*[]Event, *Cursor, error)
 	CheckAllClientSessionHasResponded(ID int) (bool, error)
+	CheckAllClientsCanAttendSession(ID int) (bool, error)
 	CheckSessionsIsForClient(clientID string, sessionIDs []int) (bool, error)
 	GetSessionsEvent(sessionIDs []int) (map[int]*Event, error)
 	GetSessionFromConferenceID(conferenceID string) (*Session, error)
+	CreateSessionProposal(clientID string, sessionID int, event Event, reason string, counterTo null.Int) (*SessionProposal, error)
+	RespondToSessionProposal(clientID string, sessionID int, ID int, accept bool) (*SessionProposal, error)
+	GetSessionProposals(sessionID int, page PageOptions) (*[]SessionProposal, *Cursor, error)
+	FindCommonFreeSlots(clientID string, clientIDs []string, start time.Time, end time.Time, duration time.Duration, limit int) ([]CandidateSlot, error)
 }
@@ -0,0 +1,197 @@
+package rfrl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	SERIES_ACTIVE    string = "active"
+	SERIES_CANCELLED string = "cancelled"
+)
+
+// MaxSeriesOccurrences caps how many sessions a single series can generate
+const MaxSeriesOccurrences = 52
+
+const recurrenceUntilLayout = "20060102T150405Z"
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE:
+// FREQ=WEEKLY with an INTERVAL of 1 or 2 and either COUNT or UNTIL
+type RecurrenceRule struct {
+	Interval int
+	Count    null.Int
+	Until    null.Time
+}
+
+// ParseRecurrenceRule parses rules like FREQ=WEEKLY;INTERVAL=2;COUNT=6
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	recurrence := RecurrenceRule{Interval: 1}
+	frequency := ""
+
+	for _, part := range strings.Split(rule, ";") {
+		keyValue := strings.SplitN(part, "=", 2)
+
+		if len(keyValue) != 2 {
+			return nil, errors.Errorf("Invalid recurrence rule part (%s)", part)
+		}
+
+		key, value := strings.ToUpper(keyValue[0]), keyValue[1]
+
+		switch key {
+		case "FREQ":
+			frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+
+			if err != nil {
+				return nil, errors.Wrap(err, "ParseRecurrenceRule")
+			}
+			recurrence.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+
+			if err != nil {
+				return nil, errors.Wrap(err, "ParseRecurrenceRule")
+			}
+			recurrence.Count = null.IntFrom(int64(count))
+		case "UNTIL":
+			until, err := time.Parse(recurrenceUntilLayout, value)
+
+			if err != nil {
+				until, err = time.Parse("20060102", value)
+
+				// UNTIL is inclusive so a date keeps the occurrences later on that day
+				until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+
+			if err != nil {
+				return nil, errors.Wrap(err, "ParseRecurrenceRule")
+			}
+			recurrence.Until = null.TimeFrom(until)
+		default:
+			return nil, errors.Errorf("Unsupported recurrence rule part (%s)", key)
+		}
+	}
+
+	if frequency != "WEEKLY" {
+		return nil, errors.New("Only weekly recurrence rules are supported")
+	}
+
+	if recurrence.Interval != 1 && recurrence.Interval != 2 {
+		return nil, errors.New("Recurrence interval can only be weekly or biweekly")
+	}
+
+	if recurrence.Count.Valid == recurrence.Until.Valid {
+		return nil, errors.New("Recurrence rule needs either COUNT or UNTIL")
+	}
+
+	if recurrence.Count.Valid && (recurrence.Count.Int64 < 1 || recurrence.Count.Int64 > MaxSeriesOccurrences) {
+		return nil, errors.Errorf("Recurrence count has to be between 1 and %d", MaxSeriesOccurrences)
+	}
+
+	return &recurrence, nil
+}
+
+func (r RecurrenceRule) String() string {
+	rule := fmt.Sprintf("FREQ=WEEKLY;INTERVAL=%d", r.Interval)
+
+	if r.Count.Valid {
+		return fmt.Sprintf("%s;COUNT=%d", rule, r.Count.Int64)
+	}
+
+	return fmt.Sprintf("%s;UNTIL=%s", rule, r.Until.Time.UTC().Format(recurrenceUntilLayout))
+}
+
+// Occurrences expands the rule into events starting from the first event
+func (r RecurrenceRule) Occurrences(first Event) ([]Event, error) {
+	events := make([]Event, 0)
+	duration := first.EndTime.Sub(first.StartTime)
+
+	// Weeks are added in the event's timezone so sessions keep their local time across daylight saving changes
+	location := LoadTimezone(first.Timezone)
+
+	for i := 0; ; i++ {
+		start := first.StartTime.In(location).AddDate(0, 0, 7*r.Interval*i)
+
+		if r.Count.Valid && int64(i) >= r.Count.Int64 {
+			break
+		}
+
+		if r.Until.Valid && start.After(r.Until.Time) {
+			break
+		}
+
+		if i >= MaxSeriesOccurrences {
+			return nil, errors.Errorf("Recurrence rule generates more than %d sessions", MaxSeriesOccurrences)
+		}
+
+		events = append(events, Event{
+			StartTime: start,
+			EndTime:   start.Add(duration),
+			Title:     first.Title,
+			Timezone:  first.Timezone,
+		})
+	}
+
+	return events, nil
+}
+
+// SessionSeries groups recurring sessions generated from one recurrence rule
+type SessionSeries struct {
+	ID        int         `db:"id" json:"id"`
+	CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time   `db:"updated_at" json:"updatedAt"`
+	TutorID   string      `db:"tutor_id" json:"tutorId"`
+	CreatedBy string      `db:"created_by" json:"createdBy"`
+	RoomID    string      `db:"room_id" json:"roomId"`
+	Rule      string      `db:"rrule" json:"rrule"`
+	StartTime time.Time   `db:"start_time" json:"start"`
+	EndTime   time.Time   `db:"end_time" json:"end"`
+	Title     null.String `db:"title" json:"title"`
+	State     string      `db:"state" json:"state"`
+	Sessions  []Session   `json:"sessions"`
+}
+
+// NewSessionSeries creates new SessionSeries
+func NewSessionSeries(
+	tutorID string,
+	createdBy string,
+	roomID string,
+	rule RecurrenceRule,
+	first Event,
+) *SessionSeries {
+	return &SessionSeries{
+		TutorID:   tutorID,
+		CreatedBy: createdBy,
+		RoomID:    roomID,
+		Rule:      rule.String(),
+		StartTime: first.StartTime,
+		EndTime:   first.EndTime,
+		Title:     first.Title,
+		State:     SERIES_ACTIVE,
+	}
+}
+
+type SessionSeriesStore interface {
+	CreateSessionSeries(db DB, series *SessionSeries) (*SessionSeries, error)
+	GetSessionSeries(db DB, ID int) (*SessionSeries, error)
+	GetSessionSeriesForUpdate(db DB, ID int) (*SessionSeries, error)
+	UpdateSessionSeriesState(db DB, ID int, state string) (*SessionSeries, error)
+	GetSessionSeriesSessions(db DB, clientID string, seriesID int) (*[]Session, error)
+	GetUpcomingSessionSeriesSessionsForUpdate(db DB, seriesID int) (*[]Session, error)
+	CheckSessionSeriesIsForClient(db DB, clientID string, seriesID int) (bool, error)
+}
+
+type SessionSeriesUseCase interface {
+	CreateSessionSeries(clientID string, tutorID string, roomID string, clients []string, first Event, rule string) (*SessionSeries, error)
+	GetSessionSeries(clientID string, ID int) (*SessionSeries, error)
+	RespondToSessionSeries(clientID string, ID int, canAttend bool) (*SessionSeries, error)
+	CancelSessionSeries(clientID string, ID int, reason string) (*SessionSeries, error)
+	CheckSessionSeriesIsForClient(clientID string, ID int) (bool, error)
+}
@@ -0,0 +1,36 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTimezone is used for clients and events that never picked a timezone
+const DefaultTimezone = "UTC"
+
+// ValidateTimezone checks that name is an IANA timezone such as America/Toronto
+func ValidateTimezone(name string) error {
+	if name == "" {
+		return errors.New("Timezone cannot be empty")
+	}
+
+	_, err := time.LoadLocation(name)
+
+	return errors.Wrapf(err, "Timezone (%s) is not valid", name)
+}
+
+// LoadTimezone returns the location of name, falling back to UTC when it is unknown
+func LoadTimezone(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(name)
+
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
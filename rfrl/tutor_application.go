@@ -0,0 +1,92 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	TUTOR_APPLICATION_PENDING  string = "pending"
+	TUTOR_APPLICATION_APPROVED string = "approved"
+	TUTOR_APPLICATION_REJECTED string = "rejected"
+)
+
+// TutorApplicationReviewsShown is how many of the applicant's latest reviews are shown to admins
+const TutorApplicationReviewsShown = 10
+
+var (
+	ErrAlreadyTutor                = errors.New("Client is already a tutor")
+	ErrTutorApplicationPending     = errors.New("Client already has a pending tutor application")
+	ErrTutorApplicationReviewed    = errors.New("Tutor application has already been reviewed")
+	ErrTutorApplicationWrongReview = errors.New("Tutor applications can only be approved or rejected")
+	ErrNotApprovedTutor            = errors.New("Sessions can only be booked with approved tutors")
+)
+
+// TutorApplication is a client asking to become a tutor, admins approve or reject it with notes
+type TutorApplication struct {
+	ID          int         `db:"id" json:"id"`
+	CreatedAt   time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time   `db:"updated_at" json:"updatedAt"`
+	ClientID    string      `db:"client_id" json:"clientId"`
+	State       string      `db:"state" json:"state"`
+	Motivation  null.String `db:"motivation" json:"motivation"`
+	ReviewedBy  null.String `db:"reviewed_by" json:"reviewedBy"`
+	ReviewNotes null.String `db:"review_notes" json:"reviewNotes"`
+	ReviewedAt  null.Time   `db:"reviewed_at" json:"reviewedAt"`
+}
+
+// NewTutorApplication creates new pending TutorApplication
+func NewTutorApplication(clientID string, motivation string) *TutorApplication {
+	return &TutorApplication{
+		ClientID:   clientID,
+		State:      TUTOR_APPLICATION_PENDING,
+		Motivation: null.NewString(motivation, motivation != ""),
+	}
+}
+
+// CanBeReviewedAs checks that a pending application is moved to a final state
+func (ta TutorApplication) CanBeReviewedAs(state string) error {
+	if ta.State != TUTOR_APPLICATION_PENDING {
+		return ErrTutorApplicationReviewed
+	}
+
+	if state != TUTOR_APPLICATION_APPROVED && state != TUTOR_APPLICATION_REJECTED {
+		return ErrTutorApplicationWrongReview
+	}
+
+	return nil
+}
+
+// TutorApplicationReview is what admins look at to decide on an application
+type TutorApplicationReview struct {
+	Application      TutorApplication     `json:"application"`
+	Applicant        Client               `json:"applicant"`
+	ReviewAggregate  TutorReviewAggregate `json:"reviewAggregate"`
+	RecentReviews    []TutorReview        `json:"recentReviews"`
+	PastApplications []TutorApplication   `json:"pastApplications"`
+}
+
+// GetTutorApplicationsOptions filters listed tutor applications
+type GetTutorApplicationsOptions struct {
+	State    null.String
+	ClientID null.String
+}
+
+type TutorApplicationStore interface {
+	CreateTutorApplication(db DB, application *TutorApplication) (*TutorApplication, error)
+	GetTutorApplication(db DB, ID int) (*TutorApplication, error)
+	GetTutorApplicationForUpdate(db DB, ID int) (*TutorApplication, error)
+	GetTutorApplications(db DB, options GetTutorApplicationsOptions, page PageOptions) (*[]TutorApplication, *Cursor, error)
+	GetClientTutorApplications(db DB, clientID string) (*[]TutorApplication, error)
+	HasPendingTutorApplication(db DB, clientID string) (bool, error)
+	ReviewTutorApplication(db DB, ID int, state string, reviewedBy string, notes string) (*TutorApplication, error)
+}
+
+type TutorApplicationUseCase interface {
+	SubmitTutorApplication(clientID string, motivation string) (*TutorApplication, error)
+	GetTutorApplications(options GetTutorApplicationsOptions, page PageOptions) (*[]TutorApplication, *Cursor, error)
+	GetTutorApplicationReview(ID int) (*TutorApplicationReview, error)
+	ReviewTutorApplication(ID int, adminID string, state string, notes string) (*TutorApplication, error)
+}
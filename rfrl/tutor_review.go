@@ -1,53 +1,174 @@
 package rfrl
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
 
+// Session types reviews are aggregated by, reviews without a session are unverified
+const (
+	REVIEW_SESSION_SINGLE     string = "single"
+	REVIEW_SESSION_RECURRING  string = "recurring"
+	REVIEW_SESSION_UNVERIFIED string = "unverified"
+)
+
+// Periods reviews can be aggregated over
+const (
+	REVIEW_PERIOD_WEEK  string = "week"
+	REVIEW_PERIOD_MONTH string = "month"
+)
+
+// TutorRatingPriorWeight is how many reviews of the average rating of all tutors are added to a tutor's
+// reviews for their Bayesian rating, tutors with few reviews are ranked close to the average
+const TutorRatingPriorWeight = 5
+
+// TutorRatingTrendDays is the length of the window the recent rating is compared over
+const TutorRatingTrendDays = 30
+
+var (
+	ErrSessionNotAttended  = errors.New("Client did not attend this session")
+	ErrTutorReviewExists   = errors.New("Client already reviewed this session")
+	ErrInvalidReviewPeriod = errors.New("Review period can only be week or month")
+)
+
 type TutorReview struct {
-	ID         int         `db:"id" json:"id"`
-	CreatedAt  time.Time   `db:"created_at" json:"createdAt"`
-	UpdatedAt  time.Time   `db:"updated_at" json:"updatedAt"`
-	TutorID    string      `db:"tutor_id" json:"tutorId"`
-	FromID     string      `db:"from_id" json:"-"`
-	FromClient Client      `json:"from"`
-	Stars      null.Int    `db:"stars" json:"stars"`
-	Review     null.String `db:"review" json:"review"`
-	Headline   null.String `db:"headline" json:"headline"`
+	ID         int               `db:"id" json:"id"`
+	CreatedAt  time.Time         `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time         `db:"updated_at" json:"updatedAt"`
+	TutorID    string            `db:"tutor_id" json:"tutorId"`
+	FromID     string            `db:"from_id" json:"-"`
+	FromClient Client            `json:"from"`
+	SessionID  null.Int          `db:"session_id" json:"sessionId"`
+	Stars      null.Int          `db:"stars" json:"stars"`
+	Review     null.String       `db:"review" json:"review"`
+	Headline   null.String       `db:"headline" json:"headline"`
+	HiddenAt   null.Time         `db:"hidden_at" json:"hiddenAt"`
+	HiddenBy   null.String       `db:"hidden_by" json:"-"`
+	Reply      *TutorReviewReply `json:"reply"`
+}
+
+// CanBeSeenBy checks the review is not hidden by moderators, hidden reviews are still shown to admins,
+// their author and the reviewed tutor
+func (tr TutorReview) CanBeSeenBy(viewer Viewer) bool {
+	return !tr.HiddenAt.Valid || viewer.Admin || viewer.ClientID == tr.FromID || viewer.ClientID == tr.TutorID
+}
+
+// VerifiedSession is true for reviews left for a session the reviewer attended
+func (tr TutorReview) VerifiedSession() bool {
+	return tr.SessionID.Valid
+}
+
+func (tr TutorReview) MarshalJSON() ([]byte, error) {
+	type tutorReview TutorReview
+
+	return json.Marshal(struct {
+		tutorReview
+		VerifiedSession bool `json:"verifiedSession"`
+	}{tutorReview(tr), tr.VerifiedSession()})
+}
+
+// TutorReviewTotals sums up a group of reviews
+type TutorReviewTotals struct {
+	TotalStars       int        `db:"total_stars" json:"totalStars"`
+	TotalReviewCount int        `db:"total_review_count" json:"totalReviewCount"`
+	AverageRating    null.Float `db:"average_rating" json:"averageRating"`
 }
 
+// TutorRatingTrend compares the average rating of the last TutorRatingTrendDays with the window before it
+type TutorRatingTrend struct {
+	Days                  int        `json:"days"`
+	RecentReviewCount     int        `db:"recent_review_count" json:"recentReviewCount"`
+	RecentAverageRating   null.Float `db:"recent_average_rating" json:"recentAverageRating"`
+	PreviousAverageRating null.Float `db:"previous_average_rating" json:"previousAverageRating"`
+}
+
+// Change is how much the recent average rating moved, it is only known when both windows have reviews
+func (trt TutorRatingTrend) Change() null.Float {
+	if !trt.RecentAverageRating.Valid || !trt.PreviousAverageRating.Valid {
+		return null.NewFloat(0, false)
+	}
+
+	return null.FloatFrom(trt.RecentAverageRating.Float64 - trt.PreviousAverageRating.Float64)
+}
+
+func (trt TutorRatingTrend) MarshalJSON() ([]byte, error) {
+	type tutorRatingTrend TutorRatingTrend
+
+	return json.Marshal(struct {
+		tutorRatingTrend
+		Change null.Float `json:"change"`
+	}{tutorRatingTrend(trt), trt.Change()})
+}
+
+// TutorReviewAggregate is the rating of a tutor. StarDistribution counts the reviews for each number of stars
 type TutorReviewAggregate struct {
-	TotalStars       int `db:"total_stars" json:"totalStars"`
-	TotalReviewCount int `db:"total_review_count" json:"totalReviewCount"`
+	TutorReviewTotals
+	BayesianRating   null.Float       `db:"bayesian_rating" json:"bayesianRating"`
+	StarDistribution map[int]int      `json:"starDistribution"`
+	Trend            TutorRatingTrend `json:"trend"`
+}
+
+// TutorRatingStarCount is the number of reviews of a tutor with the same stars
+type TutorRatingStarCount struct {
+	Stars       int `db:"stars"`
+	ReviewCount int `db:"review_count"`
+}
+
+// TutorReviewSessionTypeAggregate aggregates the reviews left for one type of session
+type TutorReviewSessionTypeAggregate struct {
+	SessionType string `db:"session_type" json:"sessionType"`
+	TutorReviewTotals
+}
+
+// TutorReviewPeriodAggregate aggregates the reviews left during the period starting at Period
+type TutorReviewPeriodAggregate struct {
+	Period time.Time `db:"period" json:"period"`
+	TutorReviewTotals
 }
 
 type PendingTutorReview struct {
+	SessionID      int    `db:"session_id" json:"sessionId"`
 	TutorID        string `db:"tutor_id" json:"tutorId"`
 	TutorFirstName string `db:"first_name" json:"firstName"`
 	TutorLastName  string `db:"last_name" json:"lastName"`
 }
 
-func NewTutorReview(tutorID string, stars int, review string, headline string) TutorReview {
+func NewTutorReview(tutorID string, sessionID int, stars int, review string, headline string) TutorReview {
 	return TutorReview{
-		TutorID:  tutorID,
-		Stars:    null.NewInt(int64(stars), stars != 0),
-		Review:   null.NewString(review, review != ""),
-		Headline: null.NewString(headline, headline != ""),
+		TutorID:   tutorID,
+		SessionID: null.IntFrom(int64(sessionID)),
+		Stars:     null.NewInt(int64(stars), stars != 0),
+		Review:    null.NewString(review, review != ""),
+		Headline:  null.NewString(headline, headline != ""),
 	}
 }
 
+// IsValidReviewPeriod checks the period can be used to aggregate reviews
+func IsValidReviewPeriod(period string) bool {
+	return period == REVIEW_PERIOD_WEEK || period == REVIEW_PERIOD_MONTH
+}
+
 type TutorReviewUseCase interface {
-	CreateTutorReview(ClientID string, TutorID string, Stars int, Review string, Headline string) (*TutorReview, error)
+	CreateTutorReview(ClientID string, SessionID int, Stars int, Review string, Headline string) (*TutorReview, error)
 	UpdateTutorReview(ClientID string, ID int, Stars int, Review string, Headline string) (*TutorReview, error)
 	DeleteTutorReview(ClientID string, ID int) error
 	GetTutorReview(ID int) (*TutorReview, error)
-	GetTutorReviews(ClientID string) (*[]TutorReview, error)
+	GetTutorReviews(ClientID string, page PageOptions) (*[]TutorReview, *Cursor, error)
 	GetTutorReviewsAggregate(ClientID string) (*TutorReviewAggregate, error)
+	GetTutorReviewsAggregateBySessionType(ClientID string) (*[]TutorReviewSessionTypeAggregate, error)
+	GetTutorReviewsAggregateByPeriod(ClientID string, period string, since null.Time) (*[]TutorReviewPeriodAggregate, error)
 	GetPendingReviews(ClientID string) (*[]PendingTutorReview, error)
-	CreatePendingReview(menteeID string, tutorID string) error
-	DeletePendingReview(menteeID string, tutorID string) error
+	CreatePendingReview(menteeID string, tutorID string, sessionID int) error
+	DeletePendingReview(menteeID string, sessionID int) error
+	FlagTutorReview(clientID string, ID int, reason string) (*TutorReviewFlag, error)
+	GetFlaggedTutorReviews(page PageOptions) (*[]FlaggedTutorReview, *Cursor, error)
+	ModerateTutorReview(adminID string, ID int, action string) (*TutorReview, error)
+	ReplyToTutorReview(clientID string, ID int, reply string) (*TutorReviewReply, error)
+	DeleteTutorReviewReply(clientID string, ID int) error
+	GetTutorReviewRevisions(viewer Viewer, ID int) (*[]TutorReviewRevision, error)
 }
 
 type TutorReviewStore interface {
@@ -56,10 +177,24 @@ type TutorReviewStore interface {
 	UpdateTutorReview(db DB, tutorReview *TutorReview) (*TutorReview, error)
 	DeleteTutorReview(db DB, id int) error
 	GetTutorReview(db DB, id int) (*TutorReview, error)
-	GetTutorReviews(db DB, tutorID string) (*[]TutorReview, error)
+	GetTutorReviews(db DB, tutorID string, page PageOptions) (*[]TutorReview, *Cursor, error)
 	GetTutorReviewsAggregate(db DB, clientID string) (*TutorReviewAggregate, error)
+	GetTutorReviewsAggregateBySessionType(db DB, tutorID string) (*[]TutorReviewSessionTypeAggregate, error)
+	GetTutorReviewsAggregateByPeriod(db DB, tutorID string, period string, since null.Time) (*[]TutorReviewPeriodAggregate, error)
 	GetPendingReviews(db DB, ClientID string) (*[]PendingTutorReview, error)
-	CreatePendingReview(db DB, menteeID string, tutorID string) error
-	DeletePendingReview(db DB, menteeID string, tutorID string) error
-	CheckIfReviewAlreadyExists(db DB, menteeID string, tutorID string) (bool, error)
+	CreatePendingReview(db DB, menteeID string, tutorID string, sessionID int) error
+	DeletePendingReview(db DB, menteeID string, sessionID int) error
+	CheckIfReviewAlreadyExists(db DB, menteeID string, sessionID int) (bool, error)
+	GetTutorReviewForUpdate(db DB, id int) (*TutorReview, error)
+	SetTutorReviewHidden(db DB, id int, hidden bool, by string) (*TutorReview, error)
+	CreateTutorReviewFlag(db DB, flag *TutorReviewFlag) (*TutorReviewFlag, error)
+	CheckOpenTutorReviewFlag(db DB, reviewID int, clientID string) (bool, error)
+	GetFlaggedTutorReviews(db DB, page PageOptions) (*[]TutorReview, *Cursor, error)
+	GetOpenTutorReviewFlags(db DB, reviewIDs []int) (*[]TutorReviewFlag, error)
+	ResolveTutorReviewFlags(db DB, reviewID int, state string, by string) error
+	UpsertTutorReviewReply(db DB, reviewID int, tutorID string, reply string) (*TutorReviewReply, error)
+	DeleteTutorReviewReply(db DB, reviewID int) error
+	GetTutorReviewReplies(db DB, reviewIDs []int) (*[]TutorReviewReply, error)
+	CreateTutorReviewRevision(db DB, review *TutorReview, revisedBy string) error
+	GetTutorReviewRevisions(db DB, reviewID int) (*[]TutorReviewRevision, error)
 }
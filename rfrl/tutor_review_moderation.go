@@ -0,0 +1,87 @@
+package rfrl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// Flags are open until an admin hides the review, which upholds them, or restores it, which dismisses them
+const (
+	REVIEW_FLAG_OPEN      string = "open"
+	REVIEW_FLAG_UPHELD    string = "upheld"
+	REVIEW_FLAG_DISMISSED string = "dismissed"
+)
+
+const (
+	REVIEW_MODERATION_HIDE    string = "hide"
+	REVIEW_MODERATION_RESTORE string = "restore"
+)
+
+var (
+	ErrTutorReviewNotForClient   = errors.New("Tutor review does not belong to this client")
+	ErrTutorReviewAlreadyFlagged = errors.New("Client already flagged this review")
+	ErrNotReviewedTutor          = errors.New("Only the reviewed tutor can reply to a review")
+	ErrInvalidModerationAction   = errors.New("Reviews can only be hidden or restored")
+)
+
+// TutorReviewFlag is a client reporting a review for moderation
+type TutorReviewFlag struct {
+	ID         int         `db:"id" json:"id"`
+	CreatedAt  time.Time   `db:"created_at" json:"createdAt"`
+	ReviewID   int         `db:"review_id" json:"reviewId"`
+	FlaggedBy  string      `db:"flagged_by" json:"flaggedBy"`
+	Reason     string      `db:"reason" json:"reason"`
+	State      string      `db:"state" json:"state"`
+	ResolvedBy null.String `db:"resolved_by" json:"resolvedBy"`
+	ResolvedAt null.Time   `db:"resolved_at" json:"resolvedAt"`
+}
+
+// NewTutorReviewFlag creates new open TutorReviewFlag
+func NewTutorReviewFlag(reviewID int, flaggedBy string, reason string) *TutorReviewFlag {
+	return &TutorReviewFlag{
+		ReviewID:  reviewID,
+		FlaggedBy: flaggedBy,
+		Reason:    reason,
+		State:     REVIEW_FLAG_OPEN,
+	}
+}
+
+// TutorReviewReply is the public answer of the tutor to a review, a review has at most one reply
+type TutorReviewReply struct {
+	ReviewID  int       `db:"review_id" json:"reviewId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+	TutorID   string    `db:"tutor_id" json:"tutorId"`
+	Reply     string    `db:"reply" json:"reply"`
+}
+
+// TutorReviewRevision is a review as it was before one of its edits
+type TutorReviewRevision struct {
+	ID        int         `db:"id" json:"id"`
+	CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+	ReviewID  int         `db:"review_id" json:"reviewId"`
+	RevisedBy null.String `db:"revised_by" json:"revisedBy"`
+	Stars     null.Int    `db:"stars" json:"stars"`
+	Review    null.String `db:"review" json:"review"`
+	Headline  null.String `db:"headline" json:"headline"`
+}
+
+// FlaggedTutorReview is a review waiting in the moderation queue with its open flags
+type FlaggedTutorReview struct {
+	Review TutorReview       `json:"review"`
+	Flags  []TutorReviewFlag `json:"flags"`
+}
+
+// ModerationStateFor is the state of the open flags once the review is moderated with action
+func ModerationStateFor(action string) (string, error) {
+	switch action {
+	case REVIEW_MODERATION_HIDE:
+		return REVIEW_FLAG_UPHELD, nil
+	case REVIEW_MODERATION_RESTORE:
+		return REVIEW_FLAG_DISMISSED, nil
+	default:
+		return "", ErrInvalidModerationAction
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterAccountRoutes account export and deletion routes
+func RegisterAccountRoutes(e *echo.Echo, key *rsa.PublicKey, apiKey string, accountUseCase rfrl.AccountUseCase) {
+
+	accountViews := views.AccountView{AccountUseCase: accountUseCase}
+
+	accountR := e.Group("/account")
+	accountR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	accountR.GET("/export/", accountViews.ExportAccountEndpoint)
+	accountR.GET("/deletion/", accountViews.GetAccountDeletionEndpoint)
+	accountR.POST("/deletion/", accountViews.ScheduleAccountDeletionEndpoint)
+	accountR.DELETE("/deletion/", accountViews.CancelAccountDeletionEndpoint)
+
+	e.POST("/account-deletion/process/", accountViews.ProcessAccountDeletionsEndpoint, middleware.KeyAuth(func(key string, c echo.Context) (bool, error) {
+		return key == apiKey, nil
+	}))
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterAvailabilityRoutes tutor availability routes
+func RegisterAvailabilityRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, availabilityUseCase rfrl.AvailabilityUseCase, privacyUseCase rfrl.PrivacyUseCase) {
+
+	availabilityViews := views.AvailabilityView{AvailabilityUseCase: availabilityUseCase, PrivacyUseCase: privacyUseCase}
+
+	availabilityR := e.Group("/tutor-availability")
+	availabilityR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	availabilityR.PUT("/", availabilityViews.SetTutorAvailabilityEndpoint)
+	availabilityR.GET("/:tutorID/", availabilityViews.GetTutorAvailabilityEndpoint)
+	availabilityR.GET("/:tutorID/slots/", availabilityViews.GetBookableSlotsEndpoint)
+	availabilityR.POST("/:tutorID/book/", availabilityViews.BookTutorSlotEndpoint)
+}
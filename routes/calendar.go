@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterCalendarRoutes calendar feed routes
+func RegisterCalendarRoutes(e *echo.Echo, key *rsa.PublicKey, calendarUseCase rfrl.CalendarUseCase) {
+	calendarViews := views.CalendarView{CalendarUseCase: calendarUseCase}
+
+	jwtMiddleware := middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	})
+
+	calendarFeedR := e.Group("/calendar-feed")
+	calendarFeedR.Use(jwtMiddleware)
+
+	calendarFeedR.GET("/", calendarViews.GetCalendarFeedEndpoint)
+	calendarFeedR.POST("/rotate/", calendarViews.RotateCalendarFeedEndpoint)
+
+	// Calendar apps cannot send a jwt so the secret in the url authenticates the feed
+	e.GET("/calendar/:secret/feed.ics", calendarViews.GetCalendarFeedICSEndpoint)
+
+	e.GET("/session/:id/calendar.ics", calendarViews.GetSessionICSEndpoint, jwtMiddleware)
+}
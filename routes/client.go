@@ -11,9 +11,10 @@ import (
 )
 
 // RegisterClientRoutes register client routes
-func RegisterClientRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, clientUseCase rfrl.ClientUseCase) {
+func RegisterClientRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, clientUseCase rfrl.ClientUseCase, privacyUseCase rfrl.PrivacyUseCase) {
 	clientView := views.ClientView{
-		ClientUseCase: clientUseCase,
+		ClientUseCase:  clientUseCase,
+		PrivacyUseCase: privacyUseCase,
 	}
 	r := e.Group("/client")
 	r.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -32,11 +33,22 @@ func RegisterClientRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.P
 	r.GET("/:clientID/verify-email/", clientView.GetVerificationEmails)
 	r.DELETE("/:clientID/verify-email/", clientView.DeleteVerifyEmail)
 
-	r.PUT("/:clientID/education/", clientView.CreateEducation)
+	r.GET("/:clientID/education/", clientView.GetEducationsEndpoint)
+	r.POST("/:clientID/education/", clientView.CreateEducationEndpoint)
+	r.PUT("/:clientID/education/:id/", clientView.UpdateEducationEndpoint)
+	r.DELETE("/:clientID/education/:id/", clientView.DeleteEducationEndpoint)
+
+	r.GET("/:clientID/work-position/", clientView.GetWorkPositionsEndpoint)
+	r.POST("/:clientID/work-position/", clientView.CreateWorkPositionEndpoint)
+	r.PUT("/:clientID/work-position/:id/", clientView.UpdateWorkPositionEndpoint)
+	r.DELETE("/:clientID/work-position/:id/", clientView.DeleteWorkPositionEndpoint)
 
 	r.PUT("/:clientID/wanting-company-referral/", clientView.CreateWantingReferralCompany)
 	r.GET("/:clientID/wanting-company-referral/", clientView.GetWantingReferralCompany)
 
+	r.PUT("/:clientID/tags/", clientView.SetClientTagsEndpoint)
+	r.GET("/:clientID/tags/", clientView.GetClientTagsEndpoint)
+
 	clientsR := e.Group("/clients")
 	clientsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
 		SigningKey:    key,
@@ -45,6 +57,7 @@ func RegisterClientRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.P
 	}))
 
 	clientsR.GET("/", clientView.GetClientsEndpoint)
+	clientsR.GET("/search/", clientView.SearchClientsEndpoint)
 
 	clientEventsR := e.Group("/client/:clientID/events")
 	clientEventsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterExternalCalendarRoutes imported calendar routes
+func RegisterExternalCalendarRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, externalCalendarUseCase rfrl.ExternalCalendarUseCase) {
+
+	externalCalendarViews := views.ExternalCalendarView{ExternalCalendarUseCase: externalCalendarUseCase}
+
+	externalCalendarR := e.Group("/external-calendar")
+	externalCalendarR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	externalCalendarR.POST("/", externalCalendarViews.ImportExternalCalendarEndpoint)
+	externalCalendarR.GET("/", externalCalendarViews.GetExternalCalendarsEndpoint)
+	externalCalendarR.POST("/:id/sync/", externalCalendarViews.SyncExternalCalendarEndpoint)
+	externalCalendarR.DELETE("/:id/", externalCalendarViews.DeleteExternalCalendarEndpoint)
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterJobPostingRoutes job posting routes
+func RegisterJobPostingRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, jobPostingUseCase rfrl.JobPostingUseCase) {
+
+	jobPostingViews := views.JobPostingView{JobPostingUseCase: jobPostingUseCase}
+
+	jobPostingR := e.Group("/job-posting")
+	jobPostingR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	jobPostingR.POST("/", jobPostingViews.CreateJobPostingEndpoint)
+	jobPostingR.GET("/", jobPostingViews.GetJobPostingsEndpoint)
+	jobPostingR.GET("/:id/", jobPostingViews.GetJobPostingEndpoint)
+	jobPostingR.PUT("/:id/", jobPostingViews.UpdateJobPostingEndpoint)
+	jobPostingR.DELETE("/:id/", jobPostingViews.DeleteJobPostingEndpoint)
+}
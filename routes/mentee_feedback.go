@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterMenteeFeedbackRoutes mentee feedback routes
+func RegisterMenteeFeedbackRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, menteeFeedbackUseCase rfrl.MenteeFeedbackUseCase) {
+
+	menteeFeedbackViews := views.MenteeFeedbackView{MenteeFeedbackUseCase: menteeFeedbackUseCase}
+
+	menteeFeedbackR := e.Group("/mentee-feedback")
+	menteeFeedbackR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	menteeFeedbackR.POST("/", menteeFeedbackViews.CreateMenteeFeedbackEndpoint)
+	menteeFeedbackR.GET("/", menteeFeedbackViews.GetReceivedMenteeFeedbacksEndpoint)
+	menteeFeedbackR.GET("/given/", menteeFeedbackViews.GetGivenMenteeFeedbacksEndpoint)
+	menteeFeedbackR.GET("/:id/", menteeFeedbackViews.GetMenteeFeedbackEndpoint)
+	menteeFeedbackR.PUT("/:id/", menteeFeedbackViews.UpdateMenteeFeedbackEndpoint)
+	menteeFeedbackR.DELETE("/:id/", menteeFeedbackViews.DeleteMenteeFeedbackEndpoint)
+}
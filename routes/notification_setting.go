@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterNotificationSettingRoutes email notification setting routes
+func RegisterNotificationSettingRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, referralNotificationUseCase rfrl.ReferralNotificationUseCase) {
+
+	notificationSettingViews := views.NotificationSettingView{ReferralNotificationUseCase: referralNotificationUseCase}
+
+	notificationSettingR := e.Group("/notification-setting")
+	notificationSettingR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	notificationSettingR.GET("/", notificationSettingViews.GetNotificationSettingEndpoint)
+	notificationSettingR.PUT("/", notificationSettingViews.UpdateNotificationSettingEndpoint)
+}
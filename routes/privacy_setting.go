@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterPrivacySettingRoutes privacy setting routes
+func RegisterPrivacySettingRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, privacyUseCase rfrl.PrivacyUseCase) {
+
+	privacySettingViews := views.PrivacySettingView{PrivacyUseCase: privacyUseCase}
+
+	privacySettingR := e.Group("/privacy-setting")
+	privacySettingR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	privacySettingR.GET("/", privacySettingViews.GetPrivacySettingEndpoint)
+	privacySettingR.PUT("/", privacySettingViews.UpdatePrivacySettingEndpoint)
+}
@@ -10,8 +10,8 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func RegisterQuestionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, questionUseCase rfrl.QuestionUseCase) {
-	questionViews := views.QuestionView{QuestionUseCase: questionUseCase}
+func RegisterQuestionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, questionUseCase rfrl.QuestionUseCase, privacyUseCase rfrl.PrivacyUseCase) {
+	questionViews := views.QuestionView{QuestionUseCase: questionUseCase, PrivacyUseCase: privacyUseCase}
 
 	questionR := e.Group("/question")
 	questionR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -24,6 +24,7 @@ func RegisterQuestionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa
 	questionR.GET("/:id/", questionViews.GetQuestionEndpoint)
 	questionR.DELETE("/:id/", questionViews.DeleteQuestionEndpoint)
 	questionR.PUT("/:id/", questionViews.UpdateQuestionEndpoint)
+	questionR.GET("/:id/tutors/", questionViews.GetSuggestedTutorsEndpoint)
 
 	questionsR := e.Group("/questions")
 	questionsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -42,4 +43,14 @@ func RegisterQuestionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa
 		Claims:        &rfrl.JWTClaims{},
 	}))
 	applyToQuestionR.POST("/", questionViews.ApplyToQuestionEndpoint)
+
+	questionApplicantsR := e.Group("/question/:id/applicants")
+	questionApplicantsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+	questionApplicantsR.GET("/", questionViews.GetQuestionApplicantsEndpoint)
+	questionApplicantsR.PUT("/:applicantID/", questionViews.UpdateQuestionApplicantEndpoint)
+	questionApplicantsR.POST("/:applicantID/accept/", questionViews.AcceptQuestionApplicantEndpoint)
 }
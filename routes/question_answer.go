@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterQuestionAnswerRoutes answers and comments on questions routes
+func RegisterQuestionAnswerRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, questionAnswerUseCase rfrl.QuestionAnswerUseCase, privacyUseCase rfrl.PrivacyUseCase) {
+	questionAnswerViews := views.QuestionAnswerView{QuestionAnswerUseCase: questionAnswerUseCase, PrivacyUseCase: privacyUseCase}
+
+	questionAnswersR := e.Group("/question/:id/answers")
+	questionAnswersR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	questionAnswersR.POST("/", questionAnswerViews.CreateQuestionAnswerEndpoint)
+	questionAnswersR.GET("/", questionAnswerViews.GetQuestionAnswersEndpoint)
+
+	questionAnswerR := e.Group("/question-answer")
+	questionAnswerR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	questionAnswerR.PUT("/:id/", questionAnswerViews.UpdateQuestionAnswerEndpoint)
+	questionAnswerR.DELETE("/:id/", questionAnswerViews.DeleteQuestionAnswerEndpoint)
+	questionAnswerR.PUT("/:id/accept/", questionAnswerViews.AcceptQuestionAnswerEndpoint)
+	questionAnswerR.DELETE("/:id/accept/", questionAnswerViews.UnacceptQuestionAnswerEndpoint)
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterReferralRoutes referral request routes
+func RegisterReferralRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, referralUseCase rfrl.ReferralUseCase) {
+
+	referralViews := views.ReferralView{ReferralUseCase: referralUseCase}
+
+	referralR := e.Group("/referral")
+	referralR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	referralR.POST("/", referralViews.CreateReferralEndpoint)
+	referralR.GET("/", referralViews.GetReferralsEndpoint)
+	referralR.GET("/:id/", referralViews.GetReferralEndpoint)
+	referralR.POST("/:id/state/", referralViews.UpdateReferralStateEndpoint)
+	referralR.POST("/:id/outcome/", referralViews.ReportReferralOutcomeEndpoint)
+
+	referralStatsR := e.Group("/referral-stats")
+	referralStatsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	referralStatsR.GET("/referrer/:clientID/", referralViews.GetReferrerProfileStatsEndpoint)
+	referralStatsR.GET("/company/:companyID/", referralViews.GetCompanyReferralStatsEndpoint)
+
+	referralLeaderboardR := e.Group("/referral-leaderboard")
+	referralLeaderboardR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	referralLeaderboardR.GET("/", referralViews.GetReferrerLeaderboardEndpoint)
+}
@@ -11,9 +11,9 @@ import (
 )
 
 // RegisterSessionRoutes session routes
-func RegisterSessionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, sessionUseCase rfrl.SessionUseCase, tutorReviewUseCase rfrl.TutorReviewUseCase) {
+func RegisterSessionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, sessionUseCase rfrl.SessionUseCase, tutorReviewUseCase rfrl.TutorReviewUseCase, privacyUseCase rfrl.PrivacyUseCase) {
 
-	sessionViews := views.SessionView{SessionUseCase: sessionUseCase, TutorReviewUseCase: tutorReviewUseCase}
+	sessionViews := views.SessionView{SessionUseCase: sessionUseCase, TutorReviewUseCase: tutorReviewUseCase, PrivacyUseCase: privacyUseCase}
 
 	sessionR := e.Group("/session")
 	sessionR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -26,6 +26,7 @@ func RegisterSessionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.
 	sessionR.PUT("/:id/", sessionViews.UpdateSessionEndpoint)
 	sessionR.DELETE("/:id/", sessionViews.DeleteSessionEndpoint)
 	sessionR.GET("/:id/", sessionViews.GetSessionEndpoint)
+	sessionR.GET("/:id/state-changes/", sessionViews.GetSessionStateChangesEndpoint)
 
 	sessionEventR := e.Group("/session/:sessionID/event")
 	sessionEventR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -38,6 +39,17 @@ func RegisterSessionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.
 	sessionEventR.GET("/:id/", sessionViews.GetSessionEventEndpoint)
 	sessionEventR.GET("/", sessionViews.GetSessionRelatedEventsEndpoint)
 
+	sessionProposalR := e.Group("/session/:sessionID/proposal")
+	sessionProposalR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	sessionProposalR.POST("/", sessionViews.CreateSessionProposalEndpoint)
+	sessionProposalR.GET("/", sessionViews.GetSessionProposalsEndpoint)
+	sessionProposalR.PUT("/:id/", sessionViews.RespondToSessionProposalEndpoint)
+
 	clientActionOnEventR := e.Group("/session/:sessionId/book")
 	clientActionOnEventR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
 		SigningKey:    key,
@@ -64,4 +76,5 @@ func RegisterSessionRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.
 	}))
 
 	sessionsR.GET("", sessionViews.GetSessionsEndpoint)
+	sessionsR.GET("free-slots/", sessionViews.FindCommonFreeSlotsEndpoint)
 }
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterSessionSeriesRoutes session series routes
+func RegisterSessionSeriesRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, sessionSeriesUseCase rfrl.SessionSeriesUseCase, sessionUseCase rfrl.SessionUseCase, privacyUseCase rfrl.PrivacyUseCase) {
+
+	sessionSeriesViews := views.SessionSeriesView{SessionSeriesUseCase: sessionSeriesUseCase, SessionUseCase: sessionUseCase, PrivacyUseCase: privacyUseCase}
+
+	sessionSeriesR := e.Group("/session-series")
+	sessionSeriesR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	sessionSeriesR.POST("/", sessionSeriesViews.CreateSessionSeriesEndpoint)
+	sessionSeriesR.GET("/:id/", sessionSeriesViews.GetSessionSeriesEndpoint)
+	sessionSeriesR.POST("/:id/cancel/", sessionSeriesViews.CancelSessionSeriesEndpoint)
+	sessionSeriesR.POST("/:seriesID/book/", sessionSeriesViews.RespondToSessionSeriesEndpoint)
+	sessionSeriesR.POST("/:seriesID/occurrence/:id/skip/", sessionSeriesViews.SkipSessionSeriesOccurrenceEndpoint)
+	sessionSeriesR.POST("/:seriesID/occurrence/:id/proposal/", sessionSeriesViews.EditSessionSeriesOccurrenceEndpoint)
+}
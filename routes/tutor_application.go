@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"crypto/rsa"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/Arun4rangan/api-rfrl/views"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RegisterTutorApplicationRoutes tutor application routes
+func RegisterTutorApplicationRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, tutorApplicationUseCase rfrl.TutorApplicationUseCase) {
+
+	tutorApplicationViews := views.TutorApplicationView{TutorApplicationUseCase: tutorApplicationUseCase}
+
+	tutorApplicationR := e.Group("/tutor-application")
+	tutorApplicationR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	tutorApplicationR.POST("/", tutorApplicationViews.SubmitTutorApplicationEndpoint)
+	tutorApplicationR.GET("/", tutorApplicationViews.GetTutorApplicationsEndpoint)
+	tutorApplicationR.GET("/:id/", tutorApplicationViews.GetTutorApplicationReviewEndpoint)
+	tutorApplicationR.PUT("/:id/", tutorApplicationViews.ReviewTutorApplicationEndpoint)
+}
@@ -10,8 +10,8 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func RegisterTutorReviewRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, tutorReviewUseCase rfrl.TutorReviewUseCase) {
-	tutorReviewView := views.TutorReviewView{TutorReviewUseCase: tutorReviewUseCase}
+func RegisterTutorReviewRoutes(e *echo.Echo, validate *validator.Validate, key *rsa.PublicKey, tutorReviewUseCase rfrl.TutorReviewUseCase, privacyUseCase rfrl.PrivacyUseCase) {
+	tutorReviewView := views.TutorReviewView{TutorReviewUseCase: tutorReviewUseCase, PrivacyUseCase: privacyUseCase}
 
 	tutorReviewR := e.Group("/tutor-review")
 	tutorReviewR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -24,6 +24,20 @@ func RegisterTutorReviewRoutes(e *echo.Echo, validate *validator.Validate, key *
 	tutorReviewR.PUT("/:id/", tutorReviewView.UpdateTutorReviewEndpoint)
 	tutorReviewR.DELETE("/:id/", tutorReviewView.DeleteTutorReviewEndpoint)
 	tutorReviewR.GET("/:id/", tutorReviewView.GetTutorReviewEndpoint)
+	tutorReviewR.POST("/:id/flag/", tutorReviewView.FlagTutorReviewEndpoint)
+	tutorReviewR.PUT("/:id/reply/", tutorReviewView.ReplyToTutorReviewEndpoint)
+	tutorReviewR.DELETE("/:id/reply/", tutorReviewView.DeleteTutorReviewReplyEndpoint)
+	tutorReviewR.GET("/:id/revisions/", tutorReviewView.GetTutorReviewRevisionsEndpoint)
+
+	tutorReviewModerationR := e.Group("/tutor-review-moderation")
+	tutorReviewModerationR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:    key,
+		SigningMethod: rfrl.AlgorithmRS256,
+		Claims:        &rfrl.JWTClaims{},
+	}))
+
+	tutorReviewModerationR.GET("/", tutorReviewView.GetFlaggedTutorReviewsEndpoint)
+	tutorReviewModerationR.PUT("/:id/", tutorReviewView.ModerateTutorReviewEndpoint)
 
 	tutorReviewsR := e.Group("/tutor-reviews")
 	tutorReviewsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -42,6 +56,8 @@ func RegisterTutorReviewRoutes(e *echo.Echo, validate *validator.Validate, key *
 	}))
 
 	tutorReviewsAggregateR.GET("/:tutorID/", tutorReviewView.GetTutorReviewsAggregateEndpoint)
+	tutorReviewsAggregateR.GET("/:tutorID/session-type/", tutorReviewView.GetTutorReviewsAggregateBySessionTypeEndpoint)
+	tutorReviewsAggregateR.GET("/:tutorID/period/", tutorReviewView.GetTutorReviewsAggregateByPeriodEndpoint)
 
 	pendingReviewsR := e.Group("/pending-tutor-reviews")
 	pendingReviewsR.Use(middleware.JWTWithConfig(middleware.JWTConfig{
@@ -51,5 +67,5 @@ func RegisterTutorReviewRoutes(e *echo.Echo, validate *validator.Validate, key *
 	}))
 
 	pendingReviewsR.GET("/", tutorReviewView.GetPendingReviewsEndpoint)
-	pendingReviewsR.DELETE("/:tutorID/", tutorReviewView.DeletePendingReviewEndpoint)
+	pendingReviewsR.DELETE("/:sessionID/", tutorReviewView.DeletePendingReviewEndpoint)
 }
@@ -0,0 +1,450 @@
+package store
+
+import (
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/pkg/errors"
+)
+
+// AccountStore holds all store related functions for exporting and deleting accounts
+type AccountStore struct{}
+
+// NewAccountStore creates new AccountStore
+func NewAccountStore() *AccountStore {
+	return &AccountStore{}
+}
+
+const createAccountDeletionQuery string = `
+INSERT INTO account_deletion (client_id, delete_after)
+VALUES ($1, $2)
+RETURNING *
+`
+
+func (as AccountStore) CreateAccountDeletion(db rfrl.DB, deletion *rfrl.AccountDeletion) (*rfrl.AccountDeletion, error) {
+	var m rfrl.AccountDeletion
+
+	err := db.QueryRowx(createAccountDeletionQuery, deletion.ClientID, deletion.DeleteAfter).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateAccountDeletion")
+}
+
+const getAccountDeletionQuery string = `
+SELECT * FROM account_deletion
+WHERE client_id = $1
+`
+
+func (as AccountStore) GetAccountDeletion(db rfrl.DB, clientID string) (*rfrl.AccountDeletion, error) {
+	var m rfrl.AccountDeletion
+
+	err := db.QueryRowx(getAccountDeletionQuery, clientID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAccountDeletion")
+	}
+
+	return &m, nil
+}
+
+// deleteAccountDeletionQuery cancels a deletion, deletions that already happened cannot be cancelled
+const deleteAccountDeletionQuery string = `
+DELETE FROM account_deletion
+WHERE client_id = $1 AND deleted_at IS NULL
+RETURNING client_id
+`
+
+func (as AccountStore) DeleteAccountDeletion(db rfrl.DB, clientID string) error {
+	var deletedID string
+
+	err := db.QueryRowx(deleteAccountDeletionQuery, clientID).Scan(&deletedID)
+
+	return errors.Wrap(err, "DeleteAccountDeletion")
+}
+
+const getDueAccountDeletionsQuery string = `
+SELECT client_id FROM account_deletion
+WHERE deleted_at IS NULL AND delete_after <= $1
+ORDER BY delete_after
+`
+
+func (as AccountStore) GetDueAccountDeletions(db rfrl.DB, now time.Time) ([]string, error) {
+	clientIDs := make([]string, 0)
+
+	rows, err := db.Queryx(getDueAccountDeletionsQuery, now)
+
+	if err != nil {
+		return clientIDs, errors.Wrap(err, "GetDueAccountDeletions")
+	}
+
+	for rows.Next() {
+		var clientID string
+		err = rows.Scan(&clientID)
+		if err != nil {
+			return clientIDs, errors.Wrap(err, "GetDueAccountDeletions")
+		}
+		clientIDs = append(clientIDs, clientID)
+	}
+
+	return clientIDs, nil
+}
+
+const getAuthMetadataQuery string = `
+SELECT created_at, updated_at, auth_type, email, sign_up_flow, blocked FROM auth
+WHERE client_id = $1
+ORDER BY created_at
+`
+
+func (as AccountStore) GetAuthMetadata(db rfrl.DB, clientID string) (*[]rfrl.AuthMetadata, error) {
+	auths := make([]rfrl.AuthMetadata, 0)
+
+	rows, err := db.Queryx(getAuthMetadataQuery, clientID)
+
+	if err != nil {
+		return &auths, errors.Wrap(err, "GetAuthMetadata")
+	}
+
+	for rows.Next() {
+		var auth rfrl.AuthMetadata
+		err = rows.StructScan(&auth)
+		if err != nil {
+			return &auths, errors.Wrap(err, "GetAuthMetadata")
+		}
+		auths = append(auths, auth)
+	}
+
+	return &auths, nil
+}
+
+const getClientDocumentsQuery string = `
+SELECT * FROM document
+WHERE client_id = $1
+ORDER BY id
+`
+
+func (as AccountStore) GetClientDocuments(db rfrl.DB, clientID string) (*[]rfrl.Document, error) {
+	documents := make([]rfrl.Document, 0)
+
+	rows, err := db.Queryx(getClientDocumentsQuery, clientID)
+
+	if err != nil {
+		return &documents, errors.Wrap(err, "GetClientDocuments")
+	}
+
+	for rows.Next() {
+		var document rfrl.Document
+		err = rows.StructScan(&document)
+		if err != nil {
+			return &documents, errors.Wrap(err, "GetClientDocuments")
+		}
+		documents = append(documents, document)
+	}
+
+	return &documents, nil
+}
+
+// getClientSessionsQuery gets the sessions the client tutored or attended
+const getClientSessionsQuery string = `
+SELECT * FROM tutor_session
+WHERE tutor_id = $1 OR id IN (
+	SELECT session_id FROM session_client
+	WHERE client_id = $1
+)
+ORDER BY id
+`
+
+func (as AccountStore) GetClientSessions(db rfrl.DB, clientID string) (*[]rfrl.Session, error) {
+	sessions := make([]rfrl.Session, 0)
+
+	rows, err := db.Queryx(getClientSessionsQuery, clientID)
+
+	if err != nil {
+		return &sessions, errors.Wrap(err, "GetClientSessions")
+	}
+
+	for rows.Next() {
+		var session rfrl.Session
+		err = rows.StructScan(&session)
+		if err != nil {
+			return &sessions, errors.Wrap(err, "GetClientSessions")
+		}
+		sessions = append(sessions, session)
+	}
+
+	return &sessions, nil
+}
+
+const getClientQuestionsQuery string = `
+SELECT * FROM question
+WHERE from_id = $1
+ORDER BY id
+`
+
+func (as AccountStore) GetClientQuestions(db rfrl.DB, clientID string) (*[]rfrl.Question, error) {
+	questions := make([]rfrl.Question, 0)
+	questionIDs := make([]int, 0)
+
+	rows, err := db.Queryx(getClientQuestionsQuery, clientID)
+
+	if err != nil {
+		return &questions, errors.Wrap(err, "GetClientQuestions")
+	}
+
+	for rows.Next() {
+		var question rfrl.Question
+		err = rows.StructScan(&question)
+		if err != nil {
+			return &questions, errors.Wrap(err, "GetClientQuestions")
+		}
+		questions = append(questions, question)
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	questionTags, err := getTagsForMultipleQuestions(db, questionIDs)
+
+	if err != nil {
+		return &questions, errors.Wrap(err, "GetClientQuestions")
+	}
+
+	for i := range questions {
+		if tags, ok := (*questionTags)[questions[i].ID]; ok {
+			questions[i].Tags = tags
+		}
+	}
+
+	return &questions, nil
+}
+
+const getClientQuestionAnswersQuery string = `
+SELECT * FROM question_answer
+WHERE from_id = $1
+ORDER BY id
+`
+
+// GetClientQuestionAnswers gets the answers and comments the client wrote, without threading them
+func (as AccountStore) GetClientQuestionAnswers(db rfrl.DB, clientID string) (*[]rfrl.QuestionAnswer, error) {
+	answers := make([]rfrl.QuestionAnswer, 0)
+
+	rows, err := db.Queryx(getClientQuestionAnswersQuery, clientID)
+
+	if err != nil {
+		return &answers, errors.Wrap(err, "GetClientQuestionAnswers")
+	}
+
+	for rows.Next() {
+		var answer rfrl.QuestionAnswer
+		err = rows.StructScan(&answer)
+		if err != nil {
+			return &answers, errors.Wrap(err, "GetClientQuestionAnswers")
+		}
+		answers = append(answers, answer)
+	}
+
+	return &answers, nil
+}
+
+const getReviewsWrittenQuery string = `
+SELECT * FROM tutor_review
+WHERE from_id = $1
+ORDER BY created_at
+`
+
+func (as AccountStore) GetReviewsWritten(db rfrl.DB, clientID string) (*[]rfrl.TutorReview, error) {
+	reviews, err := getTutorReviewsFromQuery(db, getReviewsWrittenQuery, clientID)
+
+	return reviews, errors.Wrap(err, "GetReviewsWritten")
+}
+
+const getReviewsReceivedQuery string = `
+SELECT * FROM tutor_review
+WHERE tutor_id = $1
+ORDER BY created_at
+`
+
+func (as AccountStore) GetReviewsReceived(db rfrl.DB, clientID string) (*[]rfrl.TutorReview, error) {
+	reviews, err := getTutorReviewsFromQuery(db, getReviewsReceivedQuery, clientID)
+
+	return reviews, errors.Wrap(err, "GetReviewsReceived")
+}
+
+func getTutorReviewsFromQuery(db rfrl.DB, query string, args ...interface{}) (*[]rfrl.TutorReview, error) {
+	reviews := make([]rfrl.TutorReview, 0)
+
+	rows, err := db.Queryx(query, args...)
+
+	if err != nil {
+		return &reviews, err
+	}
+
+	for rows.Next() {
+		var review rfrl.TutorReview
+		err = rows.StructScan(&review)
+		if err != nil {
+			return &reviews, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return &reviews, nil
+}
+
+const getReportsMadeQuery string = `
+SELECT id, created_at, reporter, accused, COALESCE(cause, '') AS cause, tally FROM report_client
+WHERE reporter = $1
+ORDER BY created_at
+`
+
+func (as AccountStore) GetReportsMade(db rfrl.DB, clientID string) (*[]rfrl.ReportClient, error) {
+	reports := make([]rfrl.ReportClient, 0)
+
+	rows, err := db.Queryx(getReportsMadeQuery, clientID)
+
+	if err != nil {
+		return &reports, errors.Wrap(err, "GetReportsMade")
+	}
+
+	for rows.Next() {
+		var report rfrl.ReportClient
+		err = rows.StructScan(&report)
+		if err != nil {
+			return &reports, errors.Wrap(err, "GetReportsMade")
+		}
+		reports = append(reports, report)
+	}
+
+	return &reports, nil
+}
+
+const getMenteeFeedbackGivenQuery string = `
+SELECT * FROM mentee_feedback
+WHERE tutor_id = $1
+ORDER BY id
+`
+
+func (as AccountStore) GetMenteeFeedbackGiven(db rfrl.DB, clientID string) (*[]rfrl.MenteeFeedback, error) {
+	return getAccountMenteeFeedback(db, getMenteeFeedbackGivenQuery, clientID)
+}
+
+// getMenteeFeedbackReceivedQuery only exports the feedback shared with the mentee, the rest are the tutor's notes
+const getMenteeFeedbackReceivedQuery string = `
+SELECT * FROM mentee_feedback
+WHERE mentee_id = $1 AND visible_to_mentee
+ORDER BY id
+`
+
+func (as AccountStore) GetMenteeFeedbackReceived(db rfrl.DB, clientID string) (*[]rfrl.MenteeFeedback, error) {
+	return getAccountMenteeFeedback(db, getMenteeFeedbackReceivedQuery, clientID)
+}
+
+func getAccountMenteeFeedback(db rfrl.DB, query string, clientID string) (*[]rfrl.MenteeFeedback, error) {
+	feedbacks := make([]rfrl.MenteeFeedback, 0)
+
+	rows, err := db.Queryx(query, clientID)
+
+	if err != nil {
+		return &feedbacks, errors.Wrap(err, "getAccountMenteeFeedback")
+	}
+
+	for rows.Next() {
+		var feedback rfrl.MenteeFeedback
+		err = rows.StructScan(&feedback)
+		if err != nil {
+			return &feedbacks, errors.Wrap(err, "getAccountMenteeFeedback")
+		}
+		feedbacks = append(feedbacks, feedback)
+	}
+
+	return &feedbacks, nil
+}
+
+// getCancellableSessionIDsQuery finds the client's sessions that can still be cancelled,
+// pending sessions and scheduled sessions that have not started
+const getCancellableSessionIDsQuery string = `
+SELECT tutor_session.id FROM tutor_session
+LEFT JOIN scheduled_event ON scheduled_event.id = tutor_session.event_id
+WHERE (
+	tutor_session.tutor_id = $1 OR tutor_session.id IN (
+		SELECT session_id FROM session_client
+		WHERE client_id = $1
+	)
+) AND (
+	tutor_session.state = 'pending' OR
+	(tutor_session.state = 'scheduled' AND scheduled_event.start_time > $2)
+)
+ORDER BY tutor_session.id
+`
+
+func (as AccountStore) GetCancellableSessionIDs(db rfrl.DB, clientID string, now time.Time) ([]int, error) {
+	sessionIDs := make([]int, 0)
+
+	rows, err := db.Queryx(getCancellableSessionIDsQuery, clientID, now)
+
+	if err != nil {
+		return sessionIDs, errors.Wrap(err, "GetCancellableSessionIDs")
+	}
+
+	for rows.Next() {
+		var sessionID int
+		err = rows.Scan(&sessionID)
+		if err != nil {
+			return sessionIDs, errors.Wrap(err, "GetCancellableSessionIDs")
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+
+	return sessionIDs, nil
+}
+
+// anonymizeClientQueries remove what identifies the client and keep the client row, so the reviews,
+// questions and sessions of the client stay but are shown as written by a deleted user. Every query
+// takes the client id as $1
+var anonymizeClientQueries = []string{
+	`DELETE FROM auth WHERE client_id = $1`,
+	`DELETE FROM email_verification WHERE client_id = $1`,
+	`DELETE FROM document_order WHERE ref_type = 'client' AND ref_id = $1`,
+	`DELETE FROM document_order WHERE document_id IN (SELECT id FROM document WHERE client_id = $1)`,
+	`DELETE FROM document WHERE client_id = $1`,
+	`DELETE FROM client_education WHERE client_id = $1`,
+	`DELETE FROM work_position WHERE client_id = $1`,
+	`DELETE FROM client_tags WHERE client_id = $1`,
+	`DELETE FROM client_wanting_company_referral WHERE client_id = $1`,
+	`DELETE FROM client_wanting_job_posting_referral WHERE client_id = $1`,
+	`DELETE FROM notification_setting WHERE client_id = $1`,
+	`DELETE FROM referral_notification WHERE client_id = $1 OR about_client_id = $1`,
+	`DELETE FROM client_calendar_feed WHERE client_id = $1`,
+	`DELETE FROM external_calendar WHERE client_id = $1`,
+	`DELETE FROM tutor_availability WHERE tutor_id = $1`,
+	`DELETE FROM pending_tutor_review WHERE mentee_id = $1 OR tutor_id = $1`,
+	`DELETE FROM tutor_review_flag WHERE flagged_by = $1`,
+	`DELETE FROM question_applicants WHERE applicant_id = $1`,
+	`DELETE FROM tutor_application WHERE client_id = $1`,
+	`DELETE FROM mentee_feedback WHERE mentee_id = $1`,
+	`UPDATE question SET resolved = TRUE, updated_at = CURRENT_TIMESTAMP WHERE from_id = $1 AND resolved IS NOT TRUE`,
+	`UPDATE job_posting SET posted_by = NULL WHERE posted_by = $1`,
+	`INSERT INTO privacy_setting (client_id, hide_email, hide_work_email, hide_linkedin_profile, hidden_from_search)
+	VALUES ($1, TRUE, TRUE, TRUE, TRUE)
+	ON CONFLICT (client_id) DO UPDATE
+	SET hide_email = TRUE, hide_work_email = TRUE, hide_linkedin_profile = TRUE, hidden_from_search = TRUE, updated_at = CURRENT_TIMESTAMP`,
+	`UPDATE client
+	SET first_name = '` + rfrl.DeletedClientFirstName + `', last_name = '` + rfrl.DeletedClientLastName + `',
+	about = NULL, email = NULL, work_email = NULL, company_id = NULL, photo = NULL,
+	is_tutor = FALSE, verified_work_email = FALSE, verified_email = FALSE, is_looking_for_referral = FALSE,
+	linkedin_profile = NULL, github_profile = NULL, years_of_experience = NULL, work_title = NULL,
+	updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`,
+	`UPDATE account_deletion SET deleted_at = CURRENT_TIMESTAMP WHERE client_id = $1`,
+}
+
+// AnonymizeClient deletes the personal data of the client, it should run in a transaction
+func (as AccountStore) AnonymizeClient(db rfrl.DB, clientID string) error {
+	for _, query := range anonymizeClientQueries {
+		rows, err := db.Queryx(query, clientID)
+
+		if err != nil {
+			return errors.Wrap(err, "AnonymizeClient")
+		}
+
+		rows.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package store
+
+import (
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// AvailabilityStore holds all store related function for tutor availability
+type AvailabilityStore struct{}
+
+// NewAvailabilityStore creates new AvailabilityStore
+func NewAvailabilityStore() *AvailabilityStore {
+	return &AvailabilityStore{}
+}
+
+const getTutorAvailabilityQuery string = `
+SELECT * FROM tutor_availability
+WHERE tutor_id = $1
+`
+
+const getAvailabilityWindowsQuery string = `
+SELECT * FROM tutor_availability_window
+WHERE tutor_id = $1
+ORDER BY day_of_week ASC, start_minute ASC
+`
+
+func getAvailabilityWindows(db rfrl.DB, tutorID string) ([]rfrl.AvailabilityWindow, error) {
+	windows := make([]rfrl.AvailabilityWindow, 0)
+
+	rows, err := db.Queryx(getAvailabilityWindowsQuery, tutorID)
+
+	if err != nil {
+		return windows, errors.Wrap(err, "getAvailabilityWindows")
+	}
+
+	for rows.Next() {
+		var window rfrl.AvailabilityWindow
+
+		err = rows.StructScan(&window)
+
+		if err != nil {
+			return windows, errors.Wrap(err, "getAvailabilityWindows")
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+func (as AvailabilityStore) GetTutorAvailability(db rfrl.DB, tutorID string) (*rfrl.TutorAvailability, error) {
+	var m rfrl.TutorAvailability
+
+	err := db.QueryRowx(getTutorAvailabilityQuery, tutorID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorAvailability")
+	}
+
+	m.Windows, err = getAvailabilityWindows(db, tutorID)
+
+	return &m, errors.Wrap(err, "GetTutorAvailability")
+}
+
+const getTutorAvailabilityForUpdateQuery string = `
+SELECT * FROM tutor_availability
+WHERE tutor_id = $1
+FOR UPDATE
+`
+
+func (as AvailabilityStore) GetTutorAvailabilityForUpdate(db rfrl.DB, tutorID string) (*rfrl.TutorAvailability, error) {
+	var m rfrl.TutorAvailability
+
+	err := db.QueryRowx(getTutorAvailabilityForUpdateQuery, tutorID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorAvailabilityForUpdate")
+	}
+
+	m.Windows, err = getAvailabilityWindows(db, tutorID)
+
+	return &m, errors.Wrap(err, "GetTutorAvailabilityForUpdate")
+}
+
+const upsertTutorAvailabilityQuery string = `
+INSERT INTO tutor_availability (tutor_id, timezone, slot_minutes, buffer_before_minutes, buffer_after_minutes, max_sessions_per_day)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tutor_id) DO UPDATE SET
+	timezone = EXCLUDED.timezone,
+	slot_minutes = EXCLUDED.slot_minutes,
+	buffer_before_minutes = EXCLUDED.buffer_before_minutes,
+	buffer_after_minutes = EXCLUDED.buffer_after_minutes,
+	max_sessions_per_day = EXCLUDED.max_sessions_per_day,
+	updated_at = CURRENT_TIMESTAMP
+RETURNING *
+`
+
+func (as AvailabilityStore) UpsertTutorAvailability(db rfrl.DB, availability *rfrl.TutorAvailability) (*rfrl.TutorAvailability, error) {
+	row := db.QueryRowx(
+		upsertTutorAvailabilityQuery,
+		availability.TutorID,
+		availability.Timezone,
+		availability.SlotMinutes,
+		availability.BufferBeforeMinutes,
+		availability.BufferAfterMinutes,
+		availability.MaxSessionsPerDay,
+	)
+
+	var m rfrl.TutorAvailability
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpsertTutorAvailability")
+}
+
+const deleteAvailabilityWindowsQuery string = `
+DELETE FROM tutor_availability_window
+WHERE tutor_id = $1
+`
+
+func (as AvailabilityStore) DeleteAvailabilityWindows(db rfrl.DB, tutorID string) error {
+	_, err := db.Queryx(deleteAvailabilityWindowsQuery, tutorID)
+
+	return errors.Wrap(err, "DeleteAvailabilityWindows")
+}
+
+func (as AvailabilityStore) CreateAvailabilityWindows(
+	db rfrl.DB,
+	tutorID string,
+	windows []rfrl.AvailabilityWindow,
+) (*[]rfrl.AvailabilityWindow, error) {
+	insertedWindows := make([]rfrl.AvailabilityWindow, 0)
+
+	if len(windows) == 0 {
+		return &insertedWindows, nil
+	}
+
+	query := sq.Insert("tutor_availability_window").
+		Columns("tutor_id", "day_of_week", "start_minute", "end_minute")
+
+	for i := 0; i < len(windows); i++ {
+		window := windows[i]
+		query = query.Values(tutorID, window.DayOfWeek, window.StartMinute, window.EndMinute)
+	}
+
+	sql, args, err := query.
+		Suffix("RETURNING *").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAvailabilityWindows")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAvailabilityWindows")
+	}
+
+	for rows.Next() {
+		var window rfrl.AvailabilityWindow
+
+		err = rows.StructScan(&window)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "CreateAvailabilityWindows")
+		}
+		insertedWindows = append(insertedWindows, window)
+	}
+
+	return &insertedWindows, nil
+}
+
+func (as AvailabilityStore) GetTutorSessionEvents(
+	db rfrl.DB,
+	tutorID string,
+	start time.Time,
+	end time.Time,
+) (*[]rfrl.Event, error) {
+	sql, args, err := sq.Select("scheduled_event.*").
+		From("scheduled_event").
+		Join("tutor_session ON tutor_session.event_id = scheduled_event.id").
+		Where(sq.Eq{"tutor_session.tutor_id": tutorID}).
+		Where(sq.Eq{"tutor_session.state": []rfrl.SessionState{rfrl.PENDING, rfrl.SCHEDULED}}).
+		Where(sq.Lt{"scheduled_event.start_time": end}).
+		Where(sq.Gt{"scheduled_event.end_time": start}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	events := make([]rfrl.Event, 0)
+
+	if err != nil {
+		return &events, errors.Wrap(err, "GetTutorSessionEvents")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &events, errors.Wrap(err, "GetTutorSessionEvents")
+	}
+
+	for rows.Next() {
+		var event rfrl.Event
+
+		err = rows.StructScan(&event)
+
+		if err != nil {
+			return &events, errors.Wrap(err, "GetTutorSessionEvents")
+		}
+		events = append(events, event)
+	}
+
+	return &events, nil
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// CalendarStore holds all store related function for calendar feeds
+type CalendarStore struct{}
+
+// NewCalendarStore creates new CalendarStore
+func NewCalendarStore() *CalendarStore {
+	return &CalendarStore{}
+}
+
+const getCalendarFeedQuery string = `
+SELECT * FROM client_calendar_feed
+WHERE client_id = $1
+`
+
+func (cs CalendarStore) GetCalendarFeed(db rfrl.DB, clientID string) (*rfrl.CalendarFeed, error) {
+	var m rfrl.CalendarFeed
+
+	err := db.QueryRowx(getCalendarFeedQuery, clientID).StructScan(&m)
+
+	return &m, errors.Wrap(err, "GetCalendarFeed")
+}
+
+const getCalendarFeedBySecretQuery string = `
+SELECT * FROM client_calendar_feed
+WHERE secret = $1
+`
+
+func (cs CalendarStore) GetCalendarFeedBySecret(db rfrl.DB, secret string) (*rfrl.CalendarFeed, error) {
+	var m rfrl.CalendarFeed
+
+	err := db.QueryRowx(getCalendarFeedBySecretQuery, secret).StructScan(&m)
+
+	return &m, errors.Wrap(err, "GetCalendarFeedBySecret")
+}
+
+const upsertCalendarFeedQuery string = `
+INSERT INTO client_calendar_feed (client_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (client_id) DO UPDATE SET
+	secret = EXCLUDED.secret,
+	updated_at = CURRENT_TIMESTAMP
+RETURNING *
+`
+
+func (cs CalendarStore) UpsertCalendarFeed(db rfrl.DB, clientID string, secret string) (*rfrl.CalendarFeed, error) {
+	var m rfrl.CalendarFeed
+
+	err := db.QueryRowx(upsertCalendarFeedQuery, clientID, secret).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpsertCalendarFeed")
+}
+
+const getSessionsByEventIDsQuery string = `
+SELECT tutor_session.* FROM tutor_session
+INNER JOIN session_client ON session_client.session_id = tutor_session.id
+WHERE tutor_session.event_id IN (?) AND session_client.client_id = ?
+`
+
+func (cs CalendarStore) GetSessionsByEventIDs(db rfrl.DB, clientID string, eventIDs []int) (*[]rfrl.Session, error) {
+	if len(eventIDs) == 0 {
+		return &[]rfrl.Session{}, nil
+	}
+
+	query, args, err := sqlx.In(getSessionsByEventIDsQuery, eventIDs, clientID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetSessionsByEventIDs")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetSessionsByEventIDs")
+	}
+
+	return getSessionWithClients(db, rows, clientID)
+}
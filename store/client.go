@@ -2,11 +2,11 @@ package store
 
 import (
 	"database/sql"
+	"time"
 
 	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
-	"github.com/labstack/gommon/log"
 	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
@@ -19,6 +19,155 @@ func NewClientStore() *ClientStore {
 	return &ClientStore{}
 }
 
+// clientRatingsQuery joins the rating of every tutor
+var clientRatingsQuery = `(` + tutorRatingsQuery + `) AS client_rating ON client_rating.tutor_id = client.id`
+
+// notHiddenFromSearch skips clients who hid their profile from search
+const notHiddenFromSearch string = `NOT EXISTS (
+	SELECT 1 FROM privacy_setting
+	WHERE privacy_setting.client_id = client.id AND privacy_setting.hidden_from_search
+)`
+
+// whereHasTags keeps clients having every tag, at or above the minimum proficiency when it is given
+func whereHasTags(query sq.SelectBuilder, tagIDs []int, minProficiency null.String) sq.SelectBuilder {
+	if len(tagIDs) == 0 {
+		return query
+	}
+
+	uniqueTagIDs := make(map[int]bool)
+
+	for _, tagID := range tagIDs {
+		uniqueTagIDs[tagID] = true
+	}
+
+	tagged := sq.Select("client_id").
+		From("client_tags").
+		Where(sq.Eq{"tag_id": tagIDs})
+
+	if minProficiency.Valid {
+		tagged = tagged.Where(sq.Eq{"proficiency": rfrl.ProficienciesFrom(minProficiency.String)})
+	}
+
+	tagged = tagged.
+		GroupBy("client_id").
+		Having("COUNT(*) = ?", len(uniqueTagIDs))
+
+	return query.Where(sq.Expr("client.id IN (?)", tagged))
+}
+
+// yearsOfExperienceExpr is computed from the positions when searching so that it keeps growing with
+// current positions, the stored years are only used for clients without any position
+const yearsOfExperienceExpr = "COALESCE(client_years_of_experience(client.id), client.years_of_experience)"
+
+// SearchClients ranks clients matching the query over their name, work title, company, education and about.
+// Ranks are computed so pages are found by offset
+func (cl *ClientStore) SearchClients(db rfrl.DB, options rfrl.SearchClientsOptions, page rfrl.PageOptions) (*[]rfrl.ClientSearchResult, *rfrl.Cursor, error) {
+	query := sq.Select(
+		"client.*",
+		"client_rating.average_rating",
+		"client_rating.bayesian_rating",
+		"COALESCE(client_rating.review_count, 0) AS review_count",
+	).
+		From("client").
+		LeftJoin(clientRatingsQuery)
+
+	if options.Query.Valid && options.Query.String != "" {
+		query = query.
+			Column(sq.Expr("ts_rank(client_search.document, websearch_to_tsquery('english', ?)) AS rank", options.Query.String)).
+			Join("client_search ON client_search.client_id = client.id").
+			Where("client_search.document @@ websearch_to_tsquery('english', ?)", options.Query.String)
+	} else {
+		query = query.Column("0::float AS rank")
+	}
+
+	if options.IsTutor.Valid {
+		query = query.Where(sq.Eq{"client.is_tutor": options.IsTutor.Bool})
+	}
+
+	if options.VerifiedWorkEmail.Valid {
+		query = query.Where(sq.Eq{"COALESCE(client.verified_work_email, FALSE)": options.VerifiedWorkEmail.Bool})
+	}
+
+	if len(options.CompanyIds) > 0 {
+		query = query.Where(sq.Eq{"client.company_id": options.CompanyIds})
+	}
+
+	if options.Institution.Valid {
+		query = query.Where(sq.Expr(
+			"EXISTS (?)",
+			sq.Select("1").
+				From("client_education").
+				Where("client_education.client_id = client.id").
+				Where(sq.ILike{"client_education.institution": "%" + options.Institution.String + "%"}),
+		))
+	}
+
+	if options.MinYearsOfExperience.Valid {
+		query = query.Where(sq.GtOrEq{yearsOfExperienceExpr: options.MinYearsOfExperience.Int64})
+	}
+
+	if options.MaxYearsOfExperience.Valid {
+		query = query.Where(sq.LtOrEq{yearsOfExperienceExpr: options.MaxYearsOfExperience.Int64})
+	}
+
+	query = whereHasTags(query, options.TagIds, options.MinProficiency)
+
+	if !options.IncludeHiddenFromSearch {
+		query = query.Where(notHiddenFromSearch)
+	}
+
+	if len(options.ExcludeClients) > 0 {
+		query = query.Where(sq.NotEq{"client.id": options.ExcludeClients})
+	}
+
+	switch options.Sort {
+	case rfrl.SEARCH_SORT_RATING:
+		query = query.OrderBy("client_rating.bayesian_rating DESC NULLS LAST", "review_count DESC")
+	case rfrl.SEARCH_SORT_NEWEST:
+		query = query.OrderBy("client.created_at DESC")
+	default:
+		query = query.OrderBy("rank DESC", "client.created_at DESC")
+	}
+
+	sql, args, err := query.
+		OrderBy("client.id ASC").
+		Limit(page.Limit()).
+		Offset(page.Offset()).
+		PlaceholderFormat(sq.Dollar).ToSql()
+
+	results := make([]rfrl.ClientSearchResult, 0)
+
+	if err != nil {
+		return &results, nil, errors.Wrap(err, "SearchClients")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &results, nil, errors.Wrap(err, "SearchClients")
+	}
+
+	for rows.Next() {
+		var result rfrl.ClientSearchResult
+
+		err := rows.StructScan(&result)
+
+		if err != nil {
+			return &results, nil, errors.Wrap(err, "SearchClients")
+		}
+		results = append(results, result)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(results)) {
+		results = results[:page.Size]
+		next = page.NextOffset()
+	}
+
+	return &results, next, nil
+}
+
 const (
 	getClientByIDSQL string = `
 SELECT * FROM client
@@ -30,19 +179,20 @@ WHERE client.id = $1
 `
 )
 
-func (cl *ClientStore) GetClients(db rfrl.DB, options rfrl.GetClientsOptions) (*[]rfrl.Client, error) {
+// GetClients pages through clients by id
+func (cl *ClientStore) GetClients(db rfrl.DB, options rfrl.GetClientsOptions, page rfrl.PageOptions) (*[]rfrl.Client, *rfrl.Cursor, error) {
 	query := sq.Select("client.*").From("client")
 
 	if options.IsTutor.Valid {
-		query = query.Where(sq.Eq{"is_tutor": options.IsTutor.Bool})
+		query = query.Where(sq.Eq{"client.is_tutor": options.IsTutor.Bool})
 	}
 
 	if len(options.CompanyIds) > 0 {
-		query = query.Where(sq.Eq{"company_id": options.CompanyIds})
+		query = query.Where(sq.Eq{"client.company_id": options.CompanyIds})
 	}
 
-	if options.LastTutor.Valid {
-		query = query.Where(sq.Gt{"id": options.LastTutor})
+	if page.After != nil {
+		query = query.Where(sq.Gt{"client.id": page.After.ID})
 	}
 
 	if options.WantingReferralCompanyId.Valid {
@@ -50,25 +200,36 @@ func (cl *ClientStore) GetClients(db rfrl.DB, options rfrl.GetClientsOptions) (*
 			Where(sq.Eq{"client_wanting_company_referral.company_id": options.WantingReferralCompanyId.Int64})
 	}
 
+	if options.WantingReferralJobPostingId.Valid {
+		query = query.Join("client_wanting_job_posting_referral ON client.id = client_wanting_job_posting_referral.client_id").
+			Where(sq.Eq{"client_wanting_job_posting_referral.job_posting_id": options.WantingReferralJobPostingId.Int64})
+	}
+
+	query = whereHasTags(query, options.TagIds, options.MinProficiency)
+
+	if !options.IncludeHiddenFromSearch {
+		query = query.Where(notHiddenFromSearch)
+	}
+
 	if len(options.ExcludeClients) > 0 {
-		query = query.Where(sq.NotEq{"id": options.ExcludeClients})
+		query = query.Where(sq.NotEq{"client.id": options.ExcludeClients})
 	}
 
 	sql, args, err := query.
-		OrderBy("id ASC").
-		Limit(20).
+		OrderBy("client.id ASC").
+		Limit(page.Limit()).
 		PlaceholderFormat(sq.Dollar).ToSql()
 
 	clients := make([]rfrl.Client, 0)
 
 	if err != nil {
-		return &clients, errors.Wrap(err, "GetClients")
+		return &clients, nil, errors.Wrap(err, "GetClients")
 	}
 
 	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return &clients, errors.Wrap(err, "GetClients")
+		return &clients, nil, errors.Wrap(err, "GetClients")
 	}
 
 	for rows.Next() {
@@ -77,13 +238,20 @@ func (cl *ClientStore) GetClients(db rfrl.DB, options rfrl.GetClientsOptions) (*
 		err := rows.StructScan(&client)
 
 		if err != nil {
-			return &clients, errors.Wrap(err, "GetClients")
+			return &clients, nil, errors.Wrap(err, "GetClients")
 		}
 
 		clients = append(clients, client)
 	}
 
-	return &clients, nil
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(clients)) {
+		clients = clients[:page.Size]
+		next = rfrl.NewIDCursor(clients[page.Size-1].ID)
+	}
+
+	return &clients, next, nil
 }
 
 // GetClientFromID queries the database for client with id
@@ -215,6 +383,9 @@ func (cl *ClientStore) UpdateClient(db rfrl.DB, ID string, client *rfrl.Client)
 	if client.WorkTitle.Valid {
 		query = query.Set("work_title", client.WorkTitle)
 	}
+	if client.Timezone.Valid {
+		query = query.Set("timezone", client.Timezone)
+	}
 
 	sql, args, err := query.
 		Where(sq.Eq{"id": ID}).
@@ -381,11 +552,11 @@ func (cl ClientStore) GetRelatedEventsByClientIDs(
 	clientQuery := getEventsRelatedToClientsQuery(clientIDs)
 
 	if start.Valid {
-		clientQuery = clientQuery.Where(sq.GtOrEq{"start_time": start})
+		clientQuery = clientQuery.Where(sq.GtOrEq{"scheduled_event.start_time": start})
 	}
 
 	if end.Valid {
-		clientQuery = clientQuery.Where(sq.LtOrEq{"end_time": end})
+		clientQuery = clientQuery.Where(sq.LtOrEq{"scheduled_event.end_time": end})
 	}
 
 	sql, args, err = clientQuery.PlaceholderFormat(sq.Dollar).ToSql()
@@ -414,6 +585,79 @@ func (cl ClientStore) GetRelatedEventsByClientIDs(
 	return &events, nil
 }
 
+// GetRelatedEventsPageByClientIDs pages through the session and personal events of the clients by when they start
+func (cl ClientStore) GetRelatedEventsPageByClientIDs(
+	db rfrl.DB,
+	clientIDs []string,
+	start null.Time,
+	end null.Time,
+	state null.String,
+	page rfrl.PageOptions,
+) (*[]rfrl.Event, *rfrl.Cursor, error) {
+	sessionQuery := getSessionEventsRelatedToClientsQuery(clientIDs)
+	clientQuery := getEventsRelatedToClientsQuery(clientIDs)
+
+	if start.Valid {
+		sessionQuery = sessionQuery.Where(sq.GtOrEq{"scheduled_event.start_time": start})
+		clientQuery = clientQuery.Where(sq.GtOrEq{"scheduled_event.start_time": start})
+	}
+
+	if end.Valid {
+		sessionQuery = sessionQuery.Where(sq.LtOrEq{"scheduled_event.end_time": end})
+		clientQuery = clientQuery.Where(sq.LtOrEq{"scheduled_event.end_time": end})
+	}
+
+	if state.Valid {
+		sessionQuery = sessionQuery.Where(sq.Eq{"tutor_session.state": state})
+	}
+
+	events := make([]rfrl.Event, 0)
+
+	query, err := applyStartTimePage(
+		sq.Select("*").FromSelect(sessionQuery.SuffixExpr(sq.Expr("UNION ?", clientQuery)), "related_event"),
+		page,
+		"start_time",
+		"id",
+	)
+
+	if err != nil {
+		return &events, nil, errors.Wrap(err, "GetRelatedEventsPageByClientIDs")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &events, nil, errors.Wrap(err, "GetRelatedEventsPageByClientIDs")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &events, nil, errors.Wrap(err, "GetRelatedEventsPageByClientIDs")
+	}
+
+	for rows.Next() {
+		var event rfrl.Event
+
+		err = rows.StructScan(&event)
+
+		if err != nil {
+			return &events, nil, errors.Wrap(err, "GetRelatedEventsPageByClientIDs")
+		}
+		events = append(events, event)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(events)) {
+		events = events[:page.Size]
+		last := events[page.Size-1]
+		next = rfrl.NewTimeCursor(last.StartTime, last.ID)
+	}
+
+	return &events, next, nil
+}
+
 func getSessionEventsRelatedToClientsQuery(clientIDs []string) sq.SelectBuilder {
 	return sq.Select("scheduled_event.*").
 		From("scheduled_event").
@@ -423,107 +667,169 @@ func getSessionEventsRelatedToClientsQuery(clientIDs []string) sq.SelectBuilder
 }
 
 func getEventsRelatedToClientsQuery(clientIDs []string) sq.SelectBuilder {
-	return sq.Select("*").
+	return sq.Select("scheduled_event.*").
 		From("scheduled_event").
 		Join("client_event ON client_event.event_id = scheduled_event.id").
 		Where(sq.Eq{"client_event.client_id": clientIDs})
 }
 
-func checkOverlapingSessionEvents(db rfrl.DB, clientIDs []string, events *[]rfrl.Event) (bool, error) {
-	query := getSessionEventsRelatedToClientsQuery(clientIDs).
-		Where(sq.Eq{"tutor_session.state": rfrl.SCHEDULED}).
-		Prefix("SELECT EXISTS(")
+func getEventConflicts(db rfrl.DB, query sq.SelectBuilder) ([]rfrl.EventConflict, error) {
+	conflicts := make([]rfrl.EventConflict, 0)
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 
-	query = filterInclusiveDateRange(query, events)
+	if err != nil {
+		return conflicts, errors.Wrap(err, "getEventConflicts")
+	}
 
-	sql, args, err := query.Suffix(")").PlaceholderFormat(sq.Dollar).ToSql()
+	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return true, errors.Wrap(err, "checkOverlapingSessionEvents")
+		return conflicts, errors.Wrap(err, "getEventConflicts")
 	}
 
-	m := false
+	for rows.Next() {
+		var conflict rfrl.EventConflict
 
-	row := db.QueryRowx(sql, args...)
-	err = row.Scan(&m)
+		err = rows.StructScan(&conflict)
 
-	return m, errors.Wrap(err, "checkOverlapingSessionEvents")
+		if err != nil {
+			return conflicts, errors.Wrap(err, "getEventConflicts")
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, nil
 }
 
-func checkOverlapingClientEvents(db rfrl.DB, clientIDs []string, events *[]rfrl.Event) (bool, error) {
-	query := getEventsRelatedToClientsQuery(clientIDs).
-		Prefix("SELECT EXISTS(")
+func getOverlapingSessionEvents(db rfrl.DB, clientIDs []string, events *[]rfrl.Event, excludeSessionIDs []int) ([]rfrl.EventConflict, error) {
+	query := sq.Select(
+		"scheduled_event.*",
+		"session_client.client_id AS client_id",
+		"tutor_session.id AS session_id",
+		"FALSE AS external",
+	).
+		From("scheduled_event").
+		Join("tutor_session ON tutor_session.event_id = scheduled_event.id").
+		Join("session_client ON session_client.session_id = tutor_session.id").
+		Where(sq.Eq{"session_client.client_id": clientIDs}).
+		Where(sq.Eq{"tutor_session.state": rfrl.SCHEDULED})
 
-	query = filterInclusiveDateRange(query, events)
+	if len(excludeSessionIDs) > 0 {
+		query = query.Where(sq.NotEq{"tutor_session.id": excludeSessionIDs})
+	}
 
-	sql, args, err := query.Suffix(")").PlaceholderFormat(sq.Dollar).ToSql()
+	conflicts, err := getEventConflicts(db, filterInclusiveDateRange(query, events))
 
-	if err != nil {
-		return true, errors.Wrap(err, "checkOverlapingClientEvents")
-	}
+	return conflicts, errors.Wrap(err, "getOverlapingSessionEvents")
+}
+
+func getOverlapingClientEvents(db rfrl.DB, clientIDs []string, events *[]rfrl.Event) ([]rfrl.EventConflict, error) {
+	query := sq.Select(
+		"scheduled_event.*",
+		"client_event.client_id AS client_id",
+		"NULL AS session_id",
+		"FALSE AS external",
+	).
+		From("scheduled_event").
+		Join("client_event ON client_event.event_id = scheduled_event.id").
+		Where(sq.Eq{"client_event.client_id": clientIDs})
+
+	conflicts, err := getEventConflicts(db, filterInclusiveDateRange(query, events))
+
+	return conflicts, errors.Wrap(err, "getOverlapingClientEvents")
+}
+
+// externalBusyEventColumns hides imported busy blocks behind the shape of an untitled event
+var externalBusyEventColumns = []string{
+	"0 AS id",
+	"created_at",
+	"created_at AS updated_at",
+	"start_time",
+	"end_time",
+	"NULL AS title",
+}
 
-	m := false
+func getOverlapingExternalEvents(db rfrl.DB, clientIDs []string, events *[]rfrl.Event) ([]rfrl.EventConflict, error) {
+	query := sq.Select(externalBusyEventColumns...).
+		Columns(
+			"client_id",
+			"NULL AS session_id",
+			"TRUE AS external",
+		).
+		From("external_busy_event").
+		Where(sq.Eq{"client_id": clientIDs})
 
-	row := db.QueryRowx(sql, args...)
-	err = row.Scan(&m)
+	conflicts, err := getEventConflicts(db, filterInclusiveDateRange(query, events))
 
-	return m, errors.Wrap(err, "checkOverlapingClientsEvents")
+	return conflicts, errors.Wrap(err, "getOverlapingExternalEvents")
 }
 
-func (cl ClientStore) CheckOverlapingEventsByClientIDs(db rfrl.DB, clientIds []string, events *[]rfrl.Event) (bool, error) {
-	clientOverlap, err := checkOverlapingClientEvents(db, clientIds, events)
+// GetOverlapingEventsByClientIDs returns every existing event of the clients that overlaps with events
+func (cl ClientStore) GetOverlapingEventsByClientIDs(db rfrl.DB, clientIds []string, events *[]rfrl.Event, excludeSessionIDs []int) (*[]rfrl.EventConflict, error) {
+	clientConflicts, err := getOverlapingClientEvents(db, clientIds, events)
 
 	if err != nil {
-		return true, errors.Wrap(err, "CheckOverlappingEventsByClientIDs")
+		return nil, errors.Wrap(err, "GetOverlapingEventsByClientIDs")
 	}
-	sessionOverlap, err := checkOverlapingSessionEvents(db, clientIds, events)
+
+	sessionConflicts, err := getOverlapingSessionEvents(db, clientIds, events, excludeSessionIDs)
 
 	if err != nil {
-		return true, errors.Wrap(err, "CheckOverlappingEventsByClientIDs")
+		return nil, errors.Wrap(err, "GetOverlapingEventsByClientIDs")
 	}
 
-	log.Errorj(log.JSON{
-		"clientOverlap":  clientOverlap,
-		"sessionOverlap": sessionOverlap,
-	})
+	externalConflicts, err := getOverlapingExternalEvents(db, clientIds, events)
 
-	return clientOverlap || sessionOverlap, nil
-}
+	if err != nil {
+		return nil, errors.Wrap(err, "GetOverlapingEventsByClientIDs")
+	}
 
-func (cl ClientStore) CreateOrUpdateClientEducation(db rfrl.DB, clientID string, education rfrl.Education) error {
-	query := sq.Update("client")
+	conflicts := append(clientConflicts, sessionConflicts...)
+	conflicts = append(conflicts, externalConflicts...)
 
-	if education.Institution.Valid {
-		query = query.Set("institution", education.Institution)
-	}
-	if education.Degree.Valid {
-		query = query.Set("degree", education.Degree)
-	}
-	if education.FieldOfStudy.Valid {
-		query = query.Set("field_of_study", education.FieldOfStudy)
-	}
-	if education.StartYear.Valid {
-		query = query.Set("start_year", education.StartYear)
-	}
-	if education.EndYear.Valid {
-		query = query.Set("end_year", education.EndYear)
-	}
+	return &conflicts, nil
+}
 
-	sql, args, err := query.
-		Where(sq.Eq{"id": clientID}).
+// GetExternalBusyEventsByClientIDs returns the imported busy blocks of the clients between start and end
+func (cl ClientStore) GetExternalBusyEventsByClientIDs(
+	db rfrl.DB,
+	clientIDs []string,
+	start time.Time,
+	end time.Time,
+) (*[]rfrl.Event, error) {
+	sql, args, err := sq.Select(externalBusyEventColumns...).
+		From("external_busy_event").
+		Where(sq.Eq{"client_id": clientIDs}).
+		Where(sq.Lt{"start_time": end}).
+		Where(sq.Gt{"end_time": start}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
+	events := make([]rfrl.Event, 0)
+
 	if err != nil {
-		return errors.Wrap(err, "CheckOverlappingEventsByClientIDs")
+		return &events, errors.Wrap(err, "GetExternalBusyEventsByClientIDs")
 	}
 
-	_, err = db.Queryx(
-		sql,
-		args...,
-	)
+	rows, err := db.Queryx(sql, args...)
 
-	return errors.Wrap(err, "CheckOverlappingEventsByClientIDs")
+	if err != nil {
+		return &events, errors.Wrap(err, "GetExternalBusyEventsByClientIDs")
+	}
+
+	for rows.Next() {
+		var event rfrl.Event
+
+		err = rows.StructScan(&event)
+
+		if err != nil {
+			return &events, errors.Wrap(err, "GetExternalBusyEventsByClientIDs")
+		}
+		events = append(events, event)
+	}
+
+	return &events, nil
 }
 
 const deleteClientWantingCompanyReferralsSQuery string = `
@@ -583,3 +889,139 @@ func (cl ClientStore) GetClientWantingCompanyReferrals(db rfrl.DB, clientID stri
 
 	return companyIDs, nil
 }
+
+const deleteClientWantingJobPostingReferralsQuery string = `
+DELETE FROM client_wanting_job_posting_referral WHERE client_id = $1
+`
+
+func (cl ClientStore) CreateClientWantingJobPostingReferrals(db rfrl.DB, clientID string, jobPostingIDs []int) error {
+	rows, err := db.Queryx(deleteClientWantingJobPostingReferralsQuery, clientID)
+
+	if err != nil {
+		return errors.Wrap(err, "CreateClientWantingJobPostingReferrals")
+	}
+
+	rows.Close()
+
+	if len(jobPostingIDs) == 0 {
+		return nil
+	}
+
+	query := sq.Insert("client_wanting_job_posting_referral").
+		Columns("client_id", "job_posting_id")
+
+	for i := 0; i < len(jobPostingIDs); i++ {
+		query = query.Values(clientID, jobPostingIDs[i])
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return errors.Wrap(err, "CreateClientWantingJobPostingReferrals")
+	}
+
+	rows, err = db.Queryx(sql, args...)
+
+	if err != nil {
+		return errors.Wrap(err, "CreateClientWantingJobPostingReferrals")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const getClientWantingJobPostingReferralsQuery string = `
+SELECT job_posting_id FROM client_wanting_job_posting_referral WHERE client_id = $1
+`
+
+func (cl ClientStore) GetClientWantingJobPostingReferrals(db rfrl.DB, clientID string) ([]int, error) {
+	jobPostingIDs := make([]int, 0)
+
+	rows, err := db.Queryx(getClientWantingJobPostingReferralsQuery, clientID)
+
+	if err != nil {
+		return jobPostingIDs, errors.Wrap(err, "GetClientWantingJobPostingReferrals")
+	}
+
+	for rows.Next() {
+		var jobPostingID int
+		err = rows.Scan(&jobPostingID)
+		if err != nil {
+			return jobPostingIDs, errors.Wrap(err, "GetClientWantingJobPostingReferrals")
+		}
+		jobPostingIDs = append(jobPostingIDs, jobPostingID)
+	}
+
+	return jobPostingIDs, nil
+}
+
+const deleteClientTagsQuery string = `
+DELETE FROM client_tags WHERE client_id = $1
+`
+
+// SetClientTags replaces every tag of the client
+func (cl ClientStore) SetClientTags(db rfrl.DB, clientID string, tags []rfrl.ClientTag) error {
+	rows, err := db.Queryx(deleteClientTagsQuery, clientID)
+
+	if err != nil {
+		return errors.Wrap(err, "SetClientTags")
+	}
+
+	rows.Close()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := sq.Insert("client_tags").
+		Columns("client_id", "tag_id", "proficiency")
+
+	for i := 0; i < len(tags); i++ {
+		query = query.Values(clientID, tags[i].ID, tags[i].Proficiency)
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return errors.Wrap(err, "SetClientTags")
+	}
+
+	rows, err = db.Queryx(sql, args...)
+
+	if err != nil {
+		return errors.Wrap(err, "SetClientTags")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const getClientTagsQuery string = `
+SELECT tags.*, client_tags.proficiency FROM client_tags
+INNER JOIN tags ON client_tags.tag_id = tags.id
+WHERE client_tags.client_id = $1
+ORDER BY tags.tag_name
+`
+
+func (cl ClientStore) GetClientTags(db rfrl.DB, clientID string) (*[]rfrl.ClientTag, error) {
+	tags := make([]rfrl.ClientTag, 0)
+
+	rows, err := db.Queryx(getClientTagsQuery, clientID)
+
+	if err != nil {
+		return &tags, errors.Wrap(err, "GetClientTags")
+	}
+
+	for rows.Next() {
+		var tag rfrl.ClientTag
+		err = rows.StructScan(&tag)
+		if err != nil {
+			return &tags, errors.Wrap(err, "GetClientTags")
+		}
+		tags = append(tags, tag)
+	}
+
+	return &tags, nil
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+const createEducationQuery string = `
+INSERT INTO client_education (client_id, institution, degree, field_of_study, start_year, end_year)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *
+`
+
+func (cl ClientStore) CreateEducation(db rfrl.DB, education *rfrl.Education) (*rfrl.Education, error) {
+	var m rfrl.Education
+
+	err := db.QueryRowx(
+		createEducationQuery,
+		education.ClientID,
+		education.Institution,
+		education.Degree,
+		education.FieldOfStudy,
+		education.StartYear,
+		education.EndYear,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateEducation")
+}
+
+// getEducationsQuery lists the ongoing and latest education first
+const getEducationsQuery string = `
+SELECT * FROM client_education
+WHERE client_id = $1
+ORDER BY end_year DESC NULLS FIRST, start_year DESC NULLS LAST, id DESC
+`
+
+func (cl ClientStore) GetEducations(db rfrl.DB, clientID string) (*[]rfrl.Education, error) {
+	educations := make([]rfrl.Education, 0)
+
+	rows, err := db.Queryx(getEducationsQuery, clientID)
+
+	if err != nil {
+		return &educations, errors.Wrap(err, "GetEducations")
+	}
+
+	for rows.Next() {
+		var education rfrl.Education
+		err = rows.StructScan(&education)
+		if err != nil {
+			return &educations, errors.Wrap(err, "GetEducations")
+		}
+		educations = append(educations, education)
+	}
+
+	return &educations, nil
+}
+
+const updateEducationQuery string = `
+UPDATE client_education
+SET institution = $3, degree = $4, field_of_study = $5, start_year = $6, end_year = $7, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND client_id = $2
+RETURNING *
+`
+
+func (cl ClientStore) UpdateEducation(db rfrl.DB, education *rfrl.Education) (*rfrl.Education, error) {
+	var m rfrl.Education
+
+	err := db.QueryRowx(
+		updateEducationQuery,
+		education.ID,
+		education.ClientID,
+		education.Institution,
+		education.Degree,
+		education.FieldOfStudy,
+		education.StartYear,
+		education.EndYear,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateEducation")
+}
+
+const deleteEducationQuery string = `
+DELETE FROM client_education
+WHERE id = $1 AND client_id = $2
+RETURNING id
+`
+
+func (cl ClientStore) DeleteEducation(db rfrl.DB, clientID string, ID int) error {
+	var deletedID int
+
+	err := db.QueryRowx(deleteEducationQuery, ID, clientID).Scan(&deletedID)
+
+	return errors.Wrap(err, "DeleteEducation")
+}
+
+const createWorkPositionQuery string = `
+INSERT INTO work_position (client_id, company_id, company_name, title, start_date, end_date, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *
+`
+
+func (cl ClientStore) CreateWorkPosition(db rfrl.DB, position *rfrl.WorkPosition) (*rfrl.WorkPosition, error) {
+	var m rfrl.WorkPosition
+
+	err := db.QueryRowx(
+		createWorkPositionQuery,
+		position.ClientID,
+		position.CompanyID,
+		position.CompanyName,
+		position.Title,
+		position.StartDate,
+		position.EndDate,
+		position.Description,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateWorkPosition")
+}
+
+// getWorkPositionsQuery lists current positions first and then the most recent ones
+const getWorkPositionsQuery string = `
+SELECT * FROM work_position
+WHERE client_id = $1
+ORDER BY end_date DESC NULLS FIRST, start_date DESC, id DESC
+`
+
+func (cl ClientStore) GetWorkPositions(db rfrl.DB, clientID string) (*[]rfrl.WorkPosition, error) {
+	positions := make([]rfrl.WorkPosition, 0)
+
+	rows, err := db.Queryx(getWorkPositionsQuery, clientID)
+
+	if err != nil {
+		return &positions, errors.Wrap(err, "GetWorkPositions")
+	}
+
+	for rows.Next() {
+		var position rfrl.WorkPosition
+		err = rows.StructScan(&position)
+		if err != nil {
+			return &positions, errors.Wrap(err, "GetWorkPositions")
+		}
+		positions = append(positions, position)
+	}
+
+	return &positions, nil
+}
+
+const updateWorkPositionQuery string = `
+UPDATE work_position
+SET company_id = $3, company_name = $4, title = $5, start_date = $6, end_date = $7, description = $8, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND client_id = $2
+RETURNING *
+`
+
+func (cl ClientStore) UpdateWorkPosition(db rfrl.DB, position *rfrl.WorkPosition) (*rfrl.WorkPosition, error) {
+	var m rfrl.WorkPosition
+
+	err := db.QueryRowx(
+		updateWorkPositionQuery,
+		position.ID,
+		position.ClientID,
+		position.CompanyID,
+		position.CompanyName,
+		position.Title,
+		position.StartDate,
+		position.EndDate,
+		position.Description,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateWorkPosition")
+}
+
+const deleteWorkPositionQuery string = `
+DELETE FROM work_position
+WHERE id = $1 AND client_id = $2
+RETURNING id
+`
+
+func (cl ClientStore) DeleteWorkPosition(db rfrl.DB, clientID string, ID int) error {
+	var deletedID int
+
+	err := db.QueryRowx(deleteWorkPositionQuery, ID, clientID).Scan(&deletedID)
+
+	return errors.Wrap(err, "DeleteWorkPosition")
+}
+
+const updateYearsOfExperienceQuery string = `
+UPDATE client
+SET years_of_experience = $2
+WHERE id = $1
+`
+
+func (cl ClientStore) UpdateYearsOfExperience(db rfrl.DB, clientID string, years null.Int) error {
+	rows, err := db.Queryx(updateYearsOfExperienceQuery, clientID, years)
+
+	if err != nil {
+		return errors.Wrap(err, "UpdateYearsOfExperience")
+	}
+
+	rows.Close()
+
+	return nil
+}
@@ -146,29 +146,45 @@ func (cs *CompanyStore) UpdateOrCreateCompanyEmail(db rfrl.DB, name null.String,
 	return errors.Wrap(err, "UpdateOrCreateCompanyEmail")
 }
 
-const getCompanies string = `
-SELECT * FROM company WHERE active = $1
-ORDER BY id
-`
-
-func (cs *CompanyStore) GetCompanies(db rfrl.DB, active bool) (*[]rfrl.Company, error) {
+// GetCompanies pages through companies by id
+func (cs *CompanyStore) GetCompanies(db rfrl.DB, active bool, page rfrl.PageOptions) (*[]rfrl.Company, *rfrl.Cursor, error) {
 	companies := make([]rfrl.Company, 0)
 
-	rows, err := db.Queryx(getCompanies, active)
+	query, err := applyIDPage(sq.Select("*").From("company").Where(sq.Eq{"active": active}), page, "id", false)
+
+	if err != nil {
+		return &companies, nil, errors.Wrap(err, "GetCompanies")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &companies, nil, errors.Wrap(err, "GetCompanies")
+	}
+
+	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return &companies, errors.Wrap(err, "GetCompanies")
+		return &companies, nil, errors.Wrap(err, "GetCompanies")
 	}
 
 	for rows.Next() {
 		var company rfrl.Company
 		err := rows.StructScan(&company)
 		if err != nil {
-			return &companies, errors.Wrap(err, "GetCompanies")
+			return &companies, nil, errors.Wrap(err, "GetCompanies")
 		}
 		companies = append(companies, company)
 	}
-	return &companies, nil
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(companies)) {
+		companies = companies[:page.Size]
+		next = rfrl.NewSerialIDCursor(companies[page.Size-1].ID)
+	}
+
+	return &companies, next, nil
 }
 
 const getCompanyIDFromEmailDomainQuery string = `
@@ -198,7 +214,8 @@ func (cs *CompanyStore) GetCompany(db rfrl.DB, id int) (*rfrl.Company, error) {
 	return &company, errors.Wrap(err, "GetCompany")
 }
 
-func (cs *CompanyStore) GetCompanyEmails(db rfrl.DB, withCompany null.Bool) (*[]rfrl.CompanyEmailDomain, error) {
+// GetCompanyEmails pages through email domains alphabetically
+func (cs *CompanyStore) GetCompanyEmails(db rfrl.DB, withCompany null.Bool, page rfrl.PageOptions) (*[]rfrl.CompanyEmailDomain, *rfrl.Cursor, error) {
 	companyEmails := make([]rfrl.CompanyEmailDomain, 0)
 
 	query := sq.Select("*").From("company_email")
@@ -211,16 +228,23 @@ func (cs *CompanyStore) GetCompanyEmails(db rfrl.DB, withCompany null.Bool) (*[]
 		query = query.Where(sq.Eq{"company_id": nil})
 	}
 
-	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if page.After != nil {
+		query = query.Where(sq.Gt{"email_domain": page.After.ID})
+	}
+
+	sql, args, err := query.
+		OrderBy("email_domain ASC").
+		Limit(page.Limit()).
+		PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
-		return &companyEmails, errors.Wrap(err, "GetCompanyEmails")
+		return &companyEmails, nil, errors.Wrap(err, "GetCompanyEmails")
 	}
 
 	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return &companyEmails, errors.Wrap(err, "GetCompanyEmails")
+		return &companyEmails, nil, errors.Wrap(err, "GetCompanyEmails")
 	}
 
 	for rows.Next() {
@@ -229,13 +253,20 @@ func (cs *CompanyStore) GetCompanyEmails(db rfrl.DB, withCompany null.Bool) (*[]
 		err = rows.StructScan(&companyEmail)
 
 		if err != nil {
-			return &companyEmails, errors.Wrap(err, "GetCompanyEmails")
+			return &companyEmails, nil, errors.Wrap(err, "GetCompanyEmails")
 		}
 
 		companyEmails = append(companyEmails, companyEmail)
 	}
 
-	return &companyEmails, nil
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(companyEmails)) {
+		companyEmails = companyEmails[:page.Size]
+		next = rfrl.NewIDCursor(companyEmails[page.Size-1].EmailDomain)
+	}
+
+	return &companyEmails, next, nil
 }
 
 const getCompanyEmailQuery string = `
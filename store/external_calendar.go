@@ -0,0 +1,169 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// ExternalCalendarStore holds all store related functions for imported calendars
+type ExternalCalendarStore struct{}
+
+// NewExternalCalendarStore creates new ExternalCalendarStore
+func NewExternalCalendarStore() *ExternalCalendarStore {
+	return &ExternalCalendarStore{}
+}
+
+const createExternalCalendarQuery string = `
+INSERT INTO external_calendar (client_id, name, url)
+VALUES ($1, $2, $3)
+RETURNING *
+`
+
+func (ecs ExternalCalendarStore) CreateExternalCalendar(db rfrl.DB, calendar *rfrl.ExternalCalendar) (*rfrl.ExternalCalendar, error) {
+	var m rfrl.ExternalCalendar
+
+	err := db.QueryRowx(
+		createExternalCalendarQuery,
+		calendar.ClientID,
+		calendar.Name,
+		calendar.URL,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateExternalCalendar")
+}
+
+const getExternalCalendarsQuery string = `
+SELECT external_calendar.*, COUNT(external_busy_event.id) AS busy_count
+FROM external_calendar
+LEFT JOIN external_busy_event ON external_busy_event.calendar_id = external_calendar.id
+WHERE external_calendar.client_id = $1
+GROUP BY external_calendar.id
+ORDER BY external_calendar.created_at ASC
+`
+
+func (ecs ExternalCalendarStore) GetExternalCalendars(db rfrl.DB, clientID string) (*[]rfrl.ExternalCalendar, error) {
+	calendars := make([]rfrl.ExternalCalendar, 0)
+
+	rows, err := db.Queryx(getExternalCalendarsQuery, clientID)
+
+	if err != nil {
+		return &calendars, errors.Wrap(err, "GetExternalCalendars")
+	}
+
+	for rows.Next() {
+		var calendar rfrl.ExternalCalendar
+
+		err = rows.StructScan(&calendar)
+
+		if err != nil {
+			return &calendars, errors.Wrap(err, "GetExternalCalendars")
+		}
+		calendars = append(calendars, calendar)
+	}
+
+	return &calendars, nil
+}
+
+const getExternalCalendarQuery string = `
+SELECT external_calendar.*, COUNT(external_busy_event.id) AS busy_count
+FROM external_calendar
+LEFT JOIN external_busy_event ON external_busy_event.calendar_id = external_calendar.id
+WHERE external_calendar.client_id = $1 AND external_calendar.id = $2
+GROUP BY external_calendar.id
+`
+
+func (ecs ExternalCalendarStore) GetExternalCalendar(db rfrl.DB, clientID string, ID int) (*rfrl.ExternalCalendar, error) {
+	var m rfrl.ExternalCalendar
+
+	err := db.QueryRowx(getExternalCalendarQuery, clientID, ID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetExternalCalendar")
+	}
+
+	return &m, nil
+}
+
+const deleteExternalCalendarQuery string = `
+DELETE FROM external_calendar
+WHERE client_id = $1 AND id = $2
+RETURNING id
+`
+
+func (ecs ExternalCalendarStore) DeleteExternalCalendar(db rfrl.DB, clientID string, ID int) error {
+	var deletedID int
+
+	err := db.QueryRowx(deleteExternalCalendarQuery, clientID, ID).Scan(&deletedID)
+
+	return errors.Wrap(err, "DeleteExternalCalendar")
+}
+
+const markExternalCalendarSyncedQuery string = `
+UPDATE external_calendar
+SET last_synced_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (ecs ExternalCalendarStore) MarkExternalCalendarSynced(db rfrl.DB, ID int) (*rfrl.ExternalCalendar, error) {
+	var m rfrl.ExternalCalendar
+
+	err := db.QueryRowx(markExternalCalendarSyncedQuery, ID).StructScan(&m)
+
+	return &m, errors.Wrap(err, "MarkExternalCalendarSynced")
+}
+
+const deleteExternalBusyEventsQuery string = `
+DELETE FROM external_busy_event
+WHERE calendar_id = $1
+`
+
+// externalBusyEventsBatchSize keeps each insert of busy blocks well under the bind parameter limit of postgres
+const externalBusyEventsBatchSize = 1000
+
+// ReplaceExternalBusyEvents swaps every busy block of the calendar with events
+func (ecs ExternalCalendarStore) ReplaceExternalBusyEvents(
+	db rfrl.DB,
+	calendar rfrl.ExternalCalendar,
+	events []rfrl.ExternalBusyEvent,
+) error {
+	rows, err := db.Queryx(deleteExternalBusyEventsQuery, calendar.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "ReplaceExternalBusyEvents")
+	}
+
+	rows.Close()
+
+	for start := 0; start < len(events); start += externalBusyEventsBatchSize {
+		end := start + externalBusyEventsBatchSize
+
+		if end > len(events) {
+			end = len(events)
+		}
+
+		query := sq.Insert("external_busy_event").
+			Columns("calendar_id", "client_id", "start_time", "end_time")
+
+		for i := start; i < end; i++ {
+			query = query.Values(calendar.ID, calendar.ClientID, events[i].StartTime, events[i].EndTime)
+		}
+
+		sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+		if err != nil {
+			return errors.Wrap(err, "ReplaceExternalBusyEvents")
+		}
+
+		rows, err = db.Queryx(sql, args...)
+
+		if err != nil {
+			return errors.Wrap(err, "ReplaceExternalBusyEvents")
+		}
+
+		rows.Close()
+	}
+
+	return nil
+}
@@ -155,3 +155,98 @@ func (fs *FireStoreClient) UpdateClient(id string, photo null.String, firstName
 	)
 	return errors.Wrap(err, "UpdateClient")
 }
+
+// DeleteClient removes the client's user doc, their messages and their firebase login. The client leaves
+// their chat rooms, which are only deleted once nobody but support is left in them. Documents that are
+// already gone are skipped so a failed deletion can be retried
+func (fs *FireStoreClient) DeleteClient(id string) error {
+	ctx := context.Background()
+
+	rooms, err := fs.RoomsRef.Where("users", "array-contains", id).Documents(ctx).GetAll()
+
+	if err != nil {
+		return errors.Wrap(err, "DeleteClient")
+	}
+
+	for _, room := range rooms {
+		err = fs.leaveRoom(ctx, room, id)
+
+		if err != nil {
+			return errors.Wrap(err, "DeleteClient")
+		}
+	}
+
+	_, err = fs.UserRef.Doc(id).Delete(ctx)
+
+	if err != nil {
+		return errors.Wrap(err, "DeleteClient")
+	}
+
+	err = fs.Auth.DeleteUser(ctx, id)
+
+	if err != nil && !auth.IsUserNotFound(err) {
+		return errors.Wrap(err, "DeleteClient")
+	}
+
+	return nil
+}
+
+// leaveRoom deletes the messages the client sent in the room and removes them from its users. The room
+// is deleted when the client was the last participant, support is not counted
+func (fs *FireStoreClient) leaveRoom(ctx context.Context, room *firestore.DocumentSnapshot, id string) error {
+	messages := room.Ref.Collection("messages")
+
+	err := fs.deleteQuery(ctx, messages.Where("senderId", "==", id))
+
+	if err != nil {
+		return err
+	}
+
+	var data Room
+
+	if err = room.DataTo(&data); err != nil {
+		return err
+	}
+
+	participants := 0
+
+	for _, user := range data.Users {
+		if user != id && user != fs.SupportUserId {
+			participants++
+		}
+	}
+
+	if participants > 0 {
+		_, err = room.Ref.Update(ctx, []firestore.Update{{Path: "users", Value: firestore.ArrayRemove(id)}})
+		return err
+	}
+
+	err = fs.deleteQuery(ctx, messages.Query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = room.Ref.Delete(ctx)
+
+	return err
+}
+
+// deleteQuery deletes every document the query matches, firestore does not delete sub collections with their parent
+func (fs *FireStoreClient) deleteQuery(ctx context.Context, query firestore.Query) error {
+	docs, err := query.Documents(ctx).GetAll()
+
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		_, err = doc.Ref.Delete(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// JobPostingStore holds all store related functions for job postings
+type JobPostingStore struct{}
+
+// NewJobPostingStore creates new JobPostingStore
+func NewJobPostingStore() *JobPostingStore {
+	return &JobPostingStore{}
+}
+
+const createJobPostingQuery string = `
+INSERT INTO job_posting (company_id, posted_by, title, location, remote, level, url, expires_on)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *
+`
+
+func (jps JobPostingStore) CreateJobPosting(db rfrl.DB, posting *rfrl.JobPosting) (*rfrl.JobPosting, error) {
+	var m rfrl.JobPosting
+
+	err := db.QueryRowx(
+		createJobPostingQuery,
+		posting.CompanyID,
+		posting.PostedBy,
+		posting.Title,
+		posting.Location,
+		posting.Remote,
+		posting.Level,
+		posting.URL,
+		posting.ExpiresOn,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateJobPosting")
+}
+
+const getJobPostingQuery string = `
+SELECT * FROM job_posting
+WHERE id = $1
+`
+
+func (jps JobPostingStore) GetJobPosting(db rfrl.DB, ID int) (*rfrl.JobPosting, error) {
+	var m rfrl.JobPosting
+
+	err := db.QueryRowx(getJobPostingQuery, ID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetJobPosting")
+	}
+
+	return &m, nil
+}
+
+// GetJobPostings searches postings, newest first
+func (jps JobPostingStore) GetJobPostings(
+	db rfrl.DB,
+	options rfrl.GetJobPostingsOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.JobPosting, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("job_posting")
+
+	if len(options.CompanyIDs) > 0 {
+		query = query.Where(sq.Eq{"company_id": options.CompanyIDs})
+	}
+
+	if options.Title.Valid {
+		query = query.Where(sq.ILike{"title": "%" + options.Title.String + "%"})
+	}
+
+	if options.Location.Valid {
+		query = query.Where(sq.ILike{"location": "%" + options.Location.String + "%"})
+	}
+
+	if options.Remote.Valid {
+		query = query.Where(sq.Eq{"remote": options.Remote.Bool})
+	}
+
+	if options.Level.Valid {
+		query = query.Where(sq.Eq{"level": options.Level.String})
+	}
+
+	if !options.IncludeExpired {
+		query = query.Where("expires_on >= CURRENT_DATE")
+	}
+
+	postings := make([]rfrl.JobPosting, 0)
+
+	query, err := applyIDPage(query, page, "id", true)
+
+	if err != nil {
+		return &postings, nil, errors.Wrap(err, "GetJobPostings")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &postings, nil, errors.Wrap(err, "GetJobPostings")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &postings, nil, errors.Wrap(err, "GetJobPostings")
+	}
+
+	for rows.Next() {
+		var posting rfrl.JobPosting
+
+		err = rows.StructScan(&posting)
+
+		if err != nil {
+			return &postings, nil, errors.Wrap(err, "GetJobPostings")
+		}
+		postings = append(postings, posting)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(postings)) {
+		postings = postings[:page.Size]
+		next = rfrl.NewSerialIDCursor(postings[page.Size-1].ID)
+	}
+
+	return &postings, next, nil
+}
+
+const updateJobPostingQuery string = `
+UPDATE job_posting
+SET title = $2, location = $3, remote = $4, level = $5, url = $6, expires_on = $7, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (jps JobPostingStore) UpdateJobPosting(db rfrl.DB, posting *rfrl.JobPosting) (*rfrl.JobPosting, error) {
+	var m rfrl.JobPosting
+
+	err := db.QueryRowx(
+		updateJobPostingQuery,
+		posting.ID,
+		posting.Title,
+		posting.Location,
+		posting.Remote,
+		posting.Level,
+		posting.URL,
+		posting.ExpiresOn,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateJobPosting")
+}
+
+const deleteJobPostingQuery string = `
+DELETE FROM job_posting
+WHERE id = $1
+RETURNING id
+`
+
+func (jps JobPostingStore) DeleteJobPosting(db rfrl.DB, ID int) error {
+	var deletedID int
+
+	err := db.QueryRowx(deleteJobPostingQuery, ID).Scan(&deletedID)
+
+	return errors.Wrap(err, "DeleteJobPosting")
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// MenteeFeedbackStore holds all store related functions for feedback tutors give mentees
+type MenteeFeedbackStore struct{}
+
+// NewMenteeFeedbackStore creates new MenteeFeedbackStore
+func NewMenteeFeedbackStore() *MenteeFeedbackStore {
+	return &MenteeFeedbackStore{}
+}
+
+const createMenteeFeedbackQuery string = `
+INSERT INTO mentee_feedback (
+	session_id, tutor_id, mentee_id, communication, problem_solving, preparation, notes, visible_to_mentee
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *
+`
+
+func (mfs MenteeFeedbackStore) CreateMenteeFeedback(db rfrl.DB, feedback *rfrl.MenteeFeedback) (*rfrl.MenteeFeedback, error) {
+	var m rfrl.MenteeFeedback
+
+	err := db.QueryRowx(
+		createMenteeFeedbackQuery,
+		feedback.SessionID,
+		feedback.TutorID,
+		feedback.MenteeID,
+		feedback.Communication,
+		feedback.ProblemSolving,
+		feedback.Preparation,
+		feedback.Notes,
+		feedback.VisibleToMentee,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateMenteeFeedback")
+}
+
+const getMenteeFeedbackQuery string = `
+SELECT * FROM mentee_feedback
+WHERE id = $1
+`
+
+func (mfs MenteeFeedbackStore) GetMenteeFeedback(db rfrl.DB, ID int) (*rfrl.MenteeFeedback, error) {
+	var m rfrl.MenteeFeedback
+
+	err := db.QueryRowx(getMenteeFeedbackQuery, ID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMenteeFeedback")
+	}
+
+	return &m, nil
+}
+
+const updateMenteeFeedbackQuery string = `
+UPDATE mentee_feedback
+SET communication = $2, problem_solving = $3, preparation = $4, notes = $5, visible_to_mentee = $6,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (mfs MenteeFeedbackStore) UpdateMenteeFeedback(db rfrl.DB, feedback *rfrl.MenteeFeedback) (*rfrl.MenteeFeedback, error) {
+	var m rfrl.MenteeFeedback
+
+	err := db.QueryRowx(
+		updateMenteeFeedbackQuery,
+		feedback.ID,
+		feedback.Communication,
+		feedback.ProblemSolving,
+		feedback.Preparation,
+		feedback.Notes,
+		feedback.VisibleToMentee,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateMenteeFeedback")
+}
+
+const deleteMenteeFeedbackQuery string = `
+DELETE FROM mentee_feedback
+WHERE id = $1
+`
+
+func (mfs MenteeFeedbackStore) DeleteMenteeFeedback(db rfrl.DB, ID int) error {
+	rows, err := db.Queryx(deleteMenteeFeedbackQuery, ID)
+
+	if err != nil {
+		return errors.Wrap(err, "DeleteMenteeFeedback")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+// GetMenteeFeedbacks pages through feedback newest first so mentees see how they progressed
+func (mfs MenteeFeedbackStore) GetMenteeFeedbacks(
+	db rfrl.DB,
+	options rfrl.GetMenteeFeedbacksOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.MenteeFeedback, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("mentee_feedback")
+
+	if options.TutorID.Valid {
+		query = query.Where(sq.Eq{"tutor_id": options.TutorID.String})
+	}
+
+	if options.MenteeID.Valid {
+		query = query.Where(sq.Eq{"mentee_id": options.MenteeID.String})
+	}
+
+	if options.VisibleOnly {
+		query = query.Where(sq.Eq{"visible_to_mentee": true})
+	}
+
+	feedbacks := make([]rfrl.MenteeFeedback, 0)
+
+	query, err := applyIDPage(query, page, "id", true)
+
+	if err != nil {
+		return &feedbacks, nil, errors.Wrap(err, "GetMenteeFeedbacks")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &feedbacks, nil, errors.Wrap(err, "GetMenteeFeedbacks")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &feedbacks, nil, errors.Wrap(err, "GetMenteeFeedbacks")
+	}
+
+	for rows.Next() {
+		var feedback rfrl.MenteeFeedback
+
+		err = rows.StructScan(&feedback)
+
+		if err != nil {
+			return &feedbacks, nil, errors.Wrap(err, "GetMenteeFeedbacks")
+		}
+		feedbacks = append(feedbacks, feedback)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(feedbacks)) {
+		feedbacks = feedbacks[:page.Size]
+		next = rfrl.NewSerialIDCursor(feedbacks[page.Size-1].ID)
+	}
+
+	return &feedbacks, next, nil
+}
+
+const checkMenteeAttendedSessionQuery string = `
+SELECT EXISTS (
+	SELECT 1 FROM tutor_session
+	JOIN session_client ON session_client.session_id = tutor_session.id
+	WHERE tutor_session.id = $1
+	AND tutor_session.tutor_id = $2
+	AND tutor_session.state = 'completed'
+	AND session_client.client_id = $3
+)
+`
+
+// CheckMenteeAttendedSession checks the mentee was part of a completed session of the tutor
+func (mfs MenteeFeedbackStore) CheckMenteeAttendedSession(db rfrl.DB, sessionID int, tutorID string, menteeID string) (bool, error) {
+	exists := false
+
+	err := db.QueryRowx(checkMenteeAttendedSessionQuery, sessionID, tutorID, menteeID).Scan(&exists)
+
+	return exists, errors.Wrap(err, "CheckMenteeAttendedSession")
+}
+
+const checkMenteeFeedbackExistsQuery string = `
+SELECT EXISTS (
+	SELECT 1 FROM mentee_feedback
+	WHERE session_id = $1 AND mentee_id = $2
+)
+`
+
+func (mfs MenteeFeedbackStore) CheckMenteeFeedbackExists(db rfrl.DB, sessionID int, menteeID string) (bool, error) {
+	exists := false
+
+	err := db.QueryRowx(checkMenteeFeedbackExistsQuery, sessionID, menteeID).Scan(&exists)
+
+	return exists, errors.Wrap(err, "CheckMenteeFeedbackExists")
+}
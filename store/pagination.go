@@ -0,0 +1,96 @@
+package store
+
+import (
+	"fmt"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// applyIDPage pages through rows sorted by their serial id
+func applyIDPage(query sq.SelectBuilder, page rfrl.PageOptions, idColumn string, descending bool) (sq.SelectBuilder, error) {
+	order := idColumn + " ASC"
+
+	if descending {
+		order = idColumn + " DESC"
+	}
+
+	if page.After != nil {
+		lastID, err := page.After.IntID()
+
+		if err != nil {
+			return query, err
+		}
+
+		if descending {
+			query = query.Where(sq.Lt{idColumn: lastID})
+		} else {
+			query = query.Where(sq.Gt{idColumn: lastID})
+		}
+	}
+
+	return query.OrderBy(order).Limit(page.Limit()), nil
+}
+
+// applyTimePage pages through rows newest first, the id keeps rows with the same time in a stable order
+func applyTimePage(query sq.SelectBuilder, page rfrl.PageOptions, timeColumn string, idColumn string) (sq.SelectBuilder, error) {
+	if page.After != nil {
+		key, err := page.After.TimeKey()
+
+		if err != nil {
+			return query, err
+		}
+
+		lastID, err := page.After.IntID()
+
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where(fmt.Sprintf("(%s, %s) < (?, ?)", timeColumn, idColumn), key, lastID)
+	}
+
+	return query.OrderBy(timeColumn+" DESC", idColumn+" DESC").Limit(page.Limit()), nil
+}
+
+// applyStartTimePage pages through rows oldest first, the id keeps rows with the same time in a stable order
+func applyStartTimePage(query sq.SelectBuilder, page rfrl.PageOptions, timeColumn string, idColumn string) (sq.SelectBuilder, error) {
+	if page.After != nil {
+		key, err := page.After.TimeKey()
+
+		if err != nil {
+			return query, err
+		}
+
+		lastID, err := page.After.IntID()
+
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where(fmt.Sprintf("(%s, %s) > (?, ?)", timeColumn, idColumn), key, lastID)
+	}
+
+	return query.OrderBy(timeColumn+" ASC", idColumn+" ASC").Limit(page.Limit()), nil
+}
+
+// applyStartTimeUUIDPage is applyStartTimePage for rows told apart by a uuid instead of a serial id
+func applyStartTimeUUIDPage(query sq.SelectBuilder, page rfrl.PageOptions, timeColumn string, idColumn string) (sq.SelectBuilder, error) {
+	if page.After != nil {
+		key, err := page.After.TimeKey()
+
+		if err != nil {
+			return query, err
+		}
+
+		lastID, err := page.After.UUID()
+
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where(fmt.Sprintf("(%s, %s) > (?, ?)", timeColumn, idColumn), key, lastID)
+	}
+
+	return query.OrderBy(timeColumn+" ASC", idColumn+" ASC").Limit(page.Limit()), nil
+}
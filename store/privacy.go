@@ -0,0 +1,147 @@
+package store
+
+import (
+	"database/sql"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// PrivacyStore holds all store related functions for privacy settings
+type PrivacyStore struct{}
+
+// NewPrivacyStore creates new PrivacyStore
+func NewPrivacyStore() *PrivacyStore {
+	return &PrivacyStore{}
+}
+
+const getPrivacySettingQuery string = `
+SELECT * FROM privacy_setting
+WHERE client_id = $1
+`
+
+// GetPrivacySetting gets the client's settings, clients who never changed them hide nothing
+func (ps PrivacyStore) GetPrivacySetting(db rfrl.DB, clientID string) (*rfrl.PrivacySetting, error) {
+	var m rfrl.PrivacySetting
+
+	err := db.QueryRowx(getPrivacySettingQuery, clientID).StructScan(&m)
+
+	if err == sql.ErrNoRows {
+		return rfrl.NewPrivacySetting(clientID), nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetPrivacySetting")
+	}
+
+	return &m, nil
+}
+
+const getPrivacySettingsQuery string = `
+SELECT * FROM privacy_setting
+WHERE client_id IN (?)
+`
+
+// GetPrivacySettings gets the settings of every client, including the default settings of clients without any
+func (ps PrivacyStore) GetPrivacySettings(db rfrl.DB, clientIDs []string) (map[string]rfrl.PrivacySetting, error) {
+	settings := make(map[string]rfrl.PrivacySetting)
+
+	for _, clientID := range clientIDs {
+		settings[clientID] = *rfrl.NewPrivacySetting(clientID)
+	}
+
+	if len(clientIDs) == 0 {
+		return settings, nil
+	}
+
+	query, args, err := sqlx.In(getPrivacySettingsQuery, clientIDs)
+
+	if err != nil {
+		return settings, errors.Wrap(err, "GetPrivacySettings")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return settings, errors.Wrap(err, "GetPrivacySettings")
+	}
+
+	for rows.Next() {
+		var setting rfrl.PrivacySetting
+		err = rows.StructScan(&setting)
+		if err != nil {
+			return settings, errors.Wrap(err, "GetPrivacySettings")
+		}
+		settings[setting.ClientID] = setting
+	}
+
+	return settings, nil
+}
+
+const upsertPrivacySettingQuery string = `
+INSERT INTO privacy_setting (client_id, hide_email, hide_work_email, hide_linkedin_profile, hidden_from_search)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (client_id) DO UPDATE
+SET hide_email = $2, hide_work_email = $3, hide_linkedin_profile = $4, hidden_from_search = $5, updated_at = CURRENT_TIMESTAMP
+RETURNING *
+`
+
+func (ps PrivacyStore) UpsertPrivacySetting(db rfrl.DB, setting *rfrl.PrivacySetting) (*rfrl.PrivacySetting, error) {
+	var m rfrl.PrivacySetting
+
+	err := db.QueryRowx(
+		upsertPrivacySettingQuery,
+		setting.ClientID,
+		setting.HideEmail,
+		setting.HideWorkEmail,
+		setting.HideLinkedInProfile,
+		setting.HiddenFromSearch,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpsertPrivacySetting")
+}
+
+// getSessionParticipantsQuery finds which of the other clients were in a session with the client,
+// either as the tutor or as one of the session's clients
+const getSessionParticipantsQuery string = `
+WITH participant AS (
+	SELECT session_id, client_id FROM session_client
+	UNION
+	SELECT id AS session_id, tutor_id AS client_id FROM tutor_session
+)
+SELECT DISTINCT other.client_id FROM participant AS viewer
+INNER JOIN participant AS other ON other.session_id = viewer.session_id
+WHERE viewer.client_id = ? AND other.client_id IN (?)
+`
+
+func (ps PrivacyStore) GetSessionParticipants(db rfrl.DB, clientID string, otherClientIDs []string) (map[string]bool, error) {
+	participants := make(map[string]bool)
+
+	if len(otherClientIDs) == 0 {
+		return participants, nil
+	}
+
+	query, args, err := sqlx.In(getSessionParticipantsQuery, clientID, otherClientIDs)
+
+	if err != nil {
+		return participants, errors.Wrap(err, "GetSessionParticipants")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return participants, errors.Wrap(err, "GetSessionParticipants")
+	}
+
+	for rows.Next() {
+		var participantID string
+		err = rows.Scan(&participantID)
+		if err != nil {
+			return participants, errors.Wrap(err, "GetSessionParticipants")
+		}
+		participants[participantID] = true
+	}
+
+	return participants, nil
+}
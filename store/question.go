@@ -234,7 +234,12 @@ func (qs *QuestionStore) UpdateQuestion(db rfrl.DB, clientID string, id int, tit
 		query = query.Set("resolved", resolved)
 	}
 
-	sql, args, err := query.Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+	sql, args, err := query.
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id, "from_id": clientID}).
+		Suffix("RETURNING *").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 
 	if err != nil {
 		return nil, errors.Wrap(err, "UpdateQuestion")
@@ -295,7 +300,7 @@ func (qs *QuestionStore) GetQuestion(db rfrl.DB, id int) (*rfrl.Question, error)
 		return nil, errors.Wrap(err, "GetQuestion")
 	}
 
-	tags, err := getTags(db, question.TagIDs)
+	tags, err := getTagsForQuestion(db, question.ID)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "GetQuestion")
@@ -306,117 +311,111 @@ func (qs *QuestionStore) GetQuestion(db rfrl.DB, id int) (*rfrl.Question, error)
 	return &question, nil
 }
 
-func (qs *QuestionStore) GetQuestions(db rfrl.DB, lastQuestion null.Int, resolved null.Bool) (*[]rfrl.Question, error) {
-	query := sq.Select("*").From("question")
-
-	if lastQuestion.Valid {
-		query = query.Where(sq.Lt{"id": lastQuestion})
-	}
-
-	if resolved.Valid {
-		query = query.Where(sq.Eq{"resolved": resolved})
-	}
-
-	sql, args, err := query.
-		OrderBy("id DESC").
-		Limit(50).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestions")
-	}
-
-	rows, err := db.Queryx(sql, args...)
-
-	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestions")
-	}
-
+// questionsWithTags adds the tags of the questions and keeps them in the order they were queried
+func questionsWithTags(db rfrl.DB, rows *sqlx.Rows, page rfrl.PageOptions) (*[]rfrl.Question, *rfrl.Cursor, error) {
 	idToQuestion := make(map[int]*rfrl.Question)
 	var questionIds []int
 
 	for rows.Next() {
 		var question rfrl.Question
-		err = rows.StructScan(&question)
+		err := rows.StructScan(&question)
 
 		if err != nil {
-			return nil, errors.Wrap(err, "GetQuestions")
+			return nil, nil, err
 		}
 
 		idToQuestion[question.ID] = &question
 		questionIds = append(questionIds, question.ID)
 	}
 
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(questionIds)) {
+		questionIds = questionIds[:page.Size]
+		next = rfrl.NewSerialIDCursor(questionIds[page.Size-1])
+	}
+
 	questionTag, err := getTagsForMultipleQuestions(db, questionIds)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestions")
+		return nil, nil, err
 	}
 
 	questions := make([]rfrl.Question, 0, len(questionIds))
 
-	for id, question := range idToQuestion {
+	for _, id := range questionIds {
+		question := idToQuestion[id]
 		if tags, ok := (*questionTag)[id]; ok {
 			question.Tags = tags
 		}
 		questions = append(questions, *question)
 	}
 
-	return &questions, nil
+	return &questions, next, nil
 }
 
-func (qs *QuestionStore) GetQuestionsForClient(db rfrl.DB, clientID string, resolved null.Bool) (*[]rfrl.Question, error) {
-	query := sq.Select("*").From("question").Where(sq.Eq{"from_id": clientID})
+func (qs *QuestionStore) GetQuestions(db rfrl.DB, resolved null.Bool, page rfrl.PageOptions) (*[]rfrl.Question, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("question")
 
 	if resolved.Valid {
 		query = query.Where(sq.Eq{"resolved": resolved})
 	}
 
+	query, err := applyIDPage(query, page, "id", true)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetQuestions")
+	}
+
 	sql, args, err := query.
-		OrderBy("id DESC").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestionsForClient")
+		return nil, nil, errors.Wrap(err, "GetQuestions")
 	}
 
 	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestionsForClient")
+		return nil, nil, errors.Wrap(err, "GetQuestions")
 	}
 
-	idToQuestion := make(map[int]*rfrl.Question)
-	var questionIds []int
+	questions, next, err := questionsWithTags(db, rows, page)
 
-	for rows.Next() {
-		var question rfrl.Question
-		err = rows.StructScan(&question)
-		if err != nil {
-			return nil, errors.Wrap(err, "GetQuestionsForClient")
-		}
-		idToQuestion[question.ID] = &question
-		questionIds = append(questionIds, question.ID)
+	return questions, next, errors.Wrap(err, "GetQuestions")
+}
+
+func (qs *QuestionStore) GetQuestionsForClient(db rfrl.DB, clientID string, resolved null.Bool, page rfrl.PageOptions) (*[]rfrl.Question, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("question").Where(sq.Eq{"from_id": clientID})
+
+	if resolved.Valid {
+		query = query.Where(sq.Eq{"resolved": resolved})
 	}
 
-	questionTag, err := getTagsForMultipleQuestions(db, questionIds)
+	query, err := applyIDPage(query, page, "id", true)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetQuestionsForClient")
+		return nil, nil, errors.Wrap(err, "GetQuestionsForClient")
 	}
 
-	questions := make([]rfrl.Question, 0, len(questionIds))
+	sql, args, err := query.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 
-	for id, question := range idToQuestion {
-		if tags, ok := (*questionTag)[id]; ok {
-			question.Tags = tags
-		}
-		questions = append(questions, *question)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetQuestionsForClient")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetQuestionsForClient")
 	}
 
-	return &questions, errors.Wrap(err, "GetQuestionsForClient")
+	questions, next, err := questionsWithTags(db, rows, page)
+
+	return questions, next, errors.Wrap(err, "GetQuestionsForClient")
 }
 
 const insertQuestionApplicants string = `
@@ -441,3 +440,48 @@ func (qs *QuestionStore) ApplyToQuestion(db rfrl.DB, clientID string, id int) er
 
 	return errors.Wrap(err, "ApplyToQuestion")
 }
+
+// getSuggestedTutorsSQL ranks tutors by how many tags they share with the question and then by
+// how proficient they are in them. The question's author and tutors hidden from search are never suggested
+const getSuggestedTutorsSQL string = `
+SELECT
+	client.*,
+	COUNT(*) AS matching_tags,
+	SUM(
+		CASE client_tags.proficiency
+			WHEN 'expert' THEN 4
+			WHEN 'advanced' THEN 3
+			WHEN 'intermediate' THEN 2
+			ELSE 1
+		END
+	) AS score
+FROM question
+INNER JOIN question_tags ON question_tags.question_id = question.id
+INNER JOIN client_tags ON client_tags.tag_id = question_tags.tag_id
+INNER JOIN client ON client.id = client_tags.client_id
+WHERE question.id = $1 AND client.is_tutor AND client.id <> question.from_id AND ` + notHiddenFromSearch + `
+GROUP BY client.id
+ORDER BY matching_tags DESC, score DESC, client.id ASC
+LIMIT $2
+`
+
+func (qs *QuestionStore) GetSuggestedTutors(db rfrl.DB, id int, limit int) (*[]rfrl.SuggestedTutor, error) {
+	tutors := make([]rfrl.SuggestedTutor, 0)
+
+	rows, err := db.Queryx(getSuggestedTutorsSQL, id, limit)
+
+	if err != nil {
+		return &tutors, errors.Wrap(err, "GetSuggestedTutors")
+	}
+
+	for rows.Next() {
+		var tutor rfrl.SuggestedTutor
+		err = rows.StructScan(&tutor)
+		if err != nil {
+			return &tutors, errors.Wrap(err, "GetSuggestedTutors")
+		}
+		tutors = append(tutors, tutor)
+	}
+
+	return &tutors, nil
+}
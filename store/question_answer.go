@@ -0,0 +1,206 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// QuestionAnswerStore holds all store related functions for answers and comments on questions
+type QuestionAnswerStore struct{}
+
+// NewQuestionAnswerStore creates new QuestionAnswerStore
+func NewQuestionAnswerStore() *QuestionAnswerStore {
+	return &QuestionAnswerStore{}
+}
+
+const createQuestionAnswerQuery string = `
+INSERT INTO question_answer (question_id, parent_id, root_id, from_id, body)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *
+`
+
+func (qas QuestionAnswerStore) CreateQuestionAnswer(db rfrl.DB, answer *rfrl.QuestionAnswer) (*rfrl.QuestionAnswer, error) {
+	var m rfrl.QuestionAnswer
+
+	err := db.QueryRowx(
+		createQuestionAnswerQuery,
+		answer.QuestionID,
+		answer.ParentID,
+		answer.RootID,
+		answer.FromID,
+		answer.Body,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateQuestionAnswer")
+}
+
+const getQuestionAnswerQuery string = `
+SELECT * FROM question_answer
+WHERE id = $1
+`
+
+func (qas QuestionAnswerStore) GetQuestionAnswer(db rfrl.DB, id int) (*rfrl.QuestionAnswer, error) {
+	var m rfrl.QuestionAnswer
+
+	err := db.QueryRowx(getQuestionAnswerQuery, id).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetQuestionAnswer")
+	}
+
+	return &m, nil
+}
+
+const updateQuestionAnswerQuery string = `
+UPDATE question_answer
+SET body = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (qas QuestionAnswerStore) UpdateQuestionAnswer(db rfrl.DB, id int, body string) (*rfrl.QuestionAnswer, error) {
+	var m rfrl.QuestionAnswer
+
+	err := db.QueryRowx(updateQuestionAnswerQuery, id, body).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateQuestionAnswer")
+}
+
+const deleteQuestionAnswerQuery string = `
+DELETE FROM question_answer
+WHERE id = $1
+`
+
+// DeleteQuestionAnswer deletes the answer or comment with every comment replying to it
+func (qas QuestionAnswerStore) DeleteQuestionAnswer(db rfrl.DB, id int) error {
+	rows, err := db.Queryx(deleteQuestionAnswerQuery, id)
+
+	if err != nil {
+		return errors.Wrap(err, "DeleteQuestionAnswer")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const unacceptQuestionAnswersQuery string = `
+UPDATE question_answer
+SET accepted = FALSE
+WHERE question_id = $1 AND accepted AND id <> $2
+`
+
+const setQuestionAnswerAcceptedQuery string = `
+UPDATE question_answer
+SET accepted = $2
+WHERE id = $1
+RETURNING *
+`
+
+// SetQuestionAnswerAccepted accepts the answer in place of the one accepted before it, or unaccepts it
+func (qas QuestionAnswerStore) SetQuestionAnswerAccepted(db rfrl.DB, questionID int, id int, accepted bool) (*rfrl.QuestionAnswer, error) {
+	if accepted {
+		rows, err := db.Queryx(unacceptQuestionAnswersQuery, questionID, id)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "SetQuestionAnswerAccepted")
+		}
+
+		rows.Close()
+	}
+
+	var m rfrl.QuestionAnswer
+
+	err := db.QueryRowx(setQuestionAnswerAcceptedQuery, id, accepted).StructScan(&m)
+
+	return &m, errors.Wrap(err, "SetQuestionAnswerAccepted")
+}
+
+// GetQuestionAnswers pages through the answers of the question oldest first, without their comments
+func (qas QuestionAnswerStore) GetQuestionAnswers(db rfrl.DB, questionID int, page rfrl.PageOptions) (*[]rfrl.QuestionAnswer, *rfrl.Cursor, error) {
+	query := sq.Select("*").
+		From("question_answer").
+		Where(sq.Eq{"question_id": questionID, "parent_id": nil})
+
+	answers := make([]rfrl.QuestionAnswer, 0)
+
+	query, err := applyIDPage(query, page, "id", false)
+
+	if err != nil {
+		return &answers, nil, errors.Wrap(err, "GetQuestionAnswers")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &answers, nil, errors.Wrap(err, "GetQuestionAnswers")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &answers, nil, errors.Wrap(err, "GetQuestionAnswers")
+	}
+
+	for rows.Next() {
+		var answer rfrl.QuestionAnswer
+
+		err = rows.StructScan(&answer)
+
+		if err != nil {
+			return &answers, nil, errors.Wrap(err, "GetQuestionAnswers")
+		}
+		answers = append(answers, answer)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(answers)) {
+		answers = answers[:page.Size]
+		next = rfrl.NewSerialIDCursor(answers[page.Size-1].ID)
+	}
+
+	return &answers, next, nil
+}
+
+const getQuestionAnswerCommentsQuery string = `
+SELECT * FROM question_answer
+WHERE root_id IN (?)
+ORDER BY id ASC
+`
+
+// GetQuestionAnswerComments gets every comment in the threads of the answers
+func (qas QuestionAnswerStore) GetQuestionAnswerComments(db rfrl.DB, answerIDs []int) (*[]rfrl.QuestionAnswer, error) {
+	comments := make([]rfrl.QuestionAnswer, 0)
+
+	if len(answerIDs) == 0 {
+		return &comments, nil
+	}
+
+	query, args, err := sqlx.In(getQuestionAnswerCommentsQuery, answerIDs)
+
+	if err != nil {
+		return &comments, errors.Wrap(err, "GetQuestionAnswerComments")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return &comments, errors.Wrap(err, "GetQuestionAnswerComments")
+	}
+
+	for rows.Next() {
+		var comment rfrl.QuestionAnswer
+
+		err = rows.StructScan(&comment)
+
+		if err != nil {
+			return &comments, errors.Wrap(err, "GetQuestionAnswerComments")
+		}
+		comments = append(comments, comment)
+	}
+
+	return &comments, nil
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// getQuestionForUpdateSQL uses NO KEY UPDATE so sessions linked to the question can still be created
+// while it is locked, their foreign key only takes a KEY SHARE lock on it
+const getQuestionForUpdateSQL string = `
+SELECT * FROM question
+WHERE id = $1
+FOR NO KEY UPDATE
+`
+
+// GetQuestionForUpdate locks the question so that decisions about its applicants are serialized
+func (qs *QuestionStore) GetQuestionForUpdate(db rfrl.DB, id int) (*rfrl.Question, error) {
+	var question rfrl.Question
+
+	err := db.QueryRowx(getQuestionForUpdateSQL, id).StructScan(&question)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetQuestionForUpdate")
+	}
+
+	return &question, nil
+}
+
+// questionApplicantColumns adds the applicant's rating as a tutor, applicants without reviews have none
+var questionApplicantColumns = []string{
+	"question_applicants.*",
+	"client_rating.average_rating",
+	"client_rating.bayesian_rating",
+	"COALESCE(client_rating.review_count, 0) AS review_count",
+}
+
+var questionApplicantRatingsQuery = `(` + tutorRatingsQuery + `) AS client_rating ON client_rating.tutor_id = question_applicants.applicant_id`
+
+// GetQuestionApplicants gets the applicants of the question in the order they applied
+func (qs *QuestionStore) GetQuestionApplicants(
+	db rfrl.DB,
+	id int,
+	state null.String,
+	page rfrl.PageOptions,
+) (*[]rfrl.QuestionApplicant, *rfrl.Cursor, error) {
+	applicants := make([]rfrl.QuestionApplicant, 0)
+
+	query := sq.Select(questionApplicantColumns...).
+		From("question_applicants").
+		LeftJoin(questionApplicantRatingsQuery).
+		Where(sq.Eq{"question_applicants.question_id": id})
+
+	if state.Valid {
+		query = query.Where(sq.Eq{"question_applicants.state": state.String})
+	}
+
+	query, err := applyStartTimeUUIDPage(query, page, "question_applicants.created_at", "question_applicants.applicant_id")
+
+	if err != nil {
+		return &applicants, nil, errors.Wrap(err, "GetQuestionApplicants")
+	}
+
+	sql, args, err := query.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return &applicants, nil, errors.Wrap(err, "GetQuestionApplicants")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &applicants, nil, errors.Wrap(err, "GetQuestionApplicants")
+	}
+
+	for rows.Next() {
+		var applicant rfrl.QuestionApplicant
+		err = rows.StructScan(&applicant)
+		if err != nil {
+			return &applicants, nil, errors.Wrap(err, "GetQuestionApplicants")
+		}
+		applicants = append(applicants, applicant)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(applicants)) {
+		applicants = applicants[:page.Size]
+		last := applicants[page.Size-1]
+		next = rfrl.NewTimeUUIDCursor(last.CreatedAt, last.ApplicantID)
+	}
+
+	return &applicants, next, nil
+}
+
+func (qs *QuestionStore) GetQuestionApplicant(db rfrl.DB, id int, applicantID string) (*rfrl.QuestionApplicant, error) {
+	sql, args, err := sq.Select(questionApplicantColumns...).
+		From("question_applicants").
+		LeftJoin(questionApplicantRatingsQuery).
+		Where(sq.Eq{
+			"question_applicants.question_id":  id,
+			"question_applicants.applicant_id": applicantID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetQuestionApplicant")
+	}
+
+	var applicant rfrl.QuestionApplicant
+
+	err = db.QueryRowx(sql, args...).StructScan(&applicant)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetQuestionApplicant")
+	}
+
+	return &applicant, nil
+}
+
+// UpdateQuestionApplicantState moves the applicant to the state if they are still in one of the from
+// states, sql.ErrNoRows is returned otherwise. The session is only set when it is given
+func (qs *QuestionStore) UpdateQuestionApplicantState(
+	db rfrl.DB,
+	id int,
+	applicantID string,
+	from []string,
+	state string,
+	sessionID null.Int,
+) (*rfrl.QuestionApplicant, error) {
+	query := sq.Update("question_applicants").
+		Set("state", state).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP"))
+
+	if sessionID.Valid {
+		query = query.Set("session_id", sessionID)
+	}
+
+	sql, args, err := query.
+		Where(sq.Eq{"question_id": id, "applicant_id": applicantID, "state": from}).
+		Suffix("RETURNING question_id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdateQuestionApplicantState")
+	}
+
+	var questionID int
+
+	err = db.QueryRowx(sql, args...).Scan(&questionID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdateQuestionApplicantState")
+	}
+
+	applicant, err := qs.GetQuestionApplicant(db, id, applicantID)
+
+	return applicant, errors.Wrap(err, "UpdateQuestionApplicantState")
+}
+
+const declineOtherQuestionApplicantsQuery string = `
+UPDATE question_applicants
+SET state = 'declined', updated_at = CURRENT_TIMESTAMP
+WHERE question_id = $1 AND applicant_id <> $2 AND state IN ('applied', 'shortlisted')
+RETURNING applicant_id
+`
+
+// DeclineOtherQuestionApplicants declines every applicant still waiting once one is accepted and returns
+// who was declined
+func (qs *QuestionStore) DeclineOtherQuestionApplicants(db rfrl.DB, id int, acceptedID string) ([]string, error) {
+	declinedIDs := make([]string, 0)
+
+	rows, err := db.Queryx(declineOtherQuestionApplicantsQuery, id, acceptedID)
+
+	if err != nil {
+		return declinedIDs, errors.Wrap(err, "DeclineOtherQuestionApplicants")
+	}
+
+	for rows.Next() {
+		var applicantID string
+		err = rows.Scan(&applicantID)
+		if err != nil {
+			return declinedIDs, errors.Wrap(err, "DeclineOtherQuestionApplicants")
+		}
+		declinedIDs = append(declinedIDs, applicantID)
+	}
+
+	return declinedIDs, nil
+}
@@ -0,0 +1,422 @@
+package store
+
+import (
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// ReferralStore holds all store related functions for referrals
+type ReferralStore struct{}
+
+// NewReferralStore creates new ReferralStore
+func NewReferralStore() *ReferralStore {
+	return &ReferralStore{}
+}
+
+const createReferralQuery string = `
+INSERT INTO referral (seeker_id, referrer_id, company_id, job_posting_id, job_url, document_id, message, state, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *
+`
+
+func (rs ReferralStore) CreateReferral(db rfrl.DB, referral *rfrl.Referral) (*rfrl.Referral, error) {
+	row := db.QueryRowx(
+		createReferralQuery,
+		referral.SeekerID,
+		referral.ReferrerID,
+		referral.CompanyID,
+		referral.JobPostingID,
+		referral.JobURL,
+		referral.DocumentID,
+		referral.Message,
+		referral.State,
+		referral.UpdatedBy,
+	)
+
+	var m rfrl.Referral
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateReferral")
+}
+
+const getReferralQuery string = `
+SELECT * FROM referral
+WHERE id = $1 AND (seeker_id = $2 OR referrer_id = $2)
+`
+
+func (rs ReferralStore) GetReferral(db rfrl.DB, clientID string, ID int) (*rfrl.Referral, error) {
+	var m rfrl.Referral
+
+	err := db.QueryRowx(getReferralQuery, ID, clientID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetReferral")
+	}
+
+	return &m, nil
+}
+
+const getReferralForUpdateQuery string = `
+SELECT * FROM referral
+WHERE id = $1 AND (seeker_id = $2 OR referrer_id = $2)
+FOR UPDATE
+`
+
+func (rs ReferralStore) GetReferralForUpdate(db rfrl.DB, clientID string, ID int) (*rfrl.Referral, error) {
+	var m rfrl.Referral
+
+	err := db.QueryRowx(getReferralForUpdateQuery, ID, clientID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetReferralForUpdate")
+	}
+
+	return &m, nil
+}
+
+func (rs ReferralStore) GetReferrals(
+	db rfrl.DB,
+	clientID string,
+	options rfrl.GetReferralsOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.Referral, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("referral")
+
+	switch {
+	case !options.AsSeeker.Valid:
+		query = query.Where(sq.Or{sq.Eq{"seeker_id": clientID}, sq.Eq{"referrer_id": clientID}})
+	case options.AsSeeker.Bool:
+		query = query.Where(sq.Eq{"seeker_id": clientID})
+	default:
+		query = query.Where(sq.Eq{"referrer_id": clientID})
+	}
+
+	if options.State.Valid {
+		query = query.Where(sq.Eq{"state": options.State.String})
+	}
+
+	referrals := make([]rfrl.Referral, 0)
+
+	query, err := applyTimePage(query, page, "updated_at", "id")
+
+	if err != nil {
+		return &referrals, nil, errors.Wrap(err, "GetReferrals")
+	}
+
+	sql, args, err := query.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return &referrals, nil, errors.Wrap(err, "GetReferrals")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &referrals, nil, errors.Wrap(err, "GetReferrals")
+	}
+
+	for rows.Next() {
+		var referral rfrl.Referral
+
+		err = rows.StructScan(&referral)
+
+		if err != nil {
+			return &referrals, nil, errors.Wrap(err, "GetReferrals")
+		}
+		referrals = append(referrals, referral)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(referrals)) {
+		referrals = referrals[:page.Size]
+		last := referrals[page.Size-1]
+		next = rfrl.NewTimeCursor(last.UpdatedAt, last.ID)
+	}
+
+	return &referrals, next, nil
+}
+
+const checkOpenReferralExistsQuery string = `
+SELECT EXISTS(
+	SELECT 1 FROM referral
+	WHERE seeker_id = $1 AND referrer_id = $2 AND job_url = $3 AND state IN ('requested', 'accepted')
+)
+`
+
+func (rs ReferralStore) CheckOpenReferralExists(
+	db rfrl.DB,
+	seekerID string,
+	referrerID string,
+	jobURL string,
+) (bool, error) {
+	var exists bool
+
+	err := db.QueryRowx(checkOpenReferralExistsQuery, seekerID, referrerID, jobURL).Scan(&exists)
+
+	return exists, errors.Wrap(err, "CheckOpenReferralExists")
+}
+
+const updateReferralStateQuery string = `
+UPDATE referral
+SET updated_by = $2, state = $3, state_reason = $4, state_changed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (rs ReferralStore) UpdateReferralState(
+	db rfrl.DB,
+	ID int,
+	by string,
+	state rfrl.ReferralState,
+	reason null.String,
+) (*rfrl.Referral, error) {
+	row := db.QueryRowx(updateReferralStateQuery, ID, by, state, reason)
+
+	var m rfrl.Referral
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateReferralState")
+}
+
+const createReferralStateChangeQuery string = `
+INSERT INTO referral_state_change (referral_id, from_state, to_state, changed_by, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *
+`
+
+func (rs ReferralStore) CreateReferralStateChange(
+	db rfrl.DB,
+	change *rfrl.ReferralStateChange,
+) (*rfrl.ReferralStateChange, error) {
+	row := db.QueryRowx(
+		createReferralStateChangeQuery,
+		change.ReferralID,
+		change.FromState,
+		change.ToState,
+		change.ChangedBy,
+		change.Reason,
+	)
+
+	var m rfrl.ReferralStateChange
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateReferralStateChange")
+}
+
+const getReferralStateChangesQuery string = `
+SELECT * FROM referral_state_change
+WHERE referral_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (rs ReferralStore) GetReferralStateChanges(db rfrl.DB, referralID int) (*[]rfrl.ReferralStateChange, error) {
+	changes := make([]rfrl.ReferralStateChange, 0)
+
+	rows, err := db.Queryx(getReferralStateChangesQuery, referralID)
+
+	if err != nil {
+		return &changes, errors.Wrap(err, "GetReferralStateChanges")
+	}
+
+	for rows.Next() {
+		var change rfrl.ReferralStateChange
+
+		err = rows.StructScan(&change)
+
+		if err != nil {
+			return &changes, errors.Wrap(err, "GetReferralStateChanges")
+		}
+		changes = append(changes, change)
+	}
+
+	return &changes, nil
+}
+
+const createReferralOutcomeQuery string = `
+INSERT INTO referral_outcome (referral_id, stage, occurred_on, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *
+`
+
+func (rs ReferralStore) CreateReferralOutcome(db rfrl.DB, outcome *rfrl.ReferralOutcome) (*rfrl.ReferralOutcome, error) {
+	row := db.QueryRowx(
+		createReferralOutcomeQuery,
+		outcome.ReferralID,
+		outcome.Stage,
+		outcome.OccurredOn,
+		outcome.Note,
+	)
+
+	var m rfrl.ReferralOutcome
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateReferralOutcome")
+}
+
+const updateReferralOutcomeQuery string = `
+UPDATE referral
+SET outcome = $2, outcome_on = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (rs ReferralStore) UpdateReferralOutcome(db rfrl.DB, ID int, stage string, occurredOn time.Time) (*rfrl.Referral, error) {
+	row := db.QueryRowx(updateReferralOutcomeQuery, ID, stage, occurredOn)
+
+	var m rfrl.Referral
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateReferralOutcome")
+}
+
+const getReferralOutcomesQuery string = `
+SELECT * FROM referral_outcome
+WHERE referral_id = $1
+ORDER BY occurred_on ASC, id ASC
+`
+
+func (rs ReferralStore) GetReferralOutcomes(db rfrl.DB, referralID int) (*[]rfrl.ReferralOutcome, error) {
+	outcomes := make([]rfrl.ReferralOutcome, 0)
+
+	rows, err := db.Queryx(getReferralOutcomesQuery, referralID)
+
+	if err != nil {
+		return &outcomes, errors.Wrap(err, "GetReferralOutcomes")
+	}
+
+	for rows.Next() {
+		var outcome rfrl.ReferralOutcome
+
+		err = rows.StructScan(&outcome)
+
+		if err != nil {
+			return &outcomes, errors.Wrap(err, "GetReferralOutcomes")
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return &outcomes, nil
+}
+
+// referralStatsColumns counts a referral once per stage it reached, an offer implies an interview
+var referralStatsColumns = []string{
+	"COUNT(*) AS referrals_made",
+	"COUNT(*) FILTER (WHERE reached.interview OR reached.offer OR reached.hired) AS interviews",
+	"COUNT(*) FILTER (WHERE reached.offer OR reached.hired) AS offers",
+	"COUNT(*) FILTER (WHERE reached.hired) AS hires",
+}
+
+const referralReachedStagesJoin string = `(
+	SELECT
+		referral_id,
+		BOOL_OR(stage = 'interview') AS interview,
+		BOOL_OR(stage = 'offer') AS offer,
+		BOOL_OR(stage = 'hired') AS hired
+	FROM referral_outcome
+	GROUP BY referral_id
+) AS reached ON reached.referral_id = referral.id`
+
+func referralStatsQuery() sq.SelectBuilder {
+	return sq.Select(referralStatsColumns...).
+		From("referral").
+		LeftJoin(referralReachedStagesJoin).
+		Where(sq.Eq{"referral.state": rfrl.REFERRAL_SUBMITTED})
+}
+
+func getReferralStats(db rfrl.DB, query sq.SelectBuilder) (*rfrl.ReferralStats, error) {
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "getReferralStats")
+	}
+
+	var stats rfrl.ReferralStats
+
+	err = db.QueryRowx(sql, args...).StructScan(&stats)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "getReferralStats")
+	}
+
+	stats.ComputeConversionRate()
+
+	return &stats, nil
+}
+
+func (rs ReferralStore) GetReferrerStats(db rfrl.DB, referrerID string) (*rfrl.ReferralStats, error) {
+	stats, err := getReferralStats(db, referralStatsQuery().Where(sq.Eq{"referral.referrer_id": referrerID}))
+
+	return stats, errors.Wrap(err, "GetReferrerStats")
+}
+
+func (rs ReferralStore) GetCompanyReferralStats(db rfrl.DB, companyID int) (*rfrl.ReferralStats, error) {
+	stats, err := getReferralStats(db, referralStatsQuery().Where(sq.Eq{"referral.company_id": companyID}))
+
+	return stats, errors.Wrap(err, "GetCompanyReferralStats")
+}
+
+// GetReferrerLeaderboard ranks referrers by their stats, referrers hidden from search are left out
+func (rs ReferralStore) GetReferrerLeaderboard(
+	db rfrl.DB,
+	companyID null.Int,
+	limit int,
+) (*[]rfrl.ReferrerLeaderboardEntry, error) {
+	query := referralStatsQuery().
+		Columns(
+			"referral.referrer_id",
+			"client.first_name",
+			"client.last_name",
+			"client.photo",
+			"client.company_id",
+		).
+		Join("client ON client.id = referral.referrer_id").
+		Where(notHiddenFromSearch).
+		GroupBy("referral.referrer_id", "client.id").
+		OrderBy("hires DESC", "offers DESC", "referrals_made DESC", "referral.referrer_id ASC").
+		Limit(uint64(limit))
+
+	if companyID.Valid {
+		query = query.Where(sq.Eq{"referral.company_id": companyID.Int64})
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	entries := make([]rfrl.ReferrerLeaderboardEntry, 0)
+
+	if err != nil {
+		return &entries, errors.Wrap(err, "GetReferrerLeaderboard")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &entries, errors.Wrap(err, "GetReferrerLeaderboard")
+	}
+
+	for rows.Next() {
+		var entry rfrl.ReferrerLeaderboardEntry
+
+		err = rows.StructScan(&entry)
+
+		if err != nil {
+			return &entries, errors.Wrap(err, "GetReferrerLeaderboard")
+		}
+
+		entry.ComputeConversionRate()
+		entries = append(entries, entry)
+	}
+
+	return &entries, nil
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"database/sql"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/pkg/errors"
+)
+
+// ReferralNotificationStore holds all store related functions for referral notifications
+type ReferralNotificationStore struct{}
+
+// NewReferralNotificationStore creates new ReferralNotificationStore
+func NewReferralNotificationStore() *ReferralNotificationStore {
+	return &ReferralNotificationStore{}
+}
+
+const getNotificationSettingQuery string = `
+SELECT * FROM notification_setting
+WHERE client_id = $1
+`
+
+// GetNotificationSetting gets the client's settings, clients who never changed them get every email
+func (rns ReferralNotificationStore) GetNotificationSetting(db rfrl.DB, clientID string) (*rfrl.NotificationSetting, error) {
+	var m rfrl.NotificationSetting
+
+	err := db.QueryRowx(getNotificationSettingQuery, clientID).StructScan(&m)
+
+	if err == sql.ErrNoRows {
+		return rfrl.NewNotificationSetting(clientID), nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetNotificationSetting")
+	}
+
+	return &m, nil
+}
+
+const upsertNotificationSettingQuery string = `
+INSERT INTO notification_setting (client_id, referrer_available, pending_seekers_digest)
+VALUES ($1, $2, $3)
+ON CONFLICT (client_id) DO UPDATE
+SET referrer_available = $2, pending_seekers_digest = $3, updated_at = CURRENT_TIMESTAMP
+RETURNING *
+`
+
+func (rns ReferralNotificationStore) UpsertNotificationSetting(db rfrl.DB, setting *rfrl.NotificationSetting) (*rfrl.NotificationSetting, error) {
+	var m rfrl.NotificationSetting
+
+	err := db.QueryRowx(
+		upsertNotificationSettingQuery,
+		setting.ClientID,
+		setting.ReferrerAvailable,
+		setting.PendingSeekersDigest,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpsertNotificationSetting")
+}
+
+// Seekers are skipped when they opted out, already heard about this company within the cooldown
+// or already got their daily limit of emails
+const getSeekersToNotifyOfReferrerQuery string = `
+SELECT client.* FROM client
+JOIN client_wanting_company_referral ON client_wanting_company_referral.client_id = client.id
+LEFT JOIN notification_setting ON notification_setting.client_id = client.id
+WHERE client_wanting_company_referral.company_id = $1
+AND client.id <> $2
+AND client.is_looking_for_referral
+AND client.verified_email IS TRUE
+AND client.company_id IS DISTINCT FROM $1
+AND COALESCE(notification_setting.referrer_available, TRUE)
+AND NOT EXISTS (
+	SELECT 1 FROM referral_notification
+	WHERE referral_notification.client_id = client.id
+	AND referral_notification.kind = 'referrer_available'
+	AND referral_notification.company_id = $1
+	AND referral_notification.created_at > CURRENT_TIMESTAMP - make_interval(secs => $3)
+)
+AND (
+	SELECT COUNT(*) FROM referral_notification
+	WHERE referral_notification.client_id = client.id
+	AND referral_notification.kind = 'referrer_available'
+	AND referral_notification.created_at > CURRENT_TIMESTAMP - INTERVAL '1 day'
+) < $4
+`
+
+func (rns ReferralNotificationStore) GetSeekersToNotifyOfReferrer(db rfrl.DB, companyID int, referrerID string) (*[]rfrl.Client, error) {
+	rows, err := db.Queryx(
+		getSeekersToNotifyOfReferrerQuery,
+		companyID,
+		referrerID,
+		rfrl.ReferrerAvailableCooldown.Seconds(),
+		rfrl.ReferrerAvailableDailyLimit,
+	)
+
+	clients := make([]rfrl.Client, 0)
+
+	if err != nil {
+		return &clients, errors.Wrap(err, "GetSeekersToNotifyOfReferrer")
+	}
+
+	for rows.Next() {
+		var client rfrl.Client
+
+		err = rows.StructScan(&client)
+
+		if err != nil {
+			return &clients, errors.Wrap(err, "GetSeekersToNotifyOfReferrer")
+		}
+		clients = append(clients, client)
+	}
+
+	return &clients, nil
+}
+
+// Seekers who already asked this referrer are not pending anymore
+const getPendingSeekersQuery string = `
+SELECT client.* FROM client
+JOIN client_wanting_company_referral ON client_wanting_company_referral.client_id = client.id
+WHERE client_wanting_company_referral.company_id = $1
+AND client.id <> $2
+AND client.is_looking_for_referral
+AND client.company_id IS DISTINCT FROM $1
+AND NOT EXISTS (
+	SELECT 1 FROM referral
+	WHERE referral.seeker_id = client.id AND referral.referrer_id = $2
+)
+ORDER BY client.updated_at DESC
+LIMIT $3
+`
+
+func (rns ReferralNotificationStore) GetPendingSeekers(db rfrl.DB, companyID int, referrerID string, limit int) (*[]rfrl.Client, error) {
+	rows, err := db.Queryx(getPendingSeekersQuery, companyID, referrerID, limit)
+
+	clients := make([]rfrl.Client, 0)
+
+	if err != nil {
+		return &clients, errors.Wrap(err, "GetPendingSeekers")
+	}
+
+	for rows.Next() {
+		var client rfrl.Client
+
+		err = rows.StructScan(&client)
+
+		if err != nil {
+			return &clients, errors.Wrap(err, "GetPendingSeekers")
+		}
+		clients = append(clients, client)
+	}
+
+	return &clients, nil
+}
+
+const canSendPendingSeekersDigestQuery string = `
+SELECT COALESCE(
+	(SELECT pending_seekers_digest FROM notification_setting WHERE client_id = $1),
+	TRUE
+) AND NOT EXISTS (
+	SELECT 1 FROM referral_notification
+	WHERE client_id = $1
+	AND kind = 'pending_seekers_digest'
+	AND created_at > CURRENT_TIMESTAMP - make_interval(secs => $2)
+)
+`
+
+// CanSendPendingSeekersDigest checks the referrer did not opt out or get a digest within the cooldown
+func (rns ReferralNotificationStore) CanSendPendingSeekersDigest(db rfrl.DB, referrerID string) (bool, error) {
+	var canSend bool
+
+	err := db.QueryRowx(
+		canSendPendingSeekersDigestQuery,
+		referrerID,
+		rfrl.PendingSeekersDigestCooldown.Seconds(),
+	).Scan(&canSend)
+
+	return canSend, errors.Wrap(err, "CanSendPendingSeekersDigest")
+}
+
+const createReferralNotificationQuery string = `
+INSERT INTO referral_notification (client_id, kind, company_id, about_client_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *
+`
+
+func (rns ReferralNotificationStore) CreateReferralNotification(db rfrl.DB, notification *rfrl.ReferralNotification) (*rfrl.ReferralNotification, error) {
+	var m rfrl.ReferralNotification
+
+	err := db.QueryRowx(
+		createReferralNotificationQuery,
+		notification.ClientID,
+		notification.Kind,
+		notification.CompanyID,
+		notification.AboutClientID,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateReferralNotification")
+}
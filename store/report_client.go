@@ -2,6 +2,7 @@ package store
 
 import (
 	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 )
 
@@ -36,18 +37,26 @@ func (r ReportClientStore) DeleteReport(db rfrl.DB, reporter string, accused str
 	return errors.Wrap(err, "DeleteReport")
 }
 
-const getReportQuery string = `
-SELECT * FROM report_client
-LIMIT 500
-`
-
-func (r ReportClientStore) GetReports(db rfrl.DB) (*[]rfrl.ReportClient, error) {
+// GetReports pages through reports newest first
+func (r ReportClientStore) GetReports(db rfrl.DB, page rfrl.PageOptions) (*[]rfrl.ReportClient, *rfrl.Cursor, error) {
 	reports := make([]rfrl.ReportClient, 0)
 
-	rows, err := db.Queryx(getReportQuery)
+	query, err := applyIDPage(sq.Select("*").From("report_client"), page, "id", true)
 
 	if err != nil {
-		return &reports, errors.Wrap(err, "GetReports")
+		return &reports, nil, errors.Wrap(err, "GetReports")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &reports, nil, errors.Wrap(err, "GetReports")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &reports, nil, errors.Wrap(err, "GetReports")
 	}
 
 	for rows.Next() {
@@ -56,10 +65,17 @@ func (r ReportClientStore) GetReports(db rfrl.DB) (*[]rfrl.ReportClient, error)
 		err = rows.StructScan(&report)
 
 		if err != nil {
-			return &reports, errors.Wrap(err, "GetReports")
+			return &reports, nil, errors.Wrap(err, "GetReports")
 		}
 		reports = append(reports, report)
 	}
 
-	return &reports, nil
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(reports)) {
+		reports = reports[:page.Size]
+		next = rfrl.NewIDCursor(reports[page.Size-1].ID)
+	}
+
+	return &reports, next, nil
 }
@@ -92,7 +92,13 @@ func getSessionWithClients(db rfrl.DB, rows *sqlx.Rows, clientID string) (*[]rfr
 	return &sessions, nil
 }
 
-func (ss *SessionStore) GetSessionByClientID(db rfrl.DB, clientID string, state string) (*[]rfrl.Session, error) {
+// GetSessionByClientID pages through the client's sessions newest first
+func (ss *SessionStore) GetSessionByClientID(
+	db rfrl.DB,
+	clientID string,
+	state rfrl.SessionState,
+	page rfrl.PageOptions,
+) (*[]rfrl.Session, *rfrl.Cursor, error) {
 	query := sq.
 		Select(`tutor_session.*`).
 		From("tutor_session").
@@ -103,19 +109,38 @@ func (ss *SessionStore) GetSessionByClientID(db rfrl.DB, clientID string, state
 		query = query.Where(sq.Eq{"state": state})
 	}
 
+	query, err := applyIDPage(query, page, "tutor_session.id", true)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetSessionByClientID")
+	}
+
 	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetSessionByClientID")
+		return nil, nil, errors.Wrap(err, "GetSessionByClientID")
 	}
 
 	rows, err := db.Queryx(sql, args...)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "GetSessionByClientID")
+		return nil, nil, errors.Wrap(err, "GetSessionByClientID")
 	}
 
-	return getSessionWithClients(db, rows, clientID)
+	sessions, err := getSessionWithClients(db, rows, clientID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(*sessions)) {
+		*sessions = (*sessions)[:page.Size]
+		next = rfrl.NewSerialIDCursor((*sessions)[page.Size-1].ID)
+	}
+
+	return sessions, next, nil
 }
 
 const getSessionByRoomID string = `
@@ -123,7 +148,7 @@ SELECT * FROM tutor_session
 WHERE room_id = $1 AND state = $2
 	`
 
-func (ss *SessionStore) GetSessionByRoomID(db rfrl.DB, clientID string, roomID string, state string) (*[]rfrl.Session, error) {
+func (ss *SessionStore) GetSessionByRoomID(db rfrl.DB, clientID string, roomID string, state rfrl.SessionState) (*[]rfrl.Session, error) {
 	query := sq.Select("*").From("tutor_session").Where(sq.Eq{"room_id": roomID})
 
 	if state != "" {
@@ -202,8 +227,8 @@ func (ss *SessionStore) DeleteSession(db rfrl.DB, ID int) error {
 }
 
 const insertSession string = `
-INSERT INTO tutor_session (tutor_id, updated_by, room_id, state)
-VALUES ($1, $2, $3, $4)
+INSERT INTO tutor_session (tutor_id, updated_by, room_id, state, series_id, question_id)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *
 	`
 
@@ -218,6 +243,8 @@ func (ss SessionStore) CreateSession(
 		session.UpdatedBy,
 		session.RoomID,
 		session.State,
+		session.SeriesID,
+		session.QuestionID,
 	)
 
 	var m rfrl.Session
@@ -343,16 +370,11 @@ func (ss SessionStore) UpdateSession(
 	db rfrl.DB,
 	id int,
 	by string,
-	state string,
 	eventID null.Int,
 	conferenceID null.String,
 ) (*rfrl.Session, error) {
 	query := sq.Update("tutor_session").Set("updated_by", by)
 
-	if state != "" {
-		query = query.Set("state", state)
-	}
-
 	if eventID.Valid {
 		query = query.Set("event_id", eventID)
 	}
@@ -380,16 +402,113 @@ func (ss SessionStore) UpdateSession(
 	return &m, errors.Wrap(err, "UpdateSession")
 }
 
+const updateSessionStateQuery string = `
+UPDATE tutor_session
+SET updated_by = $2, state = $3, state_reason = $4, state_changed_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (ss SessionStore) UpdateSessionState(
+	db rfrl.DB,
+	id int,
+	by string,
+	state rfrl.SessionState,
+	reason null.String,
+) (*rfrl.Session, error) {
+	row := db.QueryRowx(updateSessionStateQuery, id, by, state, reason)
+
+	var m rfrl.Session
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateSessionState")
+}
+
+const createSessionStateChangeQuery string = `
+INSERT INTO session_state_change (session_id, from_state, to_state, changed_by, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *
+`
+
+func (ss SessionStore) CreateSessionStateChange(
+	db rfrl.DB,
+	change *rfrl.SessionStateChange,
+) (*rfrl.SessionStateChange, error) {
+	row := db.QueryRowx(
+		createSessionStateChangeQuery,
+		change.SessionID,
+		change.FromState,
+		change.ToState,
+		change.ChangedBy,
+		change.Reason,
+	)
+
+	var m rfrl.SessionStateChange
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateSessionStateChange")
+}
+
+// GetSessionStateChanges pages through the state changes of the session in the order they happened
+func (ss SessionStore) GetSessionStateChanges(db rfrl.DB, sessionID int, page rfrl.PageOptions) (*[]rfrl.SessionStateChange, *rfrl.Cursor, error) {
+	changes := make([]rfrl.SessionStateChange, 0)
+
+	query, err := applyIDPage(
+		sq.Select("*").From("session_state_change").Where(sq.Eq{"session_id": sessionID}),
+		page,
+		"id",
+		false,
+	)
+
+	if err != nil {
+		return &changes, nil, errors.Wrap(err, "GetSessionStateChanges")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &changes, nil, errors.Wrap(err, "GetSessionStateChanges")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &changes, nil, errors.Wrap(err, "GetSessionStateChanges")
+	}
+
+	for rows.Next() {
+		var change rfrl.SessionStateChange
+
+		err = rows.StructScan(&change)
+
+		if err != nil {
+			return &changes, nil, errors.Wrap(err, "GetSessionStateChanges")
+		}
+		changes = append(changes, change)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(changes)) {
+		changes = changes[:page.Size]
+		next = rfrl.NewSerialIDCursor(changes[page.Size-1].ID)
+	}
+
+	return &changes, next, nil
+}
+
 func (ss SessionStore) CreateSessionEvents(
 	db rfrl.DB,
 	events []rfrl.Event,
 ) (*[]rfrl.Event, error) {
 	query := sq.Insert("scheduled_event").
-		Columns("start_time", "end_time", "title")
+		Columns("start_time", "end_time", "title", "timezone")
 
 	for i := 0; i < len(events); i++ {
 		ev := events[i]
-		query = query.Values(ev.StartTime, ev.EndTime, ev.Title)
+		query = query.Values(ev.StartTime, ev.EndTime, ev.Title, rfrl.LoadTimezone(ev.Timezone).String())
 	}
 	sql, args, err := query.
 		Suffix("RETURNING *").
@@ -478,9 +597,11 @@ func (ss SessionStore) GetSessionEventByID(db rfrl.DB, sessionID int, ID int) (*
 }
 
 func filterInclusiveDateRange(query sq.SelectBuilder, events *[]rfrl.Event) sq.SelectBuilder {
+	overlaps := sq.Or{}
 	for i := 0; i < len(*events); i++ {
 		event := (*events)[i]
-		query = query.Where(
+		overlaps = append(
+			overlaps,
 			sq.Or{
 				sq.And{
 					sq.LtOrEq{"start_time": event.StartTime},
@@ -497,14 +618,14 @@ func filterInclusiveDateRange(query sq.SelectBuilder, events *[]rfrl.Event) sq.S
 			},
 		)
 	}
-	return query
+	return query.Where(overlaps)
 }
 
 const checkAllClientSessionHasRespondedQuery string = `
 SELECT EXISTS(
 	SELECT 1 FROM session_client
 	WHERE session_client.session_id = $1 AND 
-	session_client.can_attend = NULL
+	session_client.can_attend IS NULL
 )
 `
 
@@ -523,6 +644,22 @@ func (ss SessionStore) CheckAllClientSessionHasResponded(db rfrl.DB, id int) (bo
 	return !notAllClientsResponded.Bool, nil
 }
 
+const checkAllClientsCanAttendSessionQuery string = `
+SELECT NOT EXISTS(
+	SELECT 1 FROM session_client
+	WHERE session_client.session_id = $1 AND
+	session_client.can_attend IS NOT TRUE
+)
+`
+
+// CheckAllClientsCanAttendSession checks that every client of the session said they can attend
+func (ss SessionStore) CheckAllClientsCanAttendSession(db rfrl.DB, id int) (bool, error) {
+	var allCanAttend bool
+	err := db.QueryRowx(checkAllClientsCanAttendSessionQuery, id).Scan(&allCanAttend)
+
+	return allCanAttend, errors.Wrap(err, "CheckAllClientsCanAttendSession")
+}
+
 const getSessionFromConferenceIDQuery string = `
 SELECT * FROM tutor_session
 WHERE conference_id = $1
@@ -540,7 +677,7 @@ SELECT count(client_id) FROM session_client
 JOIN tutor_session ON tutor_session.id = session_client.session_id
 WHERE client_id IN (?) 
 AND can_attend = TRUE 
-AND tutor_session.state = 'scheduled'
+AND tutor_session.state IN ('scheduled', 'completed')
 AND tutor_session.tutor_id = ?
 GROUP BY session_id
 	`
@@ -575,3 +712,201 @@ func (ss SessionStore) CheckClientsAttendedTutorSession(db rfrl.DB, tutorID stri
 
 	return false, nil
 }
+
+const createSessionProposalQuery string = `
+INSERT INTO session_proposal (session_id, proposed_by, start_time, end_time, title, reason, state, counter_to)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *
+`
+
+func (ss SessionStore) CreateSessionProposal(db rfrl.DB, proposal *rfrl.SessionProposal) (*rfrl.SessionProposal, error) {
+	row := db.QueryRowx(
+		createSessionProposalQuery,
+		proposal.SessionID,
+		proposal.ProposedBy,
+		proposal.StartTime,
+		proposal.EndTime,
+		proposal.Title,
+		proposal.Reason,
+		proposal.State,
+		proposal.CounterTo,
+	)
+
+	var m rfrl.SessionProposal
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateSessionProposal")
+}
+
+const getSessionProposalForUpdateQuery string = `
+SELECT * FROM session_proposal
+WHERE session_id = $1 AND id = $2
+FOR UPDATE
+`
+
+func (ss SessionStore) GetSessionProposalForUpdate(db rfrl.DB, sessionID int, ID int) (*rfrl.SessionProposal, error) {
+	var m rfrl.SessionProposal
+
+	err := db.QueryRowx(getSessionProposalForUpdateQuery, sessionID, ID).StructScan(&m)
+
+	return &m, errors.Wrap(err, "GetSessionProposalForUpdate")
+}
+
+const getSessionProposalResponsesQuery string = `
+SELECT * FROM session_proposal_response
+WHERE proposal_id IN (?)
+ORDER BY created_at ASC, client_id ASC
+`
+
+// GetSessionProposals pages through the proposals of the session in the order they were made, with their responses
+func (ss SessionStore) GetSessionProposals(db rfrl.DB, sessionID int, page rfrl.PageOptions) (*[]rfrl.SessionProposal, *rfrl.Cursor, error) {
+	proposals := make([]rfrl.SessionProposal, 0)
+
+	query, err := applyIDPage(
+		sq.Select("*").From("session_proposal").Where(sq.Eq{"session_id": sessionID}),
+		page,
+		"id",
+		false,
+	)
+
+	if err != nil {
+		return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+	}
+
+	for rows.Next() {
+		var proposal rfrl.SessionProposal
+
+		err = rows.StructScan(&proposal)
+
+		if err != nil {
+			return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+		}
+
+		proposal.Responses = make([]rfrl.SessionProposalResponse, 0)
+		proposals = append(proposals, proposal)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(proposals)) {
+		proposals = proposals[:page.Size]
+		next = rfrl.NewSerialIDCursor(proposals[page.Size-1].ID)
+	}
+
+	if len(proposals) == 0 {
+		return &proposals, next, nil
+	}
+
+	idToIndex := make(map[int]int)
+	proposalIDs := make([]int, len(proposals))
+
+	for i, proposal := range proposals {
+		idToIndex[proposal.ID] = i
+		proposalIDs[i] = proposal.ID
+	}
+
+	sql, args, err = sqlx.In(getSessionProposalResponsesQuery, proposalIDs)
+
+	if err != nil {
+		return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+	}
+
+	rows, err = db.Queryx(db.Rebind(sql), args...)
+
+	if err != nil {
+		return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+	}
+
+	for rows.Next() {
+		var response rfrl.SessionProposalResponse
+
+		err = rows.StructScan(&response)
+
+		if err != nil {
+			return &proposals, nil, errors.Wrap(err, "GetSessionProposals")
+		}
+
+		if index, ok := idToIndex[response.ProposalID]; ok {
+			proposals[index].Responses = append(proposals[index].Responses, response)
+		}
+	}
+
+	return &proposals, next, nil
+}
+
+const updateSessionProposalStateQuery string = `
+UPDATE session_proposal
+SET state = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (ss SessionStore) UpdateSessionProposalState(db rfrl.DB, ID int, state string) (*rfrl.SessionProposal, error) {
+	var m rfrl.SessionProposal
+
+	err := db.QueryRowx(updateSessionProposalStateQuery, ID, state).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateSessionProposalState")
+}
+
+const closeOpenSessionProposalsQuery string = `
+UPDATE session_proposal
+SET 
+	state = CASE WHEN proposed_by = $2 THEN 'withdrawn' ELSE 'countered' END,
+	updated_at = CURRENT_TIMESTAMP
+WHERE session_id = $1 AND state = 'open'
+`
+
+func (ss SessionStore) CloseOpenSessionProposals(db rfrl.DB, sessionID int, clientID string) error {
+	_, err := db.Queryx(closeOpenSessionProposalsQuery, sessionID, clientID)
+
+	return errors.Wrap(err, "CloseOpenSessionProposals")
+}
+
+const createSessionProposalResponseQuery string = `
+INSERT INTO session_proposal_response (proposal_id, client_id, accepted)
+VALUES ($1, $2, $3)
+ON CONFLICT (proposal_id, client_id)
+DO UPDATE
+SET accepted = $3, created_at = CURRENT_TIMESTAMP
+`
+
+func (ss SessionStore) CreateSessionProposalResponse(db rfrl.DB, proposalID int, clientID string, accepted bool) error {
+	_, err := db.Queryx(createSessionProposalResponseQuery, proposalID, clientID, accepted)
+
+	return errors.Wrap(err, "CreateSessionProposalResponse")
+}
+
+const checkAllClientsAcceptedSessionProposalQuery string = `
+SELECT NOT EXISTS(
+	SELECT 1 FROM session_proposal
+	JOIN session_client ON session_client.session_id = session_proposal.session_id
+	LEFT JOIN session_proposal_response ON 
+		session_proposal_response.proposal_id = session_proposal.id AND
+		session_proposal_response.client_id = session_client.client_id
+	WHERE session_proposal.id = $1 
+	AND session_client.client_id <> session_proposal.proposed_by
+	AND session_proposal_response.accepted IS NOT TRUE
+)
+`
+
+func (ss SessionStore) CheckAllClientsAcceptedSessionProposal(db rfrl.DB, proposalID int) (bool, error) {
+	allAccepted := false
+
+	err := db.QueryRowx(checkAllClientsAcceptedSessionProposalQuery, proposalID).Scan(&allAccepted)
+
+	return allAccepted, errors.Wrap(err, "CheckAllClientsAcceptedSessionProposal")
+}
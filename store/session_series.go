@@ -0,0 +1,148 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/pkg/errors"
+)
+
+// SessionSeriesStore holds all store related function for session series
+type SessionSeriesStore struct{}
+
+// NewSessionSeriesStore creates new SessionSeriesStore
+func NewSessionSeriesStore() *SessionSeriesStore {
+	return &SessionSeriesStore{}
+}
+
+const createSessionSeriesQuery string = `
+INSERT INTO session_series (tutor_id, created_by, room_id, rrule, start_time, end_time, title, state)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *
+`
+
+func (sss SessionSeriesStore) CreateSessionSeries(db rfrl.DB, series *rfrl.SessionSeries) (*rfrl.SessionSeries, error) {
+	row := db.QueryRowx(
+		createSessionSeriesQuery,
+		series.TutorID,
+		series.CreatedBy,
+		series.RoomID,
+		series.Rule,
+		series.StartTime,
+		series.EndTime,
+		series.Title,
+		series.State,
+	)
+
+	var m rfrl.SessionSeries
+
+	err := row.StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateSessionSeries")
+}
+
+const getSessionSeriesQuery string = `
+SELECT * FROM session_series
+WHERE id = $1
+`
+
+func (sss SessionSeriesStore) GetSessionSeries(db rfrl.DB, ID int) (*rfrl.SessionSeries, error) {
+	var m rfrl.SessionSeries
+
+	err := db.QueryRowx(getSessionSeriesQuery, ID).StructScan(&m)
+
+	return &m, errors.Wrap(err, "GetSessionSeries")
+}
+
+const getSessionSeriesForUpdateQuery string = `
+SELECT * FROM session_series
+WHERE id = $1
+FOR UPDATE
+`
+
+func (sss SessionSeriesStore) GetSessionSeriesForUpdate(db rfrl.DB, ID int) (*rfrl.SessionSeries, error) {
+	var m rfrl.SessionSeries
+
+	err := db.QueryRowx(getSessionSeriesForUpdateQuery, ID).StructScan(&m)
+
+	return &m, errors.Wrap(err, "GetSessionSeriesForUpdate")
+}
+
+const updateSessionSeriesStateQuery string = `
+UPDATE session_series
+SET state = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (sss SessionSeriesStore) UpdateSessionSeriesState(db rfrl.DB, ID int, state string) (*rfrl.SessionSeries, error) {
+	var m rfrl.SessionSeries
+
+	err := db.QueryRowx(updateSessionSeriesStateQuery, ID, state).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpdateSessionSeriesState")
+}
+
+const getSessionSeriesSessionsQuery string = `
+SELECT tutor_session.* FROM tutor_session
+LEFT JOIN scheduled_event ON scheduled_event.id = tutor_session.event_id
+WHERE tutor_session.series_id = $1
+ORDER BY scheduled_event.start_time ASC, tutor_session.id ASC
+`
+
+func (sss SessionSeriesStore) GetSessionSeriesSessions(db rfrl.DB, clientID string, seriesID int) (*[]rfrl.Session, error) {
+	rows, err := db.Queryx(getSessionSeriesSessionsQuery, seriesID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetSessionSeriesSessions")
+	}
+
+	return getSessionWithClients(db, rows, clientID)
+}
+
+const getUpcomingSessionSeriesSessionsForUpdateQuery string = `
+SELECT tutor_session.* FROM tutor_session
+INNER JOIN scheduled_event ON scheduled_event.id = tutor_session.event_id
+WHERE tutor_session.series_id = $1 AND
+	tutor_session.state IN ('pending', 'scheduled') AND
+	scheduled_event.start_time > CURRENT_TIMESTAMP
+ORDER BY scheduled_event.start_time ASC
+FOR UPDATE OF tutor_session
+`
+
+func (sss SessionSeriesStore) GetUpcomingSessionSeriesSessionsForUpdate(db rfrl.DB, seriesID int) (*[]rfrl.Session, error) {
+	sessions := make([]rfrl.Session, 0)
+
+	rows, err := db.Queryx(getUpcomingSessionSeriesSessionsForUpdateQuery, seriesID)
+
+	if err != nil {
+		return &sessions, errors.Wrap(err, "GetUpcomingSessionSeriesSessionsForUpdate")
+	}
+
+	for rows.Next() {
+		var session rfrl.Session
+
+		err = rows.StructScan(&session)
+
+		if err != nil {
+			return &sessions, errors.Wrap(err, "GetUpcomingSessionSeriesSessionsForUpdate")
+		}
+		sessions = append(sessions, session)
+	}
+
+	return &sessions, nil
+}
+
+const checkSessionSeriesIsForClientQuery string = `
+SELECT EXISTS(
+	SELECT 1 FROM tutor_session
+	INNER JOIN session_client ON session_client.session_id = tutor_session.id
+	WHERE tutor_session.series_id = $1 AND session_client.client_id = $2
+)
+`
+
+func (sss SessionSeriesStore) CheckSessionSeriesIsForClient(db rfrl.DB, clientID string, seriesID int) (bool, error) {
+	var exists bool
+
+	err := db.QueryRowx(checkSessionSeriesIsForClientQuery, seriesID, clientID).Scan(&exists)
+
+	return exists, errors.Wrap(err, "CheckSessionSeriesIsForClient")
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// TutorApplicationStore holds all store related functions for tutor applications
+type TutorApplicationStore struct{}
+
+// NewTutorApplicationStore creates new TutorApplicationStore
+func NewTutorApplicationStore() *TutorApplicationStore {
+	return &TutorApplicationStore{}
+}
+
+const createTutorApplicationQuery string = `
+INSERT INTO tutor_application (client_id, state, motivation)
+VALUES ($1, $2, $3)
+RETURNING *
+`
+
+func (tas TutorApplicationStore) CreateTutorApplication(db rfrl.DB, application *rfrl.TutorApplication) (*rfrl.TutorApplication, error) {
+	var m rfrl.TutorApplication
+
+	err := db.QueryRowx(
+		createTutorApplicationQuery,
+		application.ClientID,
+		application.State,
+		application.Motivation,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateTutorApplication")
+}
+
+const getTutorApplicationQuery string = `
+SELECT * FROM tutor_application
+WHERE id = $1
+`
+
+func (tas TutorApplicationStore) GetTutorApplication(db rfrl.DB, ID int) (*rfrl.TutorApplication, error) {
+	var m rfrl.TutorApplication
+
+	err := db.QueryRowx(getTutorApplicationQuery, ID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorApplication")
+	}
+
+	return &m, nil
+}
+
+const getTutorApplicationForUpdateQuery string = `
+SELECT * FROM tutor_application
+WHERE id = $1
+FOR UPDATE
+`
+
+func (tas TutorApplicationStore) GetTutorApplicationForUpdate(db rfrl.DB, ID int) (*rfrl.TutorApplication, error) {
+	var m rfrl.TutorApplication
+
+	err := db.QueryRowx(getTutorApplicationForUpdateQuery, ID).StructScan(&m)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorApplicationForUpdate")
+	}
+
+	return &m, nil
+}
+
+// GetTutorApplications lists applications, pending ones oldest first so they are reviewed in order and the rest newest first
+func (tas TutorApplicationStore) GetTutorApplications(
+	db rfrl.DB,
+	options rfrl.GetTutorApplicationsOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.TutorApplication, *rfrl.Cursor, error) {
+	query := sq.Select("*").From("tutor_application")
+
+	if options.State.Valid {
+		query = query.Where(sq.Eq{"state": options.State.String})
+	}
+
+	if options.ClientID.Valid {
+		query = query.Where(sq.Eq{"client_id": options.ClientID.String})
+	}
+
+	applications := make([]rfrl.TutorApplication, 0)
+
+	query, err := applyIDPage(query, page, "id", options.State.String != rfrl.TUTOR_APPLICATION_PENDING)
+
+	if err != nil {
+		return &applications, nil, errors.Wrap(err, "GetTutorApplications")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return &applications, nil, errors.Wrap(err, "GetTutorApplications")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &applications, nil, errors.Wrap(err, "GetTutorApplications")
+	}
+
+	for rows.Next() {
+		var application rfrl.TutorApplication
+
+		err = rows.StructScan(&application)
+
+		if err != nil {
+			return &applications, nil, errors.Wrap(err, "GetTutorApplications")
+		}
+		applications = append(applications, application)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(applications)) {
+		applications = applications[:page.Size]
+		next = rfrl.NewSerialIDCursor(applications[page.Size-1].ID)
+	}
+
+	return &applications, next, nil
+}
+
+const getClientTutorApplicationsQuery string = `
+SELECT * FROM tutor_application
+WHERE client_id = $1
+ORDER BY id DESC
+`
+
+func (tas TutorApplicationStore) GetClientTutorApplications(db rfrl.DB, clientID string) (*[]rfrl.TutorApplication, error) {
+	applications := make([]rfrl.TutorApplication, 0)
+
+	rows, err := db.Queryx(getClientTutorApplicationsQuery, clientID)
+
+	if err != nil {
+		return &applications, errors.Wrap(err, "GetClientTutorApplications")
+	}
+
+	for rows.Next() {
+		var application rfrl.TutorApplication
+		err = rows.StructScan(&application)
+		if err != nil {
+			return &applications, errors.Wrap(err, "GetClientTutorApplications")
+		}
+		applications = append(applications, application)
+	}
+
+	return &applications, nil
+}
+
+const hasPendingTutorApplicationQuery string = `
+SELECT EXISTS (
+	SELECT 1 FROM tutor_application
+	WHERE client_id = $1 AND state = 'pending'
+)
+`
+
+func (tas TutorApplicationStore) HasPendingTutorApplication(db rfrl.DB, clientID string) (bool, error) {
+	exists := false
+
+	err := db.QueryRowx(hasPendingTutorApplicationQuery, clientID).Scan(&exists)
+
+	return exists, errors.Wrap(err, "HasPendingTutorApplication")
+}
+
+const reviewTutorApplicationQuery string = `
+UPDATE tutor_application
+SET state = $2, reviewed_by = $3, review_notes = $4, reviewed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *
+`
+
+func (tas TutorApplicationStore) ReviewTutorApplication(
+	db rfrl.DB,
+	ID int,
+	state string,
+	reviewedBy string,
+	notes string,
+) (*rfrl.TutorApplication, error) {
+	var m rfrl.TutorApplication
+
+	err := db.QueryRowx(
+		reviewTutorApplicationQuery,
+		ID,
+		state,
+		reviewedBy,
+		null.NewString(notes, notes != ""),
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "ReviewTutorApplication")
+}
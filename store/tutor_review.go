@@ -1,9 +1,12 @@
 package store
 
 import (
+	"fmt"
+
 	"github.com/Arun4rangan/api-rfrl/rfrl"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 )
 
 type TutorReviewStore struct{}
@@ -14,10 +17,11 @@ func NewTutorReviewStore() *TutorReviewStore {
 
 func (trs *TutorReviewStore) CreateTutorReview(db rfrl.DB, ClientID string, tutorReview *rfrl.TutorReview) (*rfrl.TutorReview, error) {
 	sql, args, err := sq.Insert("tutor_review").
-		Columns("tutor_id", "from_id", "stars", "review", "headline").
+		Columns("tutor_id", "from_id", "session_id", "stars", "review", "headline").
 		Values(
 			tutorReview.TutorID,
 			ClientID,
+			tutorReview.SessionID,
 			tutorReview.Stars.Int64,
 			tutorReview.Review.String,
 			tutorReview.Headline.String,
@@ -39,7 +43,7 @@ func (trs *TutorReviewStore) CreateTutorReview(db rfrl.DB, ClientID string, tuto
 
 const checkTutorReviewForClient string = `
 SELECT count(*) FROM tutor_review 
-WHERE client_id = $1 AND id = $2
+WHERE from_id = $1 AND id = $2
 	`
 
 func (trs *TutorReviewStore) CheckTutorReviewForClient(db rfrl.DB, clientID string, id int) (bool, error) {
@@ -111,27 +115,40 @@ func (trs *TutorReviewStore) GetTutorReview(db rfrl.DB, id int) (*rfrl.TutorRevi
 const checkIfReviewAlreadyExistsQuery string = `
 SELECT EXISTS (
 	SELECT 1 FROM tutor_review 
-	WHERE tutor_id = $1 AND from_id = $2
+	WHERE session_id = $1 AND from_id = $2
 )
 `
 
-func (trs *TutorReviewStore) CheckIfReviewAlreadyExists(db rfrl.DB, menteeID string, tutorID string) (bool, error) {
+func (trs *TutorReviewStore) CheckIfReviewAlreadyExists(db rfrl.DB, menteeID string, sessionID int) (bool, error) {
 	exists := false
-	row := db.QueryRowx(checkIfReviewAlreadyExistsQuery, tutorID, menteeID)
+	row := db.QueryRowx(checkIfReviewAlreadyExistsQuery, sessionID, menteeID)
 	err := row.Scan(&exists)
 
 	return exists, errors.Wrap(err, "CheckIfReviewAlreadyExists")
 }
 
-const getTutorReviewsByTutorID string = `
-SELECT * FROM tutor_review
-WHERE tutor_id = $1
-`
+// GetTutorReviews pages through the reviews of the tutor that are not hidden, newest first
+func (trs *TutorReviewStore) GetTutorReviews(db rfrl.DB, tutorID string, page rfrl.PageOptions) (*[]rfrl.TutorReview, *rfrl.Cursor, error) {
+	query, err := applyTimePage(
+		sq.Select("*").From("tutor_review").Where(sq.Eq{"tutor_id": tutorID, "hidden_at": nil}),
+		page,
+		"created_at",
+		"id",
+	)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetTutorReviews")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 
-func (trs *TutorReviewStore) GetTutorReviews(db rfrl.DB, tutorID string) (*[]rfrl.TutorReview, error) {
-	rows, err := db.Queryx(getTutorReviewsByTutorID, tutorID)
 	if err != nil {
-		return nil, errors.Wrap(err, "GetTutorReviews")
+		return nil, nil, errors.Wrap(err, "GetTutorReviews")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetTutorReviews")
 	}
 
 	tutorReviews := make([]rfrl.TutorReview, 0)
@@ -140,29 +157,203 @@ func (trs *TutorReviewStore) GetTutorReviews(db rfrl.DB, tutorID string) (*[]rfr
 		var tutorReview rfrl.TutorReview
 		err = rows.StructScan(&tutorReview)
 		if err != nil {
-			return nil, errors.Wrap(err, "GetTutorReviews")
+			return nil, nil, errors.Wrap(err, "GetTutorReviews")
 		}
 		tutorReviews = append(tutorReviews, tutorReview)
 	}
-	return &tutorReviews, nil
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(tutorReviews)) {
+		tutorReviews = tutorReviews[:page.Size]
+		last := tutorReviews[page.Size-1]
+		next = rfrl.NewTimeCursor(last.CreatedAt, last.ID)
+	}
+
+	return &tutorReviews, next, nil
 }
 
-const getTutorReviewsAggregateByTutorID string = `
-SELECT COALESCE(SUM(stars),0) as total_stars, COALESCE(COUNT(*),1) as total_review_count FROM tutor_review
-WHERE tutor_review.tutor_id = $1
+// tutorRatingsQuery rates every tutor from the totals kept by the tutor_rating triggers. The Bayesian rating
+// adds TutorRatingPriorWeight reviews of the average rating of all tutors to the tutor's own reviews
+var tutorRatingsQuery = fmt.Sprintf(`
+SELECT
+	tutor_rating.tutor_id,
+	tutor_rating.review_count,
+	tutor_rating.total_stars,
+	tutor_rating.total_stars::float / NULLIF(tutor_rating.review_count, 0) AS average_rating,
+	CASE WHEN tutor_rating.review_count > 0 THEN
+		(%[1]d * all_ratings.average_rating + tutor_rating.total_stars) / (%[1]d + tutor_rating.review_count)
+	END AS bayesian_rating
+FROM tutor_rating
+CROSS JOIN (
+	SELECT COALESCE(SUM(total_stars)::float / NULLIF(SUM(review_count), 0), 0) AS average_rating
+	FROM tutor_rating
+) AS all_ratings`, rfrl.TutorRatingPriorWeight)
+
+var getTutorReviewsAggregateQuery = `
+SELECT
+	COALESCE(rating.total_stars, 0) AS total_stars,
+	COALESCE(rating.review_count, 0) AS total_review_count,
+	rating.average_rating,
+	rating.bayesian_rating
+FROM (SELECT 1) AS tutor
+LEFT JOIN (` + tutorRatingsQuery + `) AS rating ON rating.tutor_id = $1
 `
 
-func (trs *TutorReviewStore) GetTutorReviewsAggregate(db rfrl.DB, tutorID string) (*rfrl.TutorReviewAggregate, error) {
-	row := db.QueryRowx(getTutorReviewsAggregateByTutorID, tutorID)
+const getTutorRatingStarsQuery string = `
+SELECT stars, review_count FROM tutor_rating_star
+WHERE tutor_id = $1 AND review_count > 0
+`
 
+const getTutorRatingTrendQuery string = `
+SELECT
+	COALESCE(SUM(review_count) FILTER (WHERE day > CURRENT_DATE - $2::int), 0) AS recent_review_count,
+	SUM(total_stars) FILTER (WHERE day > CURRENT_DATE - $2::int)::float /
+		NULLIF(SUM(review_count) FILTER (WHERE day > CURRENT_DATE - $2::int), 0) AS recent_average_rating,
+	SUM(total_stars) FILTER (WHERE day <= CURRENT_DATE - $2::int)::float /
+		NULLIF(SUM(review_count) FILTER (WHERE day <= CURRENT_DATE - $2::int), 0) AS previous_average_rating
+FROM tutor_rating_day
+WHERE tutor_id = $1 AND day > CURRENT_DATE - 2 * $2::int
+`
+
+// GetTutorReviewsAggregate gets the tutor's rating, how their reviews are spread over the stars and how
+// their rating changed recently
+func (trs *TutorReviewStore) GetTutorReviewsAggregate(db rfrl.DB, tutorID string) (*rfrl.TutorReviewAggregate, error) {
 	var aggregate rfrl.TutorReviewAggregate
-	err := row.StructScan(&aggregate)
 
-	return &aggregate, errors.Wrap(err, "GetTutorReviewsAggregate")
+	err := db.QueryRowx(getTutorReviewsAggregateQuery, tutorID).StructScan(&aggregate)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorReviewsAggregate")
+	}
+
+	rows, err := db.Queryx(getTutorRatingStarsQuery, tutorID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorReviewsAggregate")
+	}
+
+	aggregate.StarDistribution = make(map[int]int)
+
+	for rows.Next() {
+		var count rfrl.TutorRatingStarCount
+
+		err = rows.StructScan(&count)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "GetTutorReviewsAggregate")
+		}
+
+		aggregate.StarDistribution[count.Stars] = count.ReviewCount
+	}
+
+	err = db.QueryRowx(getTutorRatingTrendQuery, tutorID, rfrl.TutorRatingTrendDays).StructScan(&aggregate.Trend)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorReviewsAggregate")
+	}
+
+	aggregate.Trend.Days = rfrl.TutorRatingTrendDays
+
+	return &aggregate, nil
+}
+
+// getTutorReviewsAggregateBySessionTypeQuery splits reviews between one off and recurring sessions,
+// reviews left before they were linked to sessions are unverified
+const getTutorReviewsAggregateBySessionTypeQuery string = `
+SELECT
+	CASE
+		WHEN tutor_review.session_id IS NULL THEN 'unverified'
+		WHEN tutor_session.series_id IS NULL THEN 'single'
+		ELSE 'recurring'
+	END AS session_type,
+	SUM(tutor_review.stars) AS total_stars,
+	COUNT(*) AS total_review_count,
+	AVG(tutor_review.stars)::float AS average_rating
+FROM tutor_review
+LEFT JOIN tutor_session ON tutor_session.id = tutor_review.session_id
+WHERE tutor_review.tutor_id = $1 AND tutor_review.hidden_at IS NULL
+GROUP BY session_type
+ORDER BY session_type
+`
+
+func (trs *TutorReviewStore) GetTutorReviewsAggregateBySessionType(db rfrl.DB, tutorID string) (*[]rfrl.TutorReviewSessionTypeAggregate, error) {
+	aggregates := make([]rfrl.TutorReviewSessionTypeAggregate, 0)
+
+	rows, err := db.Queryx(getTutorReviewsAggregateBySessionTypeQuery, tutorID)
+
+	if err != nil {
+		return &aggregates, errors.Wrap(err, "GetTutorReviewsAggregateBySessionType")
+	}
+
+	for rows.Next() {
+		var aggregate rfrl.TutorReviewSessionTypeAggregate
+
+		err = rows.StructScan(&aggregate)
+
+		if err != nil {
+			return &aggregates, errors.Wrap(err, "GetTutorReviewsAggregateBySessionType")
+		}
+
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return &aggregates, nil
+}
+
+// GetTutorReviewsAggregateByPeriod aggregates reviews per week or month, oldest period first
+func (trs *TutorReviewStore) GetTutorReviewsAggregateByPeriod(
+	db rfrl.DB,
+	tutorID string,
+	period string,
+	since null.Time,
+) (*[]rfrl.TutorReviewPeriodAggregate, error) {
+	aggregates := make([]rfrl.TutorReviewPeriodAggregate, 0)
+
+	query := sq.
+		Select().
+		Column("DATE_TRUNC(?, created_at) AS period", period).
+		Columns("SUM(stars) AS total_stars", "COUNT(*) AS total_review_count", "AVG(stars)::float AS average_rating").
+		From("tutor_review").
+		Where(sq.Eq{"tutor_id": tutorID, "hidden_at": nil})
+
+	if since.Valid {
+		query = query.Where(sq.GtOrEq{"created_at": since.Time})
+	}
+
+	sql, args, err := query.
+		GroupBy("period").
+		OrderBy("period").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		return &aggregates, errors.Wrap(err, "GetTutorReviewsAggregateByPeriod")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return &aggregates, errors.Wrap(err, "GetTutorReviewsAggregateByPeriod")
+	}
+
+	for rows.Next() {
+		var aggregate rfrl.TutorReviewPeriodAggregate
+
+		err = rows.StructScan(&aggregate)
+
+		if err != nil {
+			return &aggregates, errors.Wrap(err, "GetTutorReviewsAggregateByPeriod")
+		}
+
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return &aggregates, nil
 }
 
 const getPendingReviewsQuery string = `
-SELECT tutor_id, first_name, last_name 
+SELECT session_id, tutor_id, first_name, last_name 
 FROM pending_tutor_review
 JOIN client on pending_tutor_review.tutor_id = client.id
 WHERE mentee_id = $1
@@ -192,23 +383,23 @@ func (trs *TutorReviewStore) GetPendingReviews(db rfrl.DB, menteeID string) (*[]
 }
 
 const createPendingReviewQuery string = `
-INSERT INTO pending_tutor_review (mentee_id, tutor_id)
-VALUES ($1, $2)
+INSERT INTO pending_tutor_review (mentee_id, tutor_id, session_id)
+VALUES ($1, $2, $3)
 `
 
-func (trs *TutorReviewStore) CreatePendingReview(db rfrl.DB, menteeID string, tutorID string) error {
-	_, err := db.Queryx(createPendingReviewQuery, menteeID, tutorID)
+func (trs *TutorReviewStore) CreatePendingReview(db rfrl.DB, menteeID string, tutorID string, sessionID int) error {
+	_, err := db.Queryx(createPendingReviewQuery, menteeID, tutorID, sessionID)
 
 	return errors.Wrap(err, "CreatePendingReview")
 }
 
 const deletePendingReviewQuery string = `
 DELETE FROM pending_tutor_review
-WHERE mentee_id = $1 AND tutor_id = $2
+WHERE mentee_id = $1 AND session_id = $2
 `
 
-func (trs *TutorReviewStore) DeletePendingReview(db rfrl.DB, menteeID string, tutorID string) error {
-	_, err := db.Queryx(deletePendingReviewQuery, menteeID, tutorID)
+func (trs *TutorReviewStore) DeletePendingReview(db rfrl.DB, menteeID string, sessionID int) error {
+	_, err := db.Queryx(deletePendingReviewQuery, menteeID, sessionID)
 
 	return errors.Wrap(err, "CreatePendingReview")
 }
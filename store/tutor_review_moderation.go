@@ -0,0 +1,307 @@
+package store
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const getTutorReviewForUpdateQuery string = `
+SELECT * FROM tutor_review
+WHERE id = $1
+FOR UPDATE
+`
+
+func (trs *TutorReviewStore) GetTutorReviewForUpdate(db rfrl.DB, id int) (*rfrl.TutorReview, error) {
+	var tutorReview rfrl.TutorReview
+
+	err := db.QueryRowx(getTutorReviewForUpdateQuery, id).StructScan(&tutorReview)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTutorReviewForUpdate")
+	}
+
+	return &tutorReview, nil
+}
+
+const hideTutorReviewQuery string = `
+UPDATE tutor_review
+SET hidden_at = CURRENT_TIMESTAMP, hidden_by = $2
+WHERE id = $1
+RETURNING *
+`
+
+const restoreTutorReviewQuery string = `
+UPDATE tutor_review
+SET hidden_at = NULL, hidden_by = NULL
+WHERE id = $1
+RETURNING *
+`
+
+// SetTutorReviewHidden hides the review from other clients or shows it again
+func (trs *TutorReviewStore) SetTutorReviewHidden(db rfrl.DB, id int, hidden bool, by string) (*rfrl.TutorReview, error) {
+	var row *sqlx.Row
+
+	if hidden {
+		row = db.QueryRowx(hideTutorReviewQuery, id, by)
+	} else {
+		row = db.QueryRowx(restoreTutorReviewQuery, id)
+	}
+
+	var tutorReview rfrl.TutorReview
+
+	err := row.StructScan(&tutorReview)
+
+	return &tutorReview, errors.Wrap(err, "SetTutorReviewHidden")
+}
+
+const createTutorReviewFlagQuery string = `
+INSERT INTO tutor_review_flag (review_id, flagged_by, reason, state)
+VALUES ($1, $2, $3, $4)
+RETURNING *
+`
+
+func (trs *TutorReviewStore) CreateTutorReviewFlag(db rfrl.DB, flag *rfrl.TutorReviewFlag) (*rfrl.TutorReviewFlag, error) {
+	var m rfrl.TutorReviewFlag
+
+	err := db.QueryRowx(
+		createTutorReviewFlagQuery,
+		flag.ReviewID,
+		flag.FlaggedBy,
+		flag.Reason,
+		flag.State,
+	).StructScan(&m)
+
+	return &m, errors.Wrap(err, "CreateTutorReviewFlag")
+}
+
+const checkOpenTutorReviewFlagQuery string = `
+SELECT EXISTS (
+	SELECT 1 FROM tutor_review_flag
+	WHERE review_id = $1 AND flagged_by = $2 AND state = 'open'
+)
+`
+
+func (trs *TutorReviewStore) CheckOpenTutorReviewFlag(db rfrl.DB, reviewID int, clientID string) (bool, error) {
+	exists := false
+
+	err := db.QueryRowx(checkOpenTutorReviewFlagQuery, reviewID, clientID).Scan(&exists)
+
+	return exists, errors.Wrap(err, "CheckOpenTutorReviewFlag")
+}
+
+// GetFlaggedTutorReviews pages through reviews with open flags, oldest review first
+func (trs *TutorReviewStore) GetFlaggedTutorReviews(db rfrl.DB, page rfrl.PageOptions) (*[]rfrl.TutorReview, *rfrl.Cursor, error) {
+	query, err := applyIDPage(
+		sq.Select("tutor_review.*").
+			From("tutor_review").
+			Where("EXISTS (SELECT 1 FROM tutor_review_flag WHERE tutor_review_flag.review_id = tutor_review.id AND tutor_review_flag.state = 'open')"),
+		page,
+		"tutor_review.id",
+		false,
+	)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetFlaggedTutorReviews")
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetFlaggedTutorReviews")
+	}
+
+	rows, err := db.Queryx(sql, args...)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetFlaggedTutorReviews")
+	}
+
+	tutorReviews := make([]rfrl.TutorReview, 0)
+
+	for rows.Next() {
+		var tutorReview rfrl.TutorReview
+		err = rows.StructScan(&tutorReview)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "GetFlaggedTutorReviews")
+		}
+		tutorReviews = append(tutorReviews, tutorReview)
+	}
+
+	var next *rfrl.Cursor
+
+	if page.HasNext(len(tutorReviews)) {
+		tutorReviews = tutorReviews[:page.Size]
+		next = rfrl.NewSerialIDCursor(tutorReviews[page.Size-1].ID)
+	}
+
+	return &tutorReviews, next, nil
+}
+
+const getOpenTutorReviewFlagsQuery string = `
+SELECT * FROM tutor_review_flag
+WHERE review_id IN (?) AND state = 'open'
+ORDER BY id
+`
+
+func (trs *TutorReviewStore) GetOpenTutorReviewFlags(db rfrl.DB, reviewIDs []int) (*[]rfrl.TutorReviewFlag, error) {
+	flags := make([]rfrl.TutorReviewFlag, 0)
+
+	query, args, err := sqlx.In(getOpenTutorReviewFlagsQuery, reviewIDs)
+
+	if err != nil {
+		return &flags, errors.Wrap(err, "GetOpenTutorReviewFlags")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return &flags, errors.Wrap(err, "GetOpenTutorReviewFlags")
+	}
+
+	for rows.Next() {
+		var flag rfrl.TutorReviewFlag
+		err = rows.StructScan(&flag)
+		if err != nil {
+			return &flags, errors.Wrap(err, "GetOpenTutorReviewFlags")
+		}
+		flags = append(flags, flag)
+	}
+
+	return &flags, nil
+}
+
+const resolveTutorReviewFlagsQuery string = `
+UPDATE tutor_review_flag
+SET state = $2, resolved_by = $3, resolved_at = CURRENT_TIMESTAMP
+WHERE review_id = $1 AND state = 'open'
+`
+
+func (trs *TutorReviewStore) ResolveTutorReviewFlags(db rfrl.DB, reviewID int, state string, by string) error {
+	rows, err := db.Queryx(resolveTutorReviewFlagsQuery, reviewID, state, by)
+
+	if err != nil {
+		return errors.Wrap(err, "ResolveTutorReviewFlags")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const upsertTutorReviewReplyQuery string = `
+INSERT INTO tutor_review_reply (review_id, tutor_id, reply)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id) DO UPDATE SET reply = EXCLUDED.reply, updated_at = CURRENT_TIMESTAMP
+RETURNING *
+`
+
+func (trs *TutorReviewStore) UpsertTutorReviewReply(db rfrl.DB, reviewID int, tutorID string, reply string) (*rfrl.TutorReviewReply, error) {
+	var m rfrl.TutorReviewReply
+
+	err := db.QueryRowx(upsertTutorReviewReplyQuery, reviewID, tutorID, reply).StructScan(&m)
+
+	return &m, errors.Wrap(err, "UpsertTutorReviewReply")
+}
+
+const deleteTutorReviewReplyQuery string = `
+DELETE FROM tutor_review_reply
+WHERE review_id = $1
+`
+
+func (trs *TutorReviewStore) DeleteTutorReviewReply(db rfrl.DB, reviewID int) error {
+	rows, err := db.Queryx(deleteTutorReviewReplyQuery, reviewID)
+
+	if err != nil {
+		return errors.Wrap(err, "DeleteTutorReviewReply")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const getTutorReviewRepliesQuery string = `
+SELECT * FROM tutor_review_reply
+WHERE review_id IN (?)
+`
+
+func (trs *TutorReviewStore) GetTutorReviewReplies(db rfrl.DB, reviewIDs []int) (*[]rfrl.TutorReviewReply, error) {
+	replies := make([]rfrl.TutorReviewReply, 0)
+
+	query, args, err := sqlx.In(getTutorReviewRepliesQuery, reviewIDs)
+
+	if err != nil {
+		return &replies, errors.Wrap(err, "GetTutorReviewReplies")
+	}
+
+	rows, err := db.Queryx(db.Rebind(query), args...)
+
+	if err != nil {
+		return &replies, errors.Wrap(err, "GetTutorReviewReplies")
+	}
+
+	for rows.Next() {
+		var reply rfrl.TutorReviewReply
+		err = rows.StructScan(&reply)
+		if err != nil {
+			return &replies, errors.Wrap(err, "GetTutorReviewReplies")
+		}
+		replies = append(replies, reply)
+	}
+
+	return &replies, nil
+}
+
+const createTutorReviewRevisionQuery string = `
+INSERT INTO tutor_review_revision (review_id, revised_by, stars, review, headline)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+// CreateTutorReviewRevision keeps the review as it is before it is edited
+func (trs *TutorReviewStore) CreateTutorReviewRevision(db rfrl.DB, review *rfrl.TutorReview, revisedBy string) error {
+	rows, err := db.Queryx(
+		createTutorReviewRevisionQuery,
+		review.ID,
+		revisedBy,
+		review.Stars,
+		review.Review,
+		review.Headline,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "CreateTutorReviewRevision")
+	}
+
+	rows.Close()
+
+	return nil
+}
+
+const getTutorReviewRevisionsQuery string = `
+SELECT * FROM tutor_review_revision
+WHERE review_id = $1
+ORDER BY id
+`
+
+func (trs *TutorReviewStore) GetTutorReviewRevisions(db rfrl.DB, reviewID int) (*[]rfrl.TutorReviewRevision, error) {
+	revisions := make([]rfrl.TutorReviewRevision, 0)
+
+	rows, err := db.Queryx(getTutorReviewRevisionsQuery, reviewID)
+
+	if err != nil {
+		return &revisions, errors.Wrap(err, "GetTutorReviewRevisions")
+	}
+
+	for rows.Next() {
+		var revision rfrl.TutorReviewRevision
+		err = rows.StructScan(&revision)
+		if err != nil {
+			return &revisions, errors.Wrap(err, "GetTutorReviewRevisions")
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return &revisions, nil
+}
@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/gommon/log"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// AccountUseCase holds all business related functions for exporting and deleting accounts
+type AccountUseCase struct {
+	DB                    *sqlx.DB
+	AccountStore          rfrl.AccountStore
+	ClientStore           rfrl.ClientStore
+	SessionStore          rfrl.SessionStore
+	FireStore             rfrl.FireStoreClient
+	TutorApplicationStore rfrl.TutorApplicationStore
+}
+
+func NewAccountUseCase(
+	db sqlx.DB,
+	accountStore rfrl.AccountStore,
+	clientStore rfrl.ClientStore,
+	sessionStore rfrl.SessionStore,
+	fireStore rfrl.FireStoreClient,
+	tutorApplicationStore rfrl.TutorApplicationStore,
+) *AccountUseCase {
+	return &AccountUseCase{&db, accountStore, clientStore, sessionStore, fireStore, tutorApplicationStore}
+}
+
+// ExportAccount gathers everything stored about the client
+func (au AccountUseCase) ExportAccount(clientID string) (*rfrl.AccountExport, error) {
+	export := rfrl.AccountExport{ExportedAt: time.Now()}
+
+	client, err := au.ClientStore.GetClientFromID(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Profile = *client
+
+	auths, err := au.AccountStore.GetAuthMetadata(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Auth = *auths
+
+	educations, err := au.ClientStore.GetEducations(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Educations = *educations
+
+	positions, err := au.ClientStore.GetWorkPositions(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.WorkPositions = *positions
+
+	tags, err := au.ClientStore.GetClientTags(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Tags = *tags
+
+	documents, err := au.AccountStore.GetClientDocuments(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Documents = *documents
+
+	sessions, err := au.AccountStore.GetClientSessions(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Sessions = *sessions
+
+	events, err := au.ClientStore.GetRelatedEventsByClientIDs(
+		au.DB,
+		[]string{clientID},
+		null.NewTime(time.Time{}, false),
+		null.NewTime(time.Time{}, false),
+		null.NewString("", false),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Events = *events
+
+	questions, err := au.AccountStore.GetClientQuestions(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.Questions = *questions
+
+	questionAnswers, err := au.AccountStore.GetClientQuestionAnswers(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.QuestionAnswers = *questionAnswers
+
+	reviewsWritten, err := au.AccountStore.GetReviewsWritten(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.ReviewsWritten = *reviewsWritten
+
+	reviewsReceived, err := au.AccountStore.GetReviewsReceived(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.ReviewsReceived = *reviewsReceived
+
+	reports, err := au.AccountStore.GetReportsMade(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.ReportsMade = *reports
+
+	applications, err := au.TutorApplicationStore.GetClientTutorApplications(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.TutorApplications = *applications
+
+	feedbackGiven, err := au.AccountStore.GetMenteeFeedbackGiven(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.FeedbackGiven = *feedbackGiven
+
+	feedbackReceived, err := au.AccountStore.GetMenteeFeedbackReceived(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.FeedbackReceived = *feedbackReceived
+
+	return &export, nil
+}
+
+func (au AccountUseCase) GetAccountDeletion(clientID string) (*rfrl.AccountDeletion, error) {
+	return au.AccountStore.GetAccountDeletion(au.DB, clientID)
+}
+
+// ScheduleAccountDeletion deletes the account once the grace period is over
+func (au AccountUseCase) ScheduleAccountDeletion(clientID string) (*rfrl.AccountDeletion, error) {
+	deletion, err := au.AccountStore.GetAccountDeletion(au.DB, clientID)
+
+	switch {
+	case err == nil && deletion.DeletedAt.Valid:
+		return nil, rfrl.ErrAccountDeleted
+	case err == nil:
+		return nil, rfrl.ErrAccountDeletionScheduled
+	case errors.Cause(err) != sql.ErrNoRows:
+		return nil, err
+	}
+
+	return au.AccountStore.CreateAccountDeletion(au.DB, rfrl.NewAccountDeletion(clientID, time.Now()))
+}
+
+func (au AccountUseCase) CancelAccountDeletion(clientID string) error {
+	return au.AccountStore.DeleteAccountDeletion(au.DB, clientID)
+}
+
+// ProcessAccountDeletions deletes every account whose grace period is over and returns how many were deleted.
+// A failed deletion does not stop the others, it is retried the next time deletions are processed
+func (au AccountUseCase) ProcessAccountDeletions() (int, error) {
+	now := time.Now()
+
+	clientIDs, err := au.AccountStore.GetDueAccountDeletions(au.DB, now)
+
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	var firstErr error
+
+	for _, clientID := range clientIDs {
+		err = au.deleteAccount(clientID, now)
+
+		if err != nil {
+			log.Errorj(log.JSON{"error": err.Error(), "clientID": clientID})
+
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		deleted++
+	}
+
+	return deleted, firstErr
+}
+
+// deleteAccount removes the client from their chats before anonymizing them, both can be done again if the other fails
+func (au AccountUseCase) deleteAccount(clientID string, now time.Time) error {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	*err = au.FireStore.DeleteClient(clientID)
+
+	if *err != nil {
+		return *err
+	}
+
+	tx, *err = au.DB.Beginx()
+
+	if *err != nil {
+		return errors.Wrap(*err, "deleteAccount")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var sessionIDs []int
+	sessionIDs, *err = au.AccountStore.GetCancellableSessionIDs(tx, clientID, now)
+
+	if *err != nil {
+		return *err
+	}
+
+	for _, sessionID := range sessionIDs {
+		_, *err = transitionSession(tx, au.SessionStore, sessionID, clientID, rfrl.CANCELLED, rfrl.AccountDeletionReason)
+
+		if *err != nil {
+			return *err
+		}
+	}
+
+	*err = au.AccountStore.AnonymizeClient(tx, clientID)
+
+	return *err
+}
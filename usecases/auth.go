@@ -14,10 +14,11 @@ import (
 
 // AuthUseCase holds all business related functions for auth
 type AuthUseCase struct {
-	db          *sqlx.DB
-	authStore   rfrl.AuthStore
-	clientStore rfrl.ClientStore
-	fireStore   rfrl.FireStoreClient
+	db                    *sqlx.DB
+	authStore             rfrl.AuthStore
+	clientStore           rfrl.ClientStore
+	fireStore             rfrl.FireStoreClient
+	tutorApplicationStore rfrl.TutorApplicationStore
 }
 
 // NewAuthUseCase creates new AuthUseCase
@@ -26,8 +27,9 @@ func NewAuthUseCase(
 	authStore rfrl.AuthStore,
 	clientStore rfrl.ClientStore,
 	fireStore rfrl.FireStoreClient,
+	tutorApplicationStore rfrl.TutorApplicationStore,
 ) *AuthUseCase {
-	return &AuthUseCase{&db, authStore, clientStore, fireStore}
+	return &AuthUseCase{&db, authStore, clientStore, fireStore, tutorApplicationStore}
 }
 
 // SignupWithToken allows user to sign up with token from google or linkedin auth
@@ -45,12 +47,22 @@ func (au *AuthUseCase) SignupWithToken(newClient *rfrl.Client, auth *rfrl.Auth)
 
 	var createdClient *rfrl.Client
 
+	tutorRequested := takeTutorRequest(newClient)
+
 	createdClient, *err = au.clientStore.CreateClient(tx, newClient)
 
 	if *err != nil {
 		return nil, nil, *err
 	}
 
+	if tutorRequested {
+		*err = applyToTutorIfNeeded(tx, au.tutorApplicationStore, *createdClient)
+
+		if *err != nil {
+			return nil, nil, *err
+		}
+	}
+
 	var createdAuth *rfrl.Auth
 	createdAuth, *err = au.authStore.CreateWithToken(tx, auth, createdClient.ID)
 
@@ -87,7 +99,7 @@ func (au *AuthUseCase) SignupGoogle(
 	isTutor null.Bool,
 ) (*rfrl.Client, *rfrl.Auth, error) {
 
-	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", null.Int{}, "")
+	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", "", "")
 	auth := rfrl.Auth{
 		AuthType: null.NewString(rfrl.GOOGLE, true),
 		Token:    null.StringFrom(token),
@@ -107,7 +119,7 @@ func (au *AuthUseCase) SignupLinkedIn(
 	isTutor null.Bool,
 ) (*rfrl.Client, *rfrl.Auth, error) {
 
-	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", null.Int{}, "")
+	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", "", "")
 
 	auth := rfrl.Auth{
 		AuthType: null.NewString(rfrl.LINKEDIN, true),
@@ -134,7 +146,7 @@ func (au *AuthUseCase) SignupEmail(
 		return nil, nil, errors.Wrap(hashError, "SignupEmail")
 	}
 
-	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", null.Int{}, "")
+	newClient := rfrl.NewClient(firstName, lastName, about, email, photo, isTutor, "", "", "", "")
 	auth := rfrl.Auth{
 		Email:        null.StringFrom(email),
 		PasswordHash: hash,
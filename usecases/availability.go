@@ -0,0 +1,298 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// AvailabilityUseCase holds all business related functions for tutor availability
+type AvailabilityUseCase struct {
+	DB                *sqlx.DB
+	AvailabilityStore rfrl.AvailabilityStore
+	SessionStore      rfrl.SessionStore
+	ClientStore       rfrl.ClientStore
+}
+
+func NewAvailabilityUseCase(
+	db sqlx.DB,
+	availabilityStore rfrl.AvailabilityStore,
+	sessionStore rfrl.SessionStore,
+	clientStore rfrl.ClientStore,
+) *AvailabilityUseCase {
+	return &AvailabilityUseCase{&db, availabilityStore, sessionStore, clientStore}
+}
+
+func (au AvailabilityUseCase) SetTutorAvailability(
+	tutorID string,
+	availability rfrl.TutorAvailability,
+	windows []rfrl.AvailabilityWindow,
+) (*rfrl.TutorAvailability, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = au.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "SetTutorAvailability")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var tutor *rfrl.Client
+	tutor, *err = au.ClientStore.GetClientFromID(tx, tutorID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if !tutor.IsTutor.Valid || !tutor.IsTutor.Bool {
+		*err = errors.New("Only tutors can set their availability")
+		return nil, *err
+	}
+
+	// Tutors that do not pick a timezone for their availability use their own
+	if availability.Timezone == "" {
+		availability.Timezone = clientTimezone([]rfrl.Client{*tutor}, tutorID)
+	}
+
+	*err = rfrl.ValidateTimezone(availability.Timezone)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	availability.TutorID = tutorID
+
+	var updated *rfrl.TutorAvailability
+	updated, *err = au.AvailabilityStore.UpsertTutorAvailability(tx, &availability)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = au.AvailabilityStore.DeleteAvailabilityWindows(tx, tutorID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var insertedWindows *[]rfrl.AvailabilityWindow
+	insertedWindows, *err = au.AvailabilityStore.CreateAvailabilityWindows(tx, tutorID, windows)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	updated.Windows = *insertedWindows
+
+	return updated, nil
+}
+
+func (au AvailabilityUseCase) GetTutorAvailability(tutorID string) (*rfrl.TutorAvailability, error) {
+	return au.AvailabilityStore.GetTutorAvailability(au.DB, tutorID)
+}
+
+func (au AvailabilityUseCase) computeBookableSlots(
+	db rfrl.DB,
+	availability rfrl.TutorAvailability,
+	clientID string,
+	start time.Time,
+	end time.Time,
+) ([]rfrl.BookableSlot, error) {
+	clientIDs := []string{availability.TutorID}
+
+	if clientID != availability.TutorID {
+		clientIDs = append(clientIDs, clientID)
+	}
+
+	// Widen the range so events that straddle the edges still block slots
+	busy, err := au.ClientStore.GetRelatedEventsByClientIDs(
+		db,
+		clientIDs,
+		null.TimeFrom(start.AddDate(0, 0, -1)),
+		null.TimeFrom(end.AddDate(0, 0, 1)),
+		null.StringFrom(string(rfrl.SCHEDULED)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	externalBusy, err := au.ClientStore.GetExternalBusyEventsByClientIDs(
+		db,
+		clientIDs,
+		start.AddDate(0, 0, -1),
+		end.AddDate(0, 0, 1),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	*busy = append(*busy, *externalBusy...)
+
+	tutorSessions, err := au.AvailabilityStore.GetTutorSessionEvents(
+		db,
+		availability.TutorID,
+		start.AddDate(0, 0, -1),
+		end.AddDate(0, 0, 1),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return availability.ComputeBookableSlots(start, end, time.Now(), *busy, *tutorSessions)
+}
+
+func (au AvailabilityUseCase) GetBookableSlots(
+	tutorID string,
+	clientID string,
+	start time.Time,
+	end time.Time,
+) ([]rfrl.BookableSlot, error) {
+	availability, err := au.AvailabilityStore.GetTutorAvailability(au.DB, tutorID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := au.ClientStore.GetClientFromID(au.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := au.computeBookableSlots(au.DB, *availability, clientID, start, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Slots are shown in the timezone of the client looking at them
+	location := rfrl.LoadTimezone(clientTimezone([]rfrl.Client{*client}, clientID))
+
+	for i := 0; i < len(slots); i++ {
+		slots[i].Start = slots[i].Start.In(location)
+		slots[i].End = slots[i].End.In(location)
+	}
+
+	return slots, nil
+}
+
+// BookTutorSlot creates a scheduled session with the tutor for one of their free slots
+func (au AvailabilityUseCase) BookTutorSlot(
+	clientID string,
+	tutorID string,
+	roomID string,
+	start time.Time,
+	title string,
+) (*rfrl.Session, error) {
+	if clientID == tutorID {
+		return nil, errors.New("Cannot book a session with yourself")
+	}
+
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = au.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "BookTutorSlot")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = checkIsTutor(tx, au.ClientStore, tutorID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	// Locks the tutor's availability so that bookings for the same tutor are serialized
+	var availability *rfrl.TutorAvailability
+	availability, *err = au.AvailabilityStore.GetTutorAvailabilityForUpdate(tx, tutorID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	end := start.Add(time.Duration(availability.SlotMinutes) * time.Minute)
+
+	var slots []rfrl.BookableSlot
+	slots, *err = au.computeBookableSlots(tx, *availability, clientID, start, end)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if len(slots) == 0 || !slots[0].Start.Equal(start) {
+		*err = errors.Wrapf(rfrl.ErrSlotNotAvailable, "Slot at %s is not available", start.Format(time.RFC3339))
+		return nil, *err
+	}
+
+	session := rfrl.NewSession(tutorID, clientID, roomID, rfrl.PENDING)
+	clientIDs := []string{tutorID, clientID}
+
+	session, *err = au.SessionStore.CreateSession(tx, session)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var sessionClients *[]rfrl.Client
+	sessionClients, *err = au.SessionStore.CreateSessionClients(tx, session.ID, clientIDs)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	event := *rfrl.NewEvent(slots[0].Start, slots[0].End, title)
+	event.Timezone = clientTimezone(*sessionClients, clientID)
+
+	var insertedEvents *[]rfrl.Event
+	insertedEvents, *err = au.SessionStore.CreateSessionEvents(tx, []rfrl.Event{event})
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	event = (*insertedEvents)[0]
+
+	_, *err = au.SessionStore.UpdateSession(
+		tx,
+		session.ID,
+		clientID,
+		null.IntFrom(int64(event.ID)),
+		null.NewString("", false),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	// The tutor agreed to the slot by publishing it, so both sides can attend
+	for i := 0; i < len(clientIDs); i++ {
+		*err = au.SessionStore.CreateClientSelectionOfEvent(tx, session.ID, clientIDs[i], true)
+
+		if *err != nil {
+			return nil, *err
+		}
+	}
+
+	session, *err = transitionSession(tx, au.SessionStore, session.ID, clientID, rfrl.SCHEDULED, "")
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	session.Clients = *sessionClients
+	session.CanAttend = null.BoolFrom(true)
+	session.Event = &event
+
+	return session, nil
+}
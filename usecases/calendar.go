@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// calendarFeedHistory is how far back the subscription feed includes events
+const calendarFeedHistory = 90 * 24 * time.Hour
+
+// CalendarUseCase holds all business related functions for calendar exports
+type CalendarUseCase struct {
+	DB            *sqlx.DB
+	CalendarStore rfrl.CalendarStore
+	SessionStore  rfrl.SessionStore
+	ClientStore   rfrl.ClientStore
+}
+
+func NewCalendarUseCase(
+	db sqlx.DB,
+	calendarStore rfrl.CalendarStore,
+	sessionStore rfrl.SessionStore,
+	clientStore rfrl.ClientStore,
+) *CalendarUseCase {
+	return &CalendarUseCase{&db, calendarStore, sessionStore, clientStore}
+}
+
+func newCalendarSecret() (string, error) {
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "newCalendarSecret")
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+func (cu CalendarUseCase) GetCalendarFeed(clientID string) (*rfrl.CalendarFeed, error) {
+	feed, err := cu.CalendarStore.GetCalendarFeed(cu.DB, clientID)
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		return cu.RotateCalendarFeed(clientID)
+	}
+
+	return feed, err
+}
+
+// RotateCalendarFeed replaces the feed secret so previously shared urls stop working
+func (cu CalendarUseCase) RotateCalendarFeed(clientID string) (*rfrl.CalendarFeed, error) {
+	secret, err := newCalendarSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cu.CalendarStore.UpsertCalendarFeed(cu.DB, clientID, secret)
+}
+
+func (cu CalendarUseCase) GetCalendarFeedICS(secret string) (string, error) {
+	feed, err := cu.CalendarStore.GetCalendarFeedBySecret(cu.DB, secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	events, err := cu.ClientStore.GetRelatedEventsByClientIDs(
+		cu.DB,
+		[]string{feed.ClientID},
+		null.TimeFrom(now.Add(-calendarFeedHistory)),
+		null.NewTime(time.Time{}, false),
+		null.NewString("", false),
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	seen := make(map[int]bool)
+	eventIDs := make([]int, 0, len(*events))
+	uniqueEvents := make([]rfrl.Event, 0, len(*events))
+
+	for i := 0; i < len(*events); i++ {
+		event := (*events)[i]
+
+		if seen[event.ID] {
+			continue
+		}
+
+		seen[event.ID] = true
+		eventIDs = append(eventIDs, event.ID)
+		uniqueEvents = append(uniqueEvents, event)
+	}
+
+	sessions, err := cu.CalendarStore.GetSessionsByEventIDs(cu.DB, feed.ClientID, eventIDs)
+
+	if err != nil {
+		return "", err
+	}
+
+	eventToSession := make(map[int]*rfrl.Session)
+
+	for i := 0; i < len(*sessions); i++ {
+		session := &(*sessions)[i]
+		eventToSession[int(session.TargetedEventID.Int64)] = session
+	}
+
+	entries := make([]rfrl.CalendarEntry, len(uniqueEvents))
+
+	for i := 0; i < len(uniqueEvents); i++ {
+		entries[i] = rfrl.CalendarEntry{
+			Event:   uniqueEvents[i],
+			Session: eventToSession[uniqueEvents[i].ID],
+		}
+	}
+
+	return rfrl.BuildICalendar("rfrl sessions", entries, now), nil
+}
+
+func (cu CalendarUseCase) GetSessionICS(clientID string, sessionID int) (string, error) {
+	session, err := cu.SessionStore.GetSessionByID(cu.DB, clientID, sessionID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, forClient := getSessionClientIDs(*session, clientID); !forClient {
+		return "", errors.New("Session does not belong to client")
+	}
+
+	if !session.TargetedEventID.Valid {
+		return "", errors.New("Session does not have an event yet")
+	}
+
+	event, err := cu.SessionStore.GetSessionEventByID(cu.DB, sessionID, int(session.TargetedEventID.Int64))
+
+	if err != nil {
+		return "", err
+	}
+
+	return rfrl.BuildICalendar(
+		"rfrl session",
+		[]rfrl.CalendarEntry{{Event: *event, Session: session}},
+		time.Now(),
+	), nil
+}
@@ -6,18 +6,21 @@ import (
 
 	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
 	"github.com/jmoiron/sqlx"
+	"github.com/labstack/gommon/log"
 	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
 
 // ClientUseCase holds all business related functions for client
 type ClientUseCase struct {
-	db           *sqlx.DB
-	clientStore  rfrl.ClientStore
-	emailer      rfrl.EmailerUseCase
-	authStore    rfrl.AuthStore
-	fireStore    rfrl.FireStoreClient
-	companyStore rfrl.CompanyStore
+	db                    *sqlx.DB
+	clientStore           rfrl.ClientStore
+	emailer               rfrl.EmailerUseCase
+	authStore             rfrl.AuthStore
+	fireStore             rfrl.FireStoreClient
+	companyStore          rfrl.CompanyStore
+	notifier              rfrl.ReferralNotificationUseCase
+	tutorApplicationStore rfrl.TutorApplicationStore
 }
 
 // NewClientUseCase creates new ClientUseCase
@@ -28,6 +31,8 @@ func NewClientUseCase(
 	emailer rfrl.EmailerUseCase,
 	fireStore rfrl.FireStoreClient,
 	companyStore rfrl.CompanyStore,
+	notifier rfrl.ReferralNotificationUseCase,
+	tutorApplicationStore rfrl.TutorApplicationStore,
 ) *ClientUseCase {
 	return &ClientUseCase{
 		&db,
@@ -36,6 +41,8 @@ func NewClientUseCase(
 		authStore,
 		fireStore,
 		companyStore,
+		notifier,
+		tutorApplicationStore,
 	}
 }
 
@@ -57,7 +64,7 @@ func (cl *ClientUseCase) CreateClient(
 		isTutor,
 		"",
 		"",
-		null.Int{},
+		"",
 		"",
 	)
 	var err = new(error)
@@ -71,6 +78,8 @@ func (cl *ClientUseCase) CreateClient(
 
 	defer rfrl.HandleTransactions(tx, err)
 
+	tutorRequested := takeTutorRequest(client)
+
 	var createdClient *rfrl.Client
 	createdClient, *err = cl.clientStore.CreateClient(cl.db, client)
 
@@ -78,6 +87,14 @@ func (cl *ClientUseCase) CreateClient(
 		return nil, *err
 	}
 
+	if tutorRequested {
+		*err = applyToTutorIfNeeded(tx, cl.tutorApplicationStore, *createdClient)
+
+		if *err != nil {
+			return nil, *err
+		}
+	}
+
 	*err = cl.fireStore.CreateClient(
 		createdClient.ID,
 		createdClient.Photo.String,
@@ -113,10 +130,28 @@ func (cl *ClientUseCase) UpdateClient(
 		params.IsTutor,
 		params.LinkedInProfile,
 		params.GithubProfile,
-		params.YearsOfExperience,
 		params.WorkTitle,
+		params.Timezone,
 	)
 
+	if takeTutorRequest(client) {
+		var current *rfrl.Client
+		current, *err = cl.clientStore.GetClientFromID(tx, id)
+
+		if *err != nil {
+			return nil, *err
+		}
+
+		*err = applyToTutorIfNeeded(tx, cl.tutorApplicationStore, *current)
+
+		if *err != nil {
+			return nil, *err
+		}
+
+		// Tutors stay tutors and everyone else waits on their application
+		client.IsTutor = current.IsTutor
+	}
+
 	var updatedClient *rfrl.Client
 	updatedClient, *err = cl.clientStore.UpdateClient(cl.db, id, client)
 
@@ -138,8 +173,12 @@ func (cl *ClientUseCase) GetClient(id string) (*rfrl.Client, error) {
 	return cl.clientStore.GetClientFromID(cl.db, id)
 }
 
-func (cl *ClientUseCase) GetClients(options rfrl.GetClientsOptions) (*[]rfrl.Client, error) {
-	return cl.clientStore.GetClients(cl.db, options)
+func (cl *ClientUseCase) GetClients(options rfrl.GetClientsOptions, page rfrl.PageOptions) (*[]rfrl.Client, *rfrl.Cursor, error) {
+	return cl.clientStore.GetClients(cl.db, options, page)
+}
+
+func (cl *ClientUseCase) SearchClients(options rfrl.SearchClientsOptions, page rfrl.PageOptions) (*[]rfrl.ClientSearchResult, *rfrl.Cursor, error) {
+	return cl.clientStore.SearchClients(cl.db, options, page)
 }
 
 func (cl *ClientUseCase) CreateEmailVerification(clientID string, email string, emailType string) error {
@@ -206,14 +245,35 @@ func (cl *ClientUseCase) verifyWorkEmail(tx *sqlx.Tx, clientID string, email str
 	return updatedClient, err
 }
 
+// VerifyEmail verifies the email, seekers wanting the client's company are notified once a work email is verified
 func (cl *ClientUseCase) VerifyEmail(clientID string, email string, emailType string, passCode string) (*rfrl.Client, error) {
+	updatedClient, err := cl.verifyEmail(clientID, email, emailType, passCode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if emailType == rfrl.WorkEmail && updatedClient.CompanyID.Valid {
+		go func(clientID string, companyID int) {
+			err := cl.notifier.NotifyReferrerVerified(clientID, companyID)
+
+			if err != nil {
+				log.Errorj(log.JSON{"error": err.Error(), "clientID": clientID, "companyID": companyID})
+			}
+		}(updatedClient.ID, int(updatedClient.CompanyID.Int64))
+	}
+
+	return updatedClient, nil
+}
+
+func (cl *ClientUseCase) verifyEmail(clientID string, email string, emailType string, passCode string) (*rfrl.Client, error) {
 	var err = new(error)
 	var tx *sqlx.Tx
 
 	tx, *err = cl.db.Beginx()
 
 	if *err != nil {
-		return nil, errors.Wrap(*err, "VerifyEmail")
+		return nil, errors.Wrap(*err, "verifyEmail")
 	}
 
 	defer rfrl.HandleTransactions(tx, err)
@@ -246,22 +306,58 @@ func (cl *ClientUseCase) DeleteVerificationEmail(clientID string, emailType stri
 	return cl.clientStore.DeleteVerificationEmail(cl.db, clientID, emailType)
 }
 
-func (cl *ClientUseCase) GetClientEvents(clientID string, start null.Time, end null.Time, state null.String) (*[]rfrl.Event, error) {
-	return cl.clientStore.GetRelatedEventsByClientIDs(cl.db, []string{clientID}, start, end, state)
+func (cl *ClientUseCase) GetClientEvents(
+	clientID string,
+	start null.Time,
+	end null.Time,
+	state null.String,
+	page rfrl.PageOptions,
+) (*[]rfrl.Event, *rfrl.Cursor, error) {
+	return cl.clientStore.GetRelatedEventsPageByClientIDs(cl.db, []string{clientID}, start, end, state, page)
 }
 
-func (cl *ClientUseCase) CreateOrUpdateClientEducation(clientID string, institution string, degree string, fieldOfStudy string, startYear int, endYear int) error {
-	education := rfrl.NewEducation(institution, degree, fieldOfStudy, startYear, endYear)
-	err := cl.clientStore.CreateOrUpdateClientEducation(cl.db, clientID, education)
+func (cl *ClientUseCase) GetClientWantingCompanyReferrals(clientID string) ([]int, error) {
+	return cl.clientStore.GetClientWantingCompanyReferrals(cl.db, clientID)
+}
 
-	return err
+func (cl *ClientUseCase) GetClientWantingJobPostingReferrals(clientID string) ([]int, error) {
+	return cl.clientStore.GetClientWantingJobPostingReferrals(cl.db, clientID)
 }
 
-func (cl *ClientUseCase) GetClientWantingCompanyReferrals(clientID string) ([]int, error) {
-	return cl.clientStore.GetClientWantingCompanyReferrals(cl.db, clientID)
+func (cl *ClientUseCase) GetClientTags(clientID string) (*[]rfrl.ClientTag, error) {
+	return cl.clientStore.GetClientTags(cl.db, clientID)
 }
 
-func (cl *ClientUseCase) CreateClientWantingCompanyReferrals(clientID string, active bool, companyIDs []int) error {
+// SetClientTags replaces the client's tags and returns them with their names
+func (cl *ClientUseCase) SetClientTags(clientID string, tags []rfrl.ClientTag) (*[]rfrl.ClientTag, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = cl.db.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "SetClientTags")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = cl.clientStore.SetClientTags(tx, clientID, tags)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var clientTags *[]rfrl.ClientTag
+	clientTags, *err = cl.clientStore.GetClientTags(tx, clientID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	return clientTags, nil
+}
+
+func (cl *ClientUseCase) CreateClientWantingCompanyReferrals(clientID string, active bool, companyIDs []int, jobPostingIDs []int) error {
 	var err = new(error)
 	var tx *sqlx.Tx
 
@@ -287,5 +383,11 @@ func (cl *ClientUseCase) CreateClientWantingCompanyReferrals(clientID string, ac
 
 	*err = cl.clientStore.CreateClientWantingCompanyReferrals(tx, clientID, companyIDs)
 
+	if *err != nil {
+		return *err
+	}
+
+	*err = cl.clientStore.CreateClientWantingJobPostingReferrals(tx, clientID, jobPostingIDs)
+
 	return *err
 }
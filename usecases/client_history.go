@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"database/sql"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+func (cl *ClientUseCase) CreateEducation(education *rfrl.Education) (*rfrl.Education, error) {
+	return cl.clientStore.CreateEducation(cl.db, education)
+}
+
+func (cl *ClientUseCase) GetEducations(clientID string) (*[]rfrl.Education, error) {
+	return cl.clientStore.GetEducations(cl.db, clientID)
+}
+
+func (cl *ClientUseCase) UpdateEducation(education *rfrl.Education) (*rfrl.Education, error) {
+	return cl.clientStore.UpdateEducation(cl.db, education)
+}
+
+func (cl *ClientUseCase) DeleteEducation(clientID string, ID int) error {
+	return cl.clientStore.DeleteEducation(cl.db, clientID, ID)
+}
+
+// nameWorkPositionCompany uses the name of the company when the position is at a known company
+func (cl *ClientUseCase) nameWorkPositionCompany(db rfrl.DB, position *rfrl.WorkPosition) error {
+	if !position.CompanyID.Valid {
+		return nil
+	}
+
+	company, err := cl.companyStore.GetCompany(db, int(position.CompanyID.Int64))
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		return rfrl.ErrWorkPositionCompanyNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if company.Name.Valid {
+		position.CompanyName = company.Name.String
+	}
+
+	return nil
+}
+
+// updateYearsOfExperience recomputes the client's years of experience from all of their positions
+func (cl *ClientUseCase) updateYearsOfExperience(db rfrl.DB, clientID string) error {
+	positions, err := cl.clientStore.GetWorkPositions(db, clientID)
+
+	if err != nil {
+		return err
+	}
+
+	years := null.Int{}
+
+	if len(*positions) > 0 {
+		years = null.IntFrom(int64(rfrl.YearsOfExperience(*positions, time.Now())))
+	}
+
+	return cl.clientStore.UpdateYearsOfExperience(db, clientID, years)
+}
+
+func (cl *ClientUseCase) CreateWorkPosition(position *rfrl.WorkPosition) (*rfrl.WorkPosition, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = cl.db.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "CreateWorkPosition")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = cl.nameWorkPositionCompany(tx, position)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var created *rfrl.WorkPosition
+	created, *err = cl.clientStore.CreateWorkPosition(tx, position)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = cl.updateYearsOfExperience(tx, position.ClientID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	return created, nil
+}
+
+func (cl *ClientUseCase) GetWorkPositions(clientID string) (*[]rfrl.WorkPosition, error) {
+	return cl.clientStore.GetWorkPositions(cl.db, clientID)
+}
+
+func (cl *ClientUseCase) UpdateWorkPosition(position *rfrl.WorkPosition) (*rfrl.WorkPosition, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = cl.db.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "UpdateWorkPosition")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = cl.nameWorkPositionCompany(tx, position)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var updated *rfrl.WorkPosition
+	updated, *err = cl.clientStore.UpdateWorkPosition(tx, position)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = cl.updateYearsOfExperience(tx, position.ClientID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	return updated, nil
+}
+
+func (cl *ClientUseCase) DeleteWorkPosition(clientID string, ID int) error {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = cl.db.Beginx()
+
+	if *err != nil {
+		return errors.Wrap(*err, "DeleteWorkPosition")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = cl.clientStore.DeleteWorkPosition(tx, clientID, ID)
+
+	if *err != nil {
+		return *err
+	}
+
+	*err = cl.updateYearsOfExperience(tx, clientID)
+
+	return *err
+}
@@ -20,8 +20,8 @@ func (comu *CompanyUseCase) GetCompany(id int) (*rfrl.Company, error) {
 	return comu.CompanyStore.GetCompany(comu.db, id)
 }
 
-func (comu *CompanyUseCase) GetCompanyEmails(withCompany null.Bool) (*[]rfrl.CompanyEmailDomain, error) {
-	return comu.CompanyStore.GetCompanyEmails(comu.db, withCompany)
+func (comu *CompanyUseCase) GetCompanyEmails(withCompany null.Bool, page rfrl.PageOptions) (*[]rfrl.CompanyEmailDomain, *rfrl.Cursor, error) {
+	return comu.CompanyStore.GetCompanyEmails(comu.db, withCompany, page)
 }
 
 func (comu *CompanyUseCase) CreateCompany(
@@ -75,8 +75,8 @@ func (comu *CompanyUseCase) UpdateCompanyEmail(
 	return comu.CompanyStore.UpdateOrCreateCompanyEmail(comu.db, name, emailDomain, active)
 }
 
-func (comu *CompanyUseCase) GetCompanies(active bool) (*[]rfrl.Company, error) {
-	return comu.CompanyStore.GetCompanies(comu.db, active)
+func (comu *CompanyUseCase) GetCompanies(active bool, page rfrl.PageOptions) (*[]rfrl.Company, *rfrl.Cursor, error) {
+	return comu.CompanyStore.GetCompanies(comu.db, active, page)
 }
 
 func (comu *CompanyUseCase) GetCompanyEmail(companyEmail string) (*rfrl.CompanyEmailDomain, error) {
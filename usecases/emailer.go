@@ -3,6 +3,7 @@ package usecases
 import (
 	"bytes"
 	"fmt"
+	htmlTemplate "html/template"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -64,11 +65,57 @@ func (em *EmailerUseCase) SendEmailVerification(email string, emailType string)
 		return s, errors.Wrap(err, "SendEmailVerification")
 	}
 
+	if err := sendEmail(email, "Hello!", tpl.String()); err != nil {
+		return s, errors.Wrap(err, "SendEmailVerification")
+	}
+
+	return s, nil
+}
+
+// SendNotification emails a heading, a description and a list of lines, user content in lines is escaped
+func (em *EmailerUseCase) SendNotification(email string, subject string, heading string, description string, lines []string) error {
+	t := htmlTemplate.New("notification-email.html")
+
+	assetsPath := os.Getenv("ASSETS_FOLDER")
+	htmlPath := path.Join(assetsPath, "/notification-email.html")
+
+	htmlText, err := ioutil.ReadFile(htmlPath)
+	if err != nil {
+		return errors.Wrap(err, "SendNotification")
+	}
+
+	t, err = t.Parse(string(htmlText))
+
+	if err != nil {
+		return errors.Wrap(err, "SendNotification")
+	}
+
+	var tpl bytes.Buffer
+
+	err = t.Execute(
+		&tpl, struct {
+			Heading     string
+			Description string
+			Lines       []string
+		}{
+			Heading:     heading,
+			Description: description,
+			Lines:       lines,
+		})
+
+	if err != nil {
+		return errors.Wrap(err, "SendNotification")
+	}
+
+	return errors.Wrap(sendEmail(email, subject, tpl.String()), "SendNotification")
+}
+
+func sendEmail(email string, subject string, body string) error {
 	m := gomail.NewMessage()
 	m.SetHeader("From", "admin@rfrl.ca")
 	m.SetHeader("To", email)
-	m.SetHeader("Subject", "Hello!")
-	m.SetBody("text/html", tpl.String())
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", body)
 
 	d := gomail.NewDialer(
 		"smtp.gmail.com",
@@ -77,9 +124,5 @@ func (em *EmailerUseCase) SendEmailVerification(email string, emailType string)
 		"yjdzrlukmabxpmwm",
 	)
 
-	if err := d.DialAndSend(m); err != nil {
-		return s, errors.Wrap(err, "SendEmailVerification")
-	}
-
-	return s, nil
+	return d.DialAndSend(m)
 }
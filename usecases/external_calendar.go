@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/gommon/log"
+	"github.com/pkg/errors"
+)
+
+// externalCalendarFetchTimeout bounds how long fetching a linked calendar can take
+const externalCalendarFetchTimeout = 15 * time.Second
+
+// externalCalendarMaxRedirects caps how many redirects are followed when fetching a linked calendar
+const externalCalendarMaxRedirects = 5
+
+// blockedCalendarNetworks are private, shared and reserved networks that linked calendars cannot point to
+var blockedCalendarNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+
+		if err != nil {
+			panic(err)
+		}
+
+		networks[i] = network
+	}
+
+	return networks
+}
+
+// isPublicIP checks the ip is not loopback, private, link-local, unspecified or otherwise reserved
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	for _, network := range blockedCalendarNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dialPublicOnly refuses connections to non public addresses. It runs on the resolved address of
+// every connection, so redirects and hosts resolving to another address later are checked too
+func dialPublicOnly(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil || !isPublicIP(ip) {
+		return errors.Errorf("Calendar url resolves to a non public address (%s)", host)
+	}
+
+	return nil
+}
+
+// newExternalCalendarHTTPClient creates a client that only reaches public http and https hosts
+func newExternalCalendarHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: externalCalendarFetchTimeout,
+		Control: dialPublicOnly,
+	}
+
+	return &http.Client{
+		Timeout: externalCalendarFetchTimeout,
+		Transport: &http.Transport{
+			// No proxy, the dialer has to see the calendar's own address
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: externalCalendarFetchTimeout,
+		},
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= externalCalendarMaxRedirects {
+				return errors.New("Calendar url redirected too many times")
+			}
+
+			if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+				return errors.Errorf("Calendar url redirected to an unsupported scheme (%s)", request.URL.Scheme)
+			}
+
+			return nil
+		},
+	}
+}
+
+// ExternalCalendarUseCase holds all business related functions for imported calendars
+type ExternalCalendarUseCase struct {
+	DB                    *sqlx.DB
+	ExternalCalendarStore rfrl.ExternalCalendarStore
+	HTTPClient            *http.Client
+}
+
+func NewExternalCalendarUseCase(
+	db sqlx.DB,
+	externalCalendarStore rfrl.ExternalCalendarStore,
+) *ExternalCalendarUseCase {
+	return &ExternalCalendarUseCase{
+		&db,
+		externalCalendarStore,
+		newExternalCalendarHTTPClient(),
+	}
+}
+
+// fetchExternalCalendar downloads a linked calendar, treating webcal:// as https://
+func (ecu ExternalCalendarUseCase) fetchExternalCalendar(rawURL string) ([]rfrl.ExternalBusyEvent, error) {
+	calendarURL, err := url.Parse(rawURL)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "fetchExternalCalendar")
+	}
+
+	switch strings.ToLower(calendarURL.Scheme) {
+	case "webcal":
+		calendarURL.Scheme = "https"
+	case "http", "https":
+	default:
+		return nil, errors.Errorf("Calendar url scheme (%s) is not supported", calendarURL.Scheme)
+	}
+
+	response, err := ecu.HTTPClient.Get(calendarURL.String())
+
+	if err != nil {
+		log.Errorj(log.JSON{"error": err.Error(), "url": calendarURL.String()})
+		return nil, rfrl.ErrExternalCalendarFetch
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		log.Errorj(log.JSON{"status": response.StatusCode, "url": calendarURL.String()})
+		return nil, rfrl.ErrExternalCalendarFetch
+	}
+
+	return parseExternalCalendar(response.Body)
+}
+
+func parseExternalCalendar(reader io.Reader) ([]rfrl.ExternalBusyEvent, error) {
+	now := time.Now()
+
+	return rfrl.ParseExternalBusyEvents(reader, now.AddDate(0, 0, -1), now.Add(rfrl.ExternalBusyHorizon))
+}
+
+func (ecu ExternalCalendarUseCase) storeExternalBusyEvents(
+	calendar rfrl.ExternalCalendar,
+	events []rfrl.ExternalBusyEvent,
+	create bool,
+) (*rfrl.ExternalCalendar, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ecu.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "storeExternalBusyEvents")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	stored := &calendar
+
+	if create {
+		stored, *err = ecu.ExternalCalendarStore.CreateExternalCalendar(tx, &calendar)
+
+		if *err != nil {
+			return nil, *err
+		}
+	}
+
+	*err = ecu.ExternalCalendarStore.ReplaceExternalBusyEvents(tx, *stored, events)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	stored, *err = ecu.ExternalCalendarStore.MarkExternalCalendarSynced(tx, stored.ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	stored.BusyCount = len(events)
+
+	return stored, nil
+}
+
+// ImportExternalCalendar stores the busy times of an uploaded file, or of a url that can be synced again later
+func (ecu ExternalCalendarUseCase) ImportExternalCalendar(
+	clientID string,
+	name string,
+	calendarURL string,
+	file io.Reader,
+) (*rfrl.ExternalCalendar, error) {
+	var events []rfrl.ExternalBusyEvent
+	var err error
+
+	if file != nil {
+		calendarURL = ""
+		events, err = parseExternalCalendar(file)
+	} else {
+		events, err = ecu.fetchExternalCalendar(calendarURL)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ecu.storeExternalBusyEvents(*rfrl.NewExternalCalendar(clientID, name, calendarURL), events, true)
+}
+
+// SyncExternalCalendar fetches a linked calendar again and replaces its busy times
+func (ecu ExternalCalendarUseCase) SyncExternalCalendar(clientID string, ID int) (*rfrl.ExternalCalendar, error) {
+	calendar, err := ecu.ExternalCalendarStore.GetExternalCalendar(ecu.DB, clientID, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !calendar.URL.Valid {
+		return nil, errors.New("Uploaded calendars have to be uploaded again to be updated")
+	}
+
+	events, err := ecu.fetchExternalCalendar(calendar.URL.String)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ecu.storeExternalBusyEvents(*calendar, events, false)
+}
+
+func (ecu ExternalCalendarUseCase) GetExternalCalendars(clientID string) (*[]rfrl.ExternalCalendar, error) {
+	return ecu.ExternalCalendarStore.GetExternalCalendars(ecu.DB, clientID)
+}
+
+func (ecu ExternalCalendarUseCase) DeleteExternalCalendar(clientID string, ID int) error {
+	return ecu.ExternalCalendarStore.DeleteExternalCalendar(ecu.DB, clientID, ID)
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// JobPostingUseCase holds all business related functions for job postings
+type JobPostingUseCase struct {
+	DB              *sqlx.DB
+	JobPostingStore rfrl.JobPostingStore
+	ClientStore     rfrl.ClientStore
+}
+
+func NewJobPostingUseCase(
+	db sqlx.DB,
+	jobPostingStore rfrl.JobPostingStore,
+	clientStore rfrl.ClientStore,
+) *JobPostingUseCase {
+	return &JobPostingUseCase{&db, jobPostingStore, clientStore}
+}
+
+func (jpu JobPostingUseCase) canPost(db rfrl.DB, clientID string, admin bool, posting rfrl.JobPosting) error {
+	if admin {
+		return nil
+	}
+
+	client, err := jpu.ClientStore.GetClientFromID(db, clientID)
+
+	if err != nil {
+		return err
+	}
+
+	return posting.CanPost(*client, admin)
+}
+
+// CreateJobPosting posts an opening at a company the client works at
+func (jpu JobPostingUseCase) CreateJobPosting(clientID string, admin bool, posting *rfrl.JobPosting) (*rfrl.JobPosting, error) {
+	err := jpu.canPost(jpu.DB, clientID, admin, *posting)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return jpu.JobPostingStore.CreateJobPosting(jpu.DB, posting)
+}
+
+func (jpu JobPostingUseCase) GetJobPosting(ID int) (*rfrl.JobPosting, error) {
+	return jpu.JobPostingStore.GetJobPosting(jpu.DB, ID)
+}
+
+func (jpu JobPostingUseCase) GetJobPostings(options rfrl.GetJobPostingsOptions, page rfrl.PageOptions) (*[]rfrl.JobPosting, *rfrl.Cursor, error) {
+	return jpu.JobPostingStore.GetJobPostings(jpu.DB, options, page)
+}
+
+// UpdateJobPosting edits a posting, the company it belongs to cannot change
+func (jpu JobPostingUseCase) UpdateJobPosting(clientID string, admin bool, posting *rfrl.JobPosting) (*rfrl.JobPosting, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = jpu.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "UpdateJobPosting")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var current *rfrl.JobPosting
+	current, *err = jpu.JobPostingStore.GetJobPosting(tx, posting.ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = current.CanManage(clientID, admin)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var updated *rfrl.JobPosting
+	updated, *err = jpu.JobPostingStore.UpdateJobPosting(tx, posting)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	return updated, nil
+}
+
+func (jpu JobPostingUseCase) DeleteJobPosting(clientID string, admin bool, ID int) error {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = jpu.DB.Beginx()
+
+	if *err != nil {
+		return errors.Wrap(*err, "DeleteJobPosting")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var current *rfrl.JobPosting
+	current, *err = jpu.JobPostingStore.GetJobPosting(tx, ID)
+
+	if *err != nil {
+		return *err
+	}
+
+	*err = current.CanManage(clientID, admin)
+
+	if *err != nil {
+		return *err
+	}
+
+	*err = jpu.JobPostingStore.DeleteJobPosting(tx, ID)
+
+	return *err
+}
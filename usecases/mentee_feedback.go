@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"database/sql"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+)
+
+// MenteeFeedbackUseCase holds all business related functions for feedback tutors give mentees
+type MenteeFeedbackUseCase struct {
+	DB                  *sqlx.DB
+	MenteeFeedbackStore rfrl.MenteeFeedbackStore
+}
+
+func NewMenteeFeedbackUseCase(db sqlx.DB, menteeFeedbackStore rfrl.MenteeFeedbackStore) *MenteeFeedbackUseCase {
+	return &MenteeFeedbackUseCase{&db, menteeFeedbackStore}
+}
+
+// CreateMenteeFeedback lets the tutor of a completed session give feedback once to each of its mentees
+func (mfu MenteeFeedbackUseCase) CreateMenteeFeedback(feedback *rfrl.MenteeFeedback) (*rfrl.MenteeFeedback, error) {
+	attended, err := mfu.MenteeFeedbackStore.CheckMenteeAttendedSession(
+		mfu.DB,
+		feedback.SessionID,
+		feedback.TutorID,
+		feedback.MenteeID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !attended {
+		return nil, rfrl.ErrMenteeFeedbackNotAllowed
+	}
+
+	exists, err := mfu.MenteeFeedbackStore.CheckMenteeFeedbackExists(mfu.DB, feedback.SessionID, feedback.MenteeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, rfrl.ErrMenteeFeedbackExists
+	}
+
+	return mfu.MenteeFeedbackStore.CreateMenteeFeedback(mfu.DB, feedback)
+}
+
+// getOwnMenteeFeedback gets feedback written by the client
+func (mfu MenteeFeedbackUseCase) getOwnMenteeFeedback(clientID string, ID int) (*rfrl.MenteeFeedback, error) {
+	feedback, err := mfu.MenteeFeedbackStore.GetMenteeFeedback(mfu.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if feedback.TutorID != clientID {
+		return nil, rfrl.ErrMenteeFeedbackNotAllowed
+	}
+
+	return feedback, nil
+}
+
+func (mfu MenteeFeedbackUseCase) UpdateMenteeFeedback(clientID string, feedback *rfrl.MenteeFeedback) (*rfrl.MenteeFeedback, error) {
+	_, err := mfu.getOwnMenteeFeedback(clientID, feedback.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mfu.MenteeFeedbackStore.UpdateMenteeFeedback(mfu.DB, feedback)
+}
+
+func (mfu MenteeFeedbackUseCase) DeleteMenteeFeedback(clientID string, ID int) error {
+	_, err := mfu.getOwnMenteeFeedback(clientID, ID)
+
+	if err != nil {
+		return err
+	}
+
+	return mfu.MenteeFeedbackStore.DeleteMenteeFeedback(mfu.DB, ID)
+}
+
+// GetMenteeFeedback gets feedback for its tutor, mentees only get it once it is shared with them
+func (mfu MenteeFeedbackUseCase) GetMenteeFeedback(clientID string, ID int) (*rfrl.MenteeFeedback, error) {
+	feedback, err := mfu.MenteeFeedbackStore.GetMenteeFeedback(mfu.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !feedback.CanBeSeenBy(clientID) {
+		// Private feedback is not found for mentees so they do not learn it exists
+		return nil, sql.ErrNoRows
+	}
+
+	return feedback, nil
+}
+
+func (mfu MenteeFeedbackUseCase) GetMenteeFeedbacks(
+	options rfrl.GetMenteeFeedbacksOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.MenteeFeedback, *rfrl.Cursor, error) {
+	return mfu.MenteeFeedbackStore.GetMenteeFeedbacks(mfu.DB, options, page)
+}
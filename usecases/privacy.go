@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+)
+
+// PrivacyUseCase holds all business related functions for privacy settings
+type PrivacyUseCase struct {
+	DB           *sqlx.DB
+	PrivacyStore rfrl.PrivacyStore
+}
+
+func NewPrivacyUseCase(db sqlx.DB, privacyStore rfrl.PrivacyStore) *PrivacyUseCase {
+	return &PrivacyUseCase{&db, privacyStore}
+}
+
+func (pu PrivacyUseCase) GetPrivacySetting(clientID string) (*rfrl.PrivacySetting, error) {
+	return pu.PrivacyStore.GetPrivacySetting(pu.DB, clientID)
+}
+
+// UpdatePrivacySetting only changes the settings that are given
+func (pu PrivacyUseCase) UpdatePrivacySetting(
+	clientID string,
+	hideEmail null.Bool,
+	hideWorkEmail null.Bool,
+	hideLinkedInProfile null.Bool,
+	hiddenFromSearch null.Bool,
+) (*rfrl.PrivacySetting, error) {
+	setting, err := pu.PrivacyStore.GetPrivacySetting(pu.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hideEmail.Valid {
+		setting.HideEmail = hideEmail.Bool
+	}
+
+	if hideWorkEmail.Valid {
+		setting.HideWorkEmail = hideWorkEmail.Bool
+	}
+
+	if hideLinkedInProfile.Valid {
+		setting.HideLinkedInProfile = hideLinkedInProfile.Bool
+	}
+
+	if hiddenFromSearch.Valid {
+		setting.HiddenFromSearch = hiddenFromSearch.Bool
+	}
+
+	return pu.PrivacyStore.UpsertPrivacySetting(pu.DB, setting)
+}
+
+// HideClientFields clears the fields of every client the viewer is not allowed to see.
+// It is called on clients right before they are sent in a response
+func (pu PrivacyUseCase) HideClientFields(viewer rfrl.Viewer, clients ...*rfrl.Client) error {
+	if viewer.Admin {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	clientIDs := make([]string, 0)
+
+	for _, client := range clients {
+		if client.ID == viewer.ClientID || seen[client.ID] {
+			continue
+		}
+		seen[client.ID] = true
+		clientIDs = append(clientIDs, client.ID)
+	}
+
+	if len(clientIDs) == 0 {
+		return nil
+	}
+
+	settings, err := pu.PrivacyStore.GetPrivacySettings(pu.DB, clientIDs)
+
+	if err != nil {
+		return err
+	}
+
+	participants, err := pu.PrivacyStore.GetSessionParticipants(pu.DB, viewer.ClientID, clientIDs)
+
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+		*client = client.VisibleTo(viewer.RelationshipTo(client.ID, participants), settings[client.ID])
+	}
+
+	return nil
+}
@@ -11,14 +11,18 @@ type QuestionUseCase struct {
 	DB            *sqlx.DB
 	ClientStore   rfrl.ClientStore
 	QuestionStore rfrl.QuestionStore
+	SessionStore  rfrl.SessionStore
+	Emailer       rfrl.EmailerUseCase
 }
 
 func NewQuestionUsesCase(
 	db *sqlx.DB,
 	clientStore rfrl.ClientStore,
 	questionStore rfrl.QuestionStore,
+	sessionStore rfrl.SessionStore,
+	emailer rfrl.EmailerUseCase,
 ) *QuestionUseCase {
-	return &QuestionUseCase{db, clientStore, questionStore}
+	return &QuestionUseCase{db, clientStore, questionStore, sessionStore, emailer}
 }
 
 func (qu *QuestionUseCase) CreateQuestion(clientID string, title string, body string, tags []int) (*rfrl.Question, error) {
@@ -104,15 +108,15 @@ func (qu *QuestionUseCase) GetQuestion(id int) (*rfrl.Question, error) {
 	return question, err
 }
 
-func (qu *QuestionUseCase) GetQuestions(lastQuestion null.Int, resolved null.Bool) (*[]rfrl.Question, error) {
-	questions, err := qu.QuestionStore.GetQuestions(qu.DB, lastQuestion, resolved)
+func (qu *QuestionUseCase) GetQuestions(resolved null.Bool, page rfrl.PageOptions) (*[]rfrl.Question, *rfrl.Cursor, error) {
+	questions, next, err := qu.QuestionStore.GetQuestions(qu.DB, resolved, page)
 
-	if len(*questions) == 0 {
-		return questions, nil
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if err != nil {
-		return nil, err
+	if len(*questions) == 0 {
+		return questions, nil, nil
 	}
 
 	var fromIDs []string
@@ -124,7 +128,7 @@ func (qu *QuestionUseCase) GetQuestions(lastQuestion null.Int, resolved null.Boo
 	clients, err := qu.ClientStore.GetClientFromIDs(qu.DB, fromIDs)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	IDtoClient := make(map[string]*rfrl.Client)
@@ -138,28 +142,27 @@ func (qu *QuestionUseCase) GetQuestions(lastQuestion null.Int, resolved null.Boo
 		(*questions)[i].From = *IDtoClient[(*questions)[i].FromID]
 	}
 
-	return questions, nil
+	return questions, next, nil
 }
 
-func (qu *QuestionUseCase) GetQuestionsForClient(clientID string, resolved null.Bool) (*[]rfrl.Question, error) {
-	questions, err := qu.QuestionStore.GetQuestionsForClient(qu.DB, clientID, resolved)
+func (qu *QuestionUseCase) GetQuestionsForClient(clientID string, resolved null.Bool, page rfrl.PageOptions) (*[]rfrl.Question, *rfrl.Cursor, error) {
+	questions, next, err := qu.QuestionStore.GetQuestionsForClient(qu.DB, clientID, resolved, page)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := qu.ClientStore.GetClientFromID(qu.DB, clientID)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for i := 0; i < len(*questions); i++ {
-		question := (*questions)[i]
-		question.From = *client
+		(*questions)[i].From = *client
 	}
 
-	return questions, nil
+	return questions, next, nil
 }
 
 func (qu *QuestionUseCase) ApplyToQuestion(clientID string, id int) error {
@@ -178,3 +181,14 @@ func (qu *QuestionUseCase) ApplyToQuestion(clientID string, id int) error {
 
 	return *err
 }
+
+// GetSuggestedTutors finds tutors whose tags overlap with the question's tags
+func (qu *QuestionUseCase) GetSuggestedTutors(id int) (*[]rfrl.SuggestedTutor, error) {
+	_, err := qu.QuestionStore.GetQuestion(qu.DB, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return qu.QuestionStore.GetSuggestedTutors(qu.DB, id, rfrl.SuggestedTutorsLimit)
+}
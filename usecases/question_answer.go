@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// QuestionAnswerUseCase holds all business related functions for answers and comments on questions
+type QuestionAnswerUseCase struct {
+	DB                  *sqlx.DB
+	ClientStore         rfrl.ClientStore
+	QuestionStore       rfrl.QuestionStore
+	QuestionAnswerStore rfrl.QuestionAnswerStore
+}
+
+func NewQuestionAnswerUseCase(
+	db sqlx.DB,
+	clientStore rfrl.ClientStore,
+	questionStore rfrl.QuestionStore,
+	questionAnswerStore rfrl.QuestionAnswerStore,
+) *QuestionAnswerUseCase {
+	return &QuestionAnswerUseCase{&db, clientStore, questionStore, questionAnswerStore}
+}
+
+// addAuthors sets who wrote each answer and every comment replying to it
+func (qau QuestionAnswerUseCase) addAuthors(answers []rfrl.QuestionAnswer) error {
+	var all []*rfrl.QuestionAnswer
+	var collect func(answers []rfrl.QuestionAnswer)
+	collect = func(answers []rfrl.QuestionAnswer) {
+		for i := range answers {
+			all = append(all, &answers[i])
+			collect(answers[i].Replies)
+		}
+	}
+	collect(answers)
+
+	if len(all) == 0 {
+		return nil
+	}
+
+	fromIDs := make([]string, len(all))
+
+	for i, answer := range all {
+		fromIDs[i] = answer.FromID
+	}
+
+	clients, err := qau.ClientStore.GetClientFromIDs(qau.DB, fromIDs)
+
+	if err != nil {
+		return err
+	}
+
+	IDtoClient := make(map[string]rfrl.Client)
+
+	for _, client := range *clients {
+		IDtoClient[client.ID] = client
+	}
+
+	for _, answer := range all {
+		answer.From = IDtoClient[answer.FromID]
+	}
+
+	return nil
+}
+
+// CreateQuestionAnswer answers the question, or comments on one of its answers or comments when
+// parentID is given
+func (qau QuestionAnswerUseCase) CreateQuestionAnswer(clientID string, questionID int, parentID null.Int, body string) (*rfrl.QuestionAnswer, error) {
+	var parent *rfrl.QuestionAnswer
+	var err error
+
+	if parentID.Valid {
+		parent, err = qau.QuestionAnswerStore.GetQuestionAnswer(qau.DB, int(parentID.Int64))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.QuestionID != questionID {
+			return nil, rfrl.ErrQuestionAnswerParent
+		}
+	} else {
+		_, err = qau.QuestionStore.GetQuestion(qau.DB, questionID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	answer, err := qau.QuestionAnswerStore.CreateQuestionAnswer(
+		qau.DB,
+		rfrl.NewQuestionAnswer(questionID, clientID, body, parent),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	answers := rfrl.NewQuestionAnswerThreads([]rfrl.QuestionAnswer{*answer}, nil)
+	err = qau.addAuthors(answers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &answers[0], nil
+}
+
+// getOwnQuestionAnswer gets an answer or comment written by the client
+func (qau QuestionAnswerUseCase) getOwnQuestionAnswer(clientID string, id int) (*rfrl.QuestionAnswer, error) {
+	answer, err := qau.QuestionAnswerStore.GetQuestionAnswer(qau.DB, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if answer.FromID != clientID {
+		return nil, rfrl.ErrQuestionAnswerNotForClient
+	}
+
+	return answer, nil
+}
+
+func (qau QuestionAnswerUseCase) UpdateQuestionAnswer(clientID string, id int, body string) (*rfrl.QuestionAnswer, error) {
+	_, err := qau.getOwnQuestionAnswer(clientID, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := qau.QuestionAnswerStore.UpdateQuestionAnswer(qau.DB, id, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	answers := rfrl.NewQuestionAnswerThreads([]rfrl.QuestionAnswer{*answer}, nil)
+	err = qau.addAuthors(answers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &answers[0], nil
+}
+
+func (qau QuestionAnswerUseCase) DeleteQuestionAnswer(clientID string, id int) error {
+	_, err := qau.getOwnQuestionAnswer(clientID, id)
+
+	if err != nil {
+		return err
+	}
+
+	return qau.QuestionAnswerStore.DeleteQuestionAnswer(qau.DB, id)
+}
+
+// AcceptQuestionAnswer lets the author of the question accept an answer, which resolves the question.
+// Unaccepting the answer leaves the question as it is
+func (qau QuestionAnswerUseCase) AcceptQuestionAnswer(clientID string, id int, accepted bool) (*rfrl.QuestionAnswer, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = qau.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "AcceptQuestionAnswer")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var answer *rfrl.QuestionAnswer
+	answer, *err = qau.QuestionAnswerStore.GetQuestionAnswer(tx, id)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if answer.IsComment() {
+		*err = rfrl.ErrCommentNotAcceptable
+		return nil, *err
+	}
+
+	var question *rfrl.Question
+	question, *err = qau.QuestionStore.GetQuestion(tx, answer.QuestionID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if question.FromID != clientID {
+		*err = rfrl.ErrNotQuestionAuthor
+		return nil, *err
+	}
+
+	answer, *err = qau.QuestionAnswerStore.SetQuestionAnswerAccepted(tx, answer.QuestionID, id, accepted)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if accepted && !question.Resolved {
+		_, *err = qau.QuestionStore.UpdateQuestion(tx, clientID, question.ID, "", "", nil, null.BoolFrom(true))
+
+		if *err != nil {
+			return nil, *err
+		}
+	}
+
+	answers := rfrl.NewQuestionAnswerThreads([]rfrl.QuestionAnswer{*answer}, nil)
+	*err = qau.addAuthors(answers)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	return &answers[0], nil
+}
+
+// GetQuestionAnswers pages through the answers of the question with the comments threaded under them
+func (qau QuestionAnswerUseCase) GetQuestionAnswers(questionID int, page rfrl.PageOptions) (*[]rfrl.QuestionAnswer, *rfrl.Cursor, error) {
+	_, err := qau.QuestionStore.GetQuestion(qau.DB, questionID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answers, next, err := qau.QuestionAnswerStore.GetQuestionAnswers(qau.DB, questionID, page)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answerIDs := make([]int, len(*answers))
+
+	for i, answer := range *answers {
+		answerIDs[i] = answer.ID
+	}
+
+	comments, err := qau.QuestionAnswerStore.GetQuestionAnswerComments(qau.DB, answerIDs)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	threads := rfrl.NewQuestionAnswerThreads(*answers, *comments)
+	err = qau.addAuthors(threads)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &threads, next, nil
+}
@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/gommon/log"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// getOwnQuestion gets a question asked by the client
+func (qu *QuestionUseCase) getOwnQuestion(db rfrl.DB, clientID string, id int) (*rfrl.Question, error) {
+	question, err := qu.QuestionStore.GetQuestion(db, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if question.FromID != clientID {
+		return nil, rfrl.ErrNotQuestionAuthor
+	}
+
+	return question, nil
+}
+
+// addApplicantProfiles sets the profile of each applicant
+func (qu *QuestionUseCase) addApplicantProfiles(applicants []rfrl.QuestionApplicant) error {
+	if len(applicants) == 0 {
+		return nil
+	}
+
+	applicantIDs := make([]string, len(applicants))
+
+	for i, applicant := range applicants {
+		applicantIDs[i] = applicant.ApplicantID
+	}
+
+	clients, err := qu.ClientStore.GetClientFromIDs(qu.DB, applicantIDs)
+
+	if err != nil {
+		return err
+	}
+
+	IDtoClient := make(map[string]rfrl.Client)
+
+	for _, client := range *clients {
+		IDtoClient[client.ID] = client
+	}
+
+	for i := range applicants {
+		applicants[i].Applicant = IDtoClient[applicants[i].ApplicantID]
+	}
+
+	return nil
+}
+
+// GetQuestionApplicants lists who applied to the client's question with their profile and rating
+func (qu *QuestionUseCase) GetQuestionApplicants(
+	clientID string,
+	id int,
+	state null.String,
+	page rfrl.PageOptions,
+) (*[]rfrl.QuestionApplicant, *rfrl.Cursor, error) {
+	_, err := qu.getOwnQuestion(qu.DB, clientID, id)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicants, next, err := qu.QuestionStore.GetQuestionApplicants(qu.DB, id, state, page)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = qu.addApplicantProfiles(*applicants)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return applicants, next, nil
+}
+
+// moveQuestionApplicant moves the applicant of the client's question to the state, applicants are
+// accepted through AcceptQuestionApplicant so that their session is created
+func (qu *QuestionUseCase) moveQuestionApplicant(
+	db rfrl.DB,
+	clientID string,
+	id int,
+	applicantID string,
+	state string,
+) (*rfrl.QuestionApplicant, error) {
+	_, err := qu.getOwnQuestion(db, clientID, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	applicant, err := qu.QuestionStore.GetQuestionApplicant(db, id, applicantID)
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, rfrl.ErrNotAnApplicant
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !applicant.CanMoveTo(state) {
+		return nil, errors.Wrapf(
+			rfrl.ErrInvalidApplicantTransition,
+			"Applicant cannot move from %s to %s",
+			applicant.State,
+			state,
+		)
+	}
+
+	// Only moves the applicant if nobody else moved them in the meantime
+	applicant, err = qu.QuestionStore.UpdateQuestionApplicantState(db, id, applicantID, []string{applicant.State}, state, null.Int{})
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, rfrl.ErrInvalidApplicantTransition
+	}
+
+	return applicant, err
+}
+
+// UpdateQuestionApplicant shortlists or declines an applicant of the client's question and lets them know
+func (qu *QuestionUseCase) UpdateQuestionApplicant(clientID string, id int, applicantID string, state string) (*rfrl.QuestionApplicant, error) {
+	if state == rfrl.APPLICANT_ACCEPTED {
+		return nil, errors.Wrap(rfrl.ErrInvalidApplicantTransition, "Applicants are accepted by creating a session with them")
+	}
+
+	applicant, err := qu.moveQuestionApplicant(qu.DB, clientID, id, applicantID, state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = qu.addApplicantProfiles([]rfrl.QuestionApplicant{*applicant})
+
+	if err != nil {
+		return nil, err
+	}
+
+	go qu.notifyQuestionApplicants(id, []string{applicantID}, state)
+
+	return applicant, nil
+}
+
+// AcceptQuestionApplicant creates a pending session between the applicant, as its tutor, and the author of
+// the question. Every other applicant still waiting is declined and each of them is notified
+func (qu *QuestionUseCase) AcceptQuestionApplicant(
+	clientID string,
+	id int,
+	applicantID string,
+	roomID string,
+) (*rfrl.QuestionApplicant, *rfrl.Session, error) {
+	applicant, session, declinedIDs, err := qu.acceptQuestionApplicant(clientID, id, applicantID, roomID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = qu.addApplicantProfiles([]rfrl.QuestionApplicant{*applicant})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go qu.notifyQuestionApplicants(id, []string{applicantID}, rfrl.APPLICANT_ACCEPTED)
+	go qu.notifyQuestionApplicants(id, declinedIDs, rfrl.APPLICANT_DECLINED)
+
+	return applicant, session, nil
+}
+
+// acceptQuestionApplicant creates the session and accepts the applicant in one transaction while holding
+// the lock on the question, so concurrent accepts wait for each other and only the first one creates a session
+func (qu *QuestionUseCase) acceptQuestionApplicant(
+	clientID string,
+	id int,
+	applicantID string,
+	roomID string,
+) (*rfrl.QuestionApplicant, *rfrl.Session, []string, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = qu.DB.Beginx()
+
+	if *err != nil {
+		return nil, nil, nil, errors.Wrap(*err, "acceptQuestionApplicant")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var question *rfrl.Question
+	question, *err = qu.QuestionStore.GetQuestionForUpdate(tx, id)
+
+	if *err != nil {
+		return nil, nil, nil, *err
+	}
+
+	if question.FromID != clientID {
+		*err = rfrl.ErrNotQuestionAuthor
+		return nil, nil, nil, *err
+	}
+
+	var applicant *rfrl.QuestionApplicant
+	applicant, *err = qu.QuestionStore.GetQuestionApplicant(tx, id, applicantID)
+
+	if errors.Cause(*err) == sql.ErrNoRows {
+		*err = rfrl.ErrNotAnApplicant
+	}
+
+	if *err != nil {
+		return nil, nil, nil, *err
+	}
+
+	if !applicant.CanMoveTo(rfrl.APPLICANT_ACCEPTED) {
+		*err = errors.Wrapf(
+			rfrl.ErrInvalidApplicantTransition,
+			"Applicant cannot move from %s to %s",
+			applicant.State,
+			rfrl.APPLICANT_ACCEPTED,
+		)
+		return nil, nil, nil, *err
+	}
+
+	session := rfrl.NewSession(applicantID, clientID, roomID, rfrl.PENDING)
+	session.QuestionID = null.IntFrom(int64(id))
+
+	session, *err = createSession(tx, qu.SessionStore, qu.ClientStore, session, []string{applicantID, clientID})
+
+	if *err != nil {
+		return nil, nil, nil, *err
+	}
+
+	applicant, *err = qu.QuestionStore.UpdateQuestionApplicantState(
+		tx,
+		id,
+		applicantID,
+		[]string{applicant.State},
+		rfrl.APPLICANT_ACCEPTED,
+		null.IntFrom(int64(session.ID)),
+	)
+
+	var declinedIDs []string
+
+	if *err == nil {
+		declinedIDs, *err = qu.QuestionStore.DeclineOtherQuestionApplicants(tx, id, applicantID)
+	}
+
+	if *err != nil {
+		return nil, nil, nil, *err
+	}
+
+	return applicant, session, declinedIDs, nil
+}
+
+var questionApplicantEmails = map[string]struct {
+	subject     string
+	description string
+}{
+	rfrl.APPLICANT_SHORTLISTED: {
+		"You were shortlisted for a question on rfrl",
+		"The author of the question shortlisted you among its applicants. They may accept you for a session soon.",
+	},
+	rfrl.APPLICANT_DECLINED: {
+		"Update on a question you applied to on rfrl",
+		"The author of the question decided not to go ahead with your application this time.",
+	},
+	rfrl.APPLICANT_ACCEPTED: {
+		"Your application to a question was accepted on rfrl",
+		"The author of the question accepted you and created a session with you. Pick a time for it on rfrl.",
+	},
+}
+
+// notifyQuestionApplicants emails the applicants what the author of the question decided about them.
+// It runs after the change is saved so failures are only logged
+func (qu *QuestionUseCase) notifyQuestionApplicants(id int, applicantIDs []string, state string) {
+	email, ok := questionApplicantEmails[state]
+
+	if !ok || len(applicantIDs) == 0 {
+		return
+	}
+
+	question, err := qu.QuestionStore.GetQuestion(qu.DB, id)
+
+	if err != nil {
+		log.Errorj(log.JSON{"error": err.Error(), "questionID": id})
+		return
+	}
+
+	applicants, err := qu.ClientStore.GetClientFromIDs(qu.DB, applicantIDs)
+
+	if err != nil {
+		log.Errorj(log.JSON{"error": err.Error(), "questionID": id})
+		return
+	}
+
+	for _, applicant := range *applicants {
+		if !applicant.Email.Valid {
+			continue
+		}
+
+		err = qu.Emailer.SendNotification(
+			applicant.Email.String,
+			email.subject,
+			fmt.Sprintf("Your application to \"%s\" was %s", question.Title, state),
+			email.description,
+			[]string{},
+		)
+
+		if err != nil {
+			log.Errorj(log.JSON{"error": err.Error(), "questionID": id, "clientID": applicant.ID})
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package usecases
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// ReferralUseCase holds all business related functions for referrals
+type ReferralUseCase struct {
+	DB               *sqlx.DB
+	ReferralStore    rfrl.ReferralStore
+	ClientStore      rfrl.ClientStore
+	DocumentStore    rfrl.DocumentStore
+	TutorReviewStore rfrl.TutorReviewStore
+	JobPostingStore  rfrl.JobPostingStore
+}
+
+func NewReferralUseCase(
+	db sqlx.DB,
+	referralStore rfrl.ReferralStore,
+	clientStore rfrl.ClientStore,
+	documentStore rfrl.DocumentStore,
+	tutorReviewStore rfrl.TutorReviewStore,
+	jobPostingStore rfrl.JobPostingStore,
+) *ReferralUseCase {
+	return &ReferralUseCase{&db, referralStore, clientStore, documentStore, tutorReviewStore, jobPostingStore}
+}
+
+// CreateReferral asks an employee with a verified work email at the company to refer the seeker
+func (ru ReferralUseCase) CreateReferral(
+	seekerID string,
+	referrerID string,
+	companyID int,
+	jobPostingID null.Int,
+	jobURL string,
+	documentID int,
+	message string,
+) (*rfrl.Referral, error) {
+	if seekerID == referrerID {
+		return nil, errors.New("Cannot request a referral from yourself")
+	}
+
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ru.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "CreateReferral")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var referrer *rfrl.Client
+	referrer, *err = ru.ClientStore.GetClientFromID(tx, referrerID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if !referrer.VerifiedWorkEmail.Valid || !referrer.VerifiedWorkEmail.Bool ||
+		!referrer.CompanyID.Valid || referrer.CompanyID.Int64 != int64(companyID) {
+		*err = rfrl.ErrReferrerNotVerified
+		return nil, *err
+	}
+
+	// A referral for a posting has to be for an open posting at the referrer's company
+	if jobPostingID.Valid {
+		var posting *rfrl.JobPosting
+		posting, *err = ru.JobPostingStore.GetJobPosting(tx, int(jobPostingID.Int64))
+
+		if *err != nil {
+			return nil, *err
+		}
+
+		if posting.CompanyID != companyID {
+			*err = rfrl.ErrJobPostingWrongCompany
+			return nil, *err
+		}
+
+		if posting.IsExpired(time.Now()) {
+			*err = rfrl.ErrJobPostingExpired
+			return nil, *err
+		}
+
+		if jobURL == "" {
+			jobURL = posting.URL
+		}
+	}
+
+	if jobURL == "" {
+		*err = errors.New("Job url or job posting is required")
+		return nil, *err
+	}
+
+	// Makes sure the resume belongs to the seeker
+	_, *err = ru.DocumentStore.GetDocument(tx, documentID, seekerID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var exists bool
+	exists, *err = ru.ReferralStore.CheckOpenReferralExists(tx, seekerID, referrerID, jobURL)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if exists {
+		*err = rfrl.ErrReferralAlreadyRequested
+		return nil, *err
+	}
+
+	var referral *rfrl.Referral
+	referral, *err = ru.ReferralStore.CreateReferral(
+		tx,
+		rfrl.NewReferral(seekerID, referrerID, companyID, jobPostingID, jobURL, documentID, message),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var change *rfrl.ReferralStateChange
+	change, *err = ru.ReferralStore.CreateReferralStateChange(
+		tx,
+		rfrl.NewReferralStateChange(referral.ID, "", referral.State, seekerID, ""),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	referral.History = []rfrl.ReferralStateChange{*change}
+	referral.Outcomes = []rfrl.ReferralOutcome{}
+
+	return referral, nil
+}
+
+// withHistory adds the status history, the outcomes and the seeker's resume, which the referrer could
+// not read otherwise since documents are only fetched by their owner
+func (ru ReferralUseCase) withHistory(db rfrl.DB, referral *rfrl.Referral) (*rfrl.Referral, error) {
+	if referral.DocumentID.Valid {
+		document, err := ru.DocumentStore.GetDocument(db, int(referral.DocumentID.Int64), referral.SeekerID)
+
+		if err != nil && errors.Cause(err) != sql.ErrNoRows {
+			return nil, err
+		}
+
+		if err == nil {
+			referral.Document = document
+		}
+	}
+
+	history, err := ru.ReferralStore.GetReferralStateChanges(db, referral.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes, err := ru.ReferralStore.GetReferralOutcomes(db, referral.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	referral.History = *history
+	referral.Outcomes = *outcomes
+
+	return referral, nil
+}
+
+// GetReferral returns the referral with its status history and outcomes if the client is part of it
+func (ru ReferralUseCase) GetReferral(clientID string, ID int) (*rfrl.Referral, error) {
+	referral, err := ru.ReferralStore.GetReferral(ru.DB, clientID, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ru.withHistory(ru.DB, referral)
+}
+
+func (ru ReferralUseCase) GetReferrals(clientID string, options rfrl.GetReferralsOptions, page rfrl.PageOptions) (*[]rfrl.Referral, *rfrl.Cursor, error) {
+	return ru.ReferralStore.GetReferrals(ru.DB, clientID, options, page)
+}
+
+// UpdateReferralState moves the referral to state and records it in the status history
+func (ru ReferralUseCase) UpdateReferralState(
+	clientID string,
+	ID int,
+	state rfrl.ReferralState,
+	reason string,
+) (*rfrl.Referral, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ru.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "UpdateReferralState")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var referral *rfrl.Referral
+	referral, *err = ru.ReferralStore.GetReferralForUpdate(tx, clientID, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = referral.CanTransition(clientID, state)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var updated *rfrl.Referral
+	updated, *err = ru.ReferralStore.UpdateReferralState(
+		tx,
+		ID,
+		clientID,
+		state,
+		null.NewString(reason, reason != ""),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	_, *err = ru.ReferralStore.CreateReferralStateChange(
+		tx,
+		rfrl.NewReferralStateChange(ID, referral.State, state, clientID, reason),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	updated, *err = ru.withHistory(tx, updated)
+
+	return updated, *err
+}
+
+// ReportReferralOutcome records how far the seeker got in the hiring process after the referral
+func (ru ReferralUseCase) ReportReferralOutcome(
+	clientID string,
+	ID int,
+	stage string,
+	occurredOn time.Time,
+	note string,
+) (*rfrl.Referral, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ru.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "ReportReferralOutcome")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var referral *rfrl.Referral
+	referral, *err = ru.ReferralStore.GetReferralForUpdate(tx, clientID, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = referral.CanReportOutcome(clientID, stage)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if referral.Outcome.Valid && referral.OutcomeOn.Valid && occurredOn.Before(referral.OutcomeOn.Time) {
+		*err = errors.Wrapf(rfrl.ErrInvalidReferralOutcome, "Outcome cannot happen before the last reported outcome")
+		return nil, *err
+	}
+
+	_, *err = ru.ReferralStore.CreateReferralOutcome(tx, rfrl.NewReferralOutcome(ID, stage, occurredOn, note))
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var updated *rfrl.Referral
+	updated, *err = ru.ReferralStore.UpdateReferralOutcome(tx, ID, stage, occurredOn)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	updated, *err = ru.withHistory(tx, updated)
+
+	return updated, *err
+}
+
+// GetReferrerProfileStats returns the referral stats shown next to the reviews on a public profile
+func (ru ReferralUseCase) GetReferrerProfileStats(referrerID string) (*rfrl.ReferrerProfileStats, error) {
+	referrals, err := ru.ReferralStore.GetReferrerStats(ru.DB, referrerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := ru.TutorReviewStore.GetTutorReviewsAggregate(ru.DB, referrerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rfrl.ReferrerProfileStats{Referrals: *referrals, Reviews: *reviews}, nil
+}
+
+func (ru ReferralUseCase) GetCompanyReferralStats(companyID int) (*rfrl.ReferralStats, error) {
+	return ru.ReferralStore.GetCompanyReferralStats(ru.DB, companyID)
+}
+
+func (ru ReferralUseCase) GetReferrerLeaderboard(companyID null.Int, limit int) (*[]rfrl.ReferrerLeaderboardEntry, error) {
+	if limit <= 0 || limit > rfrl.MaxLeaderboardSize {
+		limit = rfrl.MaxLeaderboardSize
+	}
+
+	return ru.ReferralStore.GetReferrerLeaderboard(ru.DB, companyID, limit)
+}
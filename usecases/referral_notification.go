@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+)
+
+// ReferralNotificationUseCase holds all business related functions for referral emails
+type ReferralNotificationUseCase struct {
+	DB                        *sqlx.DB
+	ReferralNotificationStore rfrl.ReferralNotificationStore
+	ClientStore               rfrl.ClientStore
+	CompanyStore              rfrl.CompanyStore
+	Emailer                   rfrl.EmailerUseCase
+}
+
+func NewReferralNotificationUseCase(
+	db sqlx.DB,
+	referralNotificationStore rfrl.ReferralNotificationStore,
+	clientStore rfrl.ClientStore,
+	companyStore rfrl.CompanyStore,
+	emailer rfrl.EmailerUseCase,
+) *ReferralNotificationUseCase {
+	return &ReferralNotificationUseCase{&db, referralNotificationStore, clientStore, companyStore, emailer}
+}
+
+// NotifyReferrerVerified emails seekers wanting the company that a new referrer joined, then sends
+// the referrer a digest of the seekers waiting at their company. Every email that could be sent is
+// sent, the first error is returned
+func (rnu ReferralNotificationUseCase) NotifyReferrerVerified(referrerID string, companyID int) error {
+	referrer, err := rnu.ClientStore.GetClientFromID(rnu.DB, referrerID)
+
+	if err != nil {
+		return err
+	}
+
+	company, err := rnu.CompanyStore.GetCompany(rnu.DB, companyID)
+
+	if err != nil {
+		return err
+	}
+
+	companyName := company.Name.String
+
+	seekers, err := rnu.ReferralNotificationStore.GetSeekersToNotifyOfReferrer(rnu.DB, companyID, referrerID)
+
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+
+	for _, seeker := range *seekers {
+		if !seeker.Email.Valid {
+			continue
+		}
+
+		err = rnu.Emailer.SendNotification(
+			seeker.Email.String,
+			fmt.Sprintf("A referrer from %s just joined rfrl", companyName),
+			fmt.Sprintf("%s can refer you to %s", referrer.DisplayName(), companyName),
+			"Someone who works at a company you want a referral for verified their work email. Send them a referral request on rfrl.",
+			[]string{},
+		)
+
+		if err == nil {
+			_, err = rnu.ReferralNotificationStore.CreateReferralNotification(
+				rnu.DB,
+				rfrl.NewReferralNotification(seeker.ID, rfrl.NOTIFICATION_REFERRER_AVAILABLE, companyID, referrerID),
+			)
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	err = rnu.sendPendingSeekersDigest(*referrer, companyID, companyName)
+
+	if err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+func (rnu ReferralNotificationUseCase) sendPendingSeekersDigest(referrer rfrl.Client, companyID int, companyName string) error {
+	if !referrer.Email.Valid {
+		return nil
+	}
+
+	canSend, err := rnu.ReferralNotificationStore.CanSendPendingSeekersDigest(rnu.DB, referrer.ID)
+
+	if err != nil || !canSend {
+		return err
+	}
+
+	seekers, err := rnu.ReferralNotificationStore.GetPendingSeekers(rnu.DB, companyID, referrer.ID, rfrl.PendingSeekersDigestSize)
+
+	if err != nil || len(*seekers) == 0 {
+		return err
+	}
+
+	lines := make([]string, 0, len(*seekers))
+
+	for _, seeker := range *seekers {
+		line := seeker.DisplayName()
+
+		if seeker.WorkTitle.Valid && seeker.WorkTitle.String != "" {
+			line = fmt.Sprintf("%s, %s", line, seeker.WorkTitle.String)
+		}
+		lines = append(lines, line)
+	}
+
+	err = rnu.Emailer.SendNotification(
+		referrer.Email.String,
+		fmt.Sprintf("%d people want a referral to %s", len(*seekers), companyName),
+		fmt.Sprintf("People are looking for a referral to %s", companyName),
+		"Thanks for verifying your work email. These members are waiting for a referral at your company:",
+		lines,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = rnu.ReferralNotificationStore.CreateReferralNotification(
+		rnu.DB,
+		rfrl.NewReferralNotification(referrer.ID, rfrl.NOTIFICATION_PENDING_SEEKERS_DIGEST, companyID, ""),
+	)
+
+	return err
+}
+
+func (rnu ReferralNotificationUseCase) GetNotificationSetting(clientID string) (*rfrl.NotificationSetting, error) {
+	return rnu.ReferralNotificationStore.GetNotificationSetting(rnu.DB, clientID)
+}
+
+// UpdateNotificationSetting changes only the settings that are given
+func (rnu ReferralNotificationUseCase) UpdateNotificationSetting(
+	clientID string,
+	referrerAvailable null.Bool,
+	pendingSeekersDigest null.Bool,
+) (*rfrl.NotificationSetting, error) {
+	setting, err := rnu.ReferralNotificationStore.GetNotificationSetting(rnu.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if referrerAvailable.Valid {
+		setting.ReferrerAvailable = referrerAvailable.Bool
+	}
+
+	if pendingSeekersDigest.Valid {
+		setting.PendingSeekersDigest = pendingSeekersDigest.Bool
+	}
+
+	return rnu.ReferralNotificationStore.UpsertNotificationSetting(rnu.DB, setting)
+}
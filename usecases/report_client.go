@@ -22,6 +22,6 @@ func (r ReportClientUseCase) DeleteReport(report rfrl.ReportClient) error {
 	return r.ReportClientStore.DeleteReport(r.db, report.Reporter, report.Accused)
 }
 
-func (r ReportClientUseCase) GetReports() (*[]rfrl.ReportClient, error) {
-	return r.ReportClientStore.GetReports(r.db)
+func (r ReportClientUseCase) GetReports(page rfrl.PageOptions) (*[]rfrl.ReportClient, *rfrl.Cursor, error) {
+	return r.ReportClientStore.GetReports(r.db, page)
 }
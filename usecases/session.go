@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/Arun4rangan/api-rfrl/rfrl"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
@@ -14,10 +16,16 @@ type SessionUseCase struct {
 	DB           *sqlx.DB
 	SessionStore rfrl.SessionStore
 	ClientStore  rfrl.ClientStore
+	PrivacyStore rfrl.PrivacyStore
 }
 
-func NewSessionUseCase(db sqlx.DB, sessionStore rfrl.SessionStore, clientStore rfrl.ClientStore) *SessionUseCase {
-	return &SessionUseCase{&db, sessionStore, clientStore}
+func NewSessionUseCase(
+	db sqlx.DB,
+	sessionStore rfrl.SessionStore,
+	clientStore rfrl.ClientStore,
+	privacyStore rfrl.PrivacyStore,
+) *SessionUseCase {
+	return &SessionUseCase{&db, sessionStore, clientStore, privacyStore}
 }
 
 func (su *SessionUseCase) CreateSession(
@@ -25,9 +33,11 @@ func (su *SessionUseCase) CreateSession(
 	updatedBy string,
 	roomID string,
 	clients []string,
-	state string,
+	state rfrl.SessionState,
+	questionID null.Int,
 ) (*rfrl.Session, error) {
 	session := rfrl.NewSession(tutorID, updatedBy, roomID, state)
+	session.QuestionID = questionID
 	var err = new(error)
 	var tx *sqlx.Tx
 
@@ -39,29 +49,42 @@ func (su *SessionUseCase) CreateSession(
 
 	defer rfrl.HandleTransactions(tx, err)
 
-	if *err != nil {
-		return nil, *err
-	}
+	session, *err = createSession(tx, su.SessionStore, su.ClientStore, session, clients)
+
+	return session, *err
+}
 
-	session, *err = su.SessionStore.CreateSession(tx, session)
+// createSession creates the session with its clients in db so that it can be part of a larger transaction
+func createSession(
+	db rfrl.DB,
+	sessionStore rfrl.SessionStore,
+	clientStore rfrl.ClientStore,
+	session *rfrl.Session,
+	clients []string,
+) (*rfrl.Session, error) {
+	err := checkIsTutor(db, clientStore, session.TutorID)
 
-	if *err != nil {
-		return nil, *err
+	if err != nil {
+		return nil, err
 	}
 
-	cl := &([]rfrl.Client{})
+	session, err = sessionStore.CreateSession(db, session)
 
-	cl, *err = su.SessionStore.CreateSessionClients(tx, session.ID, clients)
+	if err != nil {
+		return nil, err
+	}
 
-	if *err != nil {
-		return nil, *err
+	cl, err := sessionStore.CreateSessionClients(db, session.ID, clients)
+
+	if err != nil {
+		return nil, err
 	}
 
 	log.Errorj(log.JSON{"session_clients": cl})
 
 	session.Clients = *cl
 
-	return session, *err
+	return session, nil
 }
 
 func (su SessionUseCase) CheckAllClientSessionHasResponded(
@@ -70,45 +93,154 @@ func (su SessionUseCase) CheckAllClientSessionHasResponded(
 	return su.SessionStore.CheckAllClientSessionHasResponded(su.DB, ID)
 }
 
-func (su SessionUseCase) UpdateSession(
+func (su SessionUseCase) CheckAllClientsCanAttendSession(ID int) (bool, error) {
+	return su.SessionStore.CheckAllClientsCanAttendSession(su.DB, ID)
+}
+
+func canTransitionSession(
+	clientID string,
+	session rfrl.Session,
+	event *rfrl.Event,
+	allAttending bool,
+	state rfrl.SessionState,
+	now time.Time,
+) error {
+	transition, ok := rfrl.SessionStateTransitions[session.State][state]
+
+	if !ok {
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Session cannot move from %s to %s",
+			session.State,
+			state,
+		)
+	}
+
+	if transition.TutorOnly && session.TutorID != clientID {
+		return errors.Wrapf(
+			rfrl.ErrSessionStateTransitionNotAllowed,
+			"Only the tutor can move a session to %s",
+			state,
+		)
+	}
+
+	if transition.RequiresAllAttending && !allAttending {
+		return errors.Wrapf(
+			rfrl.ErrSessionStateTransitionNotAllowed,
+			"Every client has to be able to attend before the session moves to %s",
+			state,
+		)
+	}
+
+	if !transition.RequiresEvent {
+		return nil
+	}
+
+	if event == nil {
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Session needs an event before it can move to %s",
+			state,
+		)
+	}
+
+	if transition.BeforeEventStart && !now.Before(event.StartTime) {
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Session cannot move to %s after its event has started",
+			state,
+		)
+	}
+
+	if transition.AfterEventStart && now.Before(event.StartTime) {
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Session cannot move to %s before its event has started",
+			state,
+		)
+	}
+
+	return nil
+}
+
+// transitionSession moves a locked session to a new state and records the change
+func transitionSession(
+	tx *sqlx.Tx,
+	sessionStore rfrl.SessionStore,
 	ID int,
 	updatedBy string,
-	state string,
+	state rfrl.SessionState,
+	reason string,
 ) (*rfrl.Session, error) {
-	var err = new(error)
-	var tx *sqlx.Tx
+	session, err := sessionStore.GetSessionByIDForUpdate(tx, updatedBy, ID)
 
-	tx, *err = su.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
 
-	if *err != nil {
-		return nil, errors.Wrap(*err, "UpdateSession")
+	var event *rfrl.Event
+	if session.TargetedEventID.Valid {
+		event, err = sessionStore.GetSessionEventByID(tx, ID, int(session.TargetedEventID.Int64))
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	defer rfrl.HandleTransactions(tx, err)
+	allAttending := false
+
+	if rfrl.SessionStateTransitions[session.State][state].RequiresAllAttending {
+		allAttending, err = sessionStore.CheckAllClientsCanAttendSession(tx, ID)
 
-	var session, updatedSession *rfrl.Session
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	session, *err = su.SessionStore.GetSessionByIDForUpdate(tx, updatedBy, ID)
+	err = canTransitionSession(updatedBy, *session, event, allAttending, state, time.Now())
 
-	if *err != nil {
-		return nil, *err
+	if err != nil {
+		return nil, err
 	}
 
-	conferenceID := null.NewString("", false)
 	if state == rfrl.SCHEDULED {
-		var newUUID uuid.UUID
-		newUUID, *err = uuid.NewV4()
-		if *err != nil {
-			return nil, *err
+		newUUID, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sessionStore.UpdateSession(
+			tx,
+			ID,
+			updatedBy,
+			null.NewInt(0, false),
+			null.NewString(newUUID.String(), true),
+		)
+
+		if err != nil {
+			return nil, err
 		}
-		conferenceID = null.NewString(newUUID.String(), true)
 	}
 
-	//TODO: Add logic on what should be updated
-	updatedSession, *err = su.SessionStore.UpdateSession(tx, ID, updatedBy, state, null.NewInt(0, false), conferenceID)
+	updatedSession, err := sessionStore.UpdateSessionState(
+		tx,
+		ID,
+		updatedBy,
+		state,
+		null.NewString(reason, reason != ""),
+	)
 
-	if *err != nil {
-		return session, *err
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sessionStore.CreateSessionStateChange(
+		tx,
+		rfrl.NewSessionStateChange(ID, session.State, state, updatedBy, reason),
+	)
+
+	if err != nil {
+		return nil, err
 	}
 
 	updatedSession.CanAttend = session.CanAttend
@@ -116,6 +248,34 @@ func (su SessionUseCase) UpdateSession(
 	return updatedSession, nil
 }
 
+func (su SessionUseCase) UpdateSession(
+	ID int,
+	updatedBy string,
+	state rfrl.SessionState,
+	reason string,
+) (*rfrl.Session, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = su.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "UpdateSession")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var session *rfrl.Session
+
+	session, *err = transitionSession(tx, su.SessionStore, ID, updatedBy, state, reason)
+
+	return session, *err
+}
+
+func (su SessionUseCase) GetSessionStateChanges(sessionID int, page rfrl.PageOptions) (*[]rfrl.SessionStateChange, *rfrl.Cursor, error) {
+	return su.SessionStore.GetSessionStateChanges(su.DB, sessionID, page)
+}
+
 func (su SessionUseCase) GetSessionByID(clientID string, ID int) (*rfrl.Session, error) {
 	session, err := su.SessionStore.GetSessionByID(su.DB, clientID, ID)
 
@@ -126,7 +286,7 @@ func (su SessionUseCase) GetSessionByID(clientID string, ID int) (*rfrl.Session,
 	return session, nil
 }
 
-func (su SessionUseCase) GetSessionByRoomId(clientID string, roomID string, state string) (*[]rfrl.Session, error) {
+func (su SessionUseCase) GetSessionByRoomId(clientID string, roomID string, state rfrl.SessionState) (*[]rfrl.Session, error) {
 	sessions, err := su.SessionStore.GetSessionByRoomID(su.DB, clientID, roomID, state)
 
 	if err != nil {
@@ -136,8 +296,8 @@ func (su SessionUseCase) GetSessionByRoomId(clientID string, roomID string, stat
 	return sessions, nil
 }
 
-func (su SessionUseCase) GetSessionByClientID(clientID string, state string) (*[]rfrl.Session, error) {
-	return su.SessionStore.GetSessionByClientID(su.DB, clientID, state)
+func (su SessionUseCase) GetSessionByClientID(clientID string, state rfrl.SessionState, page rfrl.PageOptions) (*[]rfrl.Session, *rfrl.Cursor, error) {
+	return su.SessionStore.GetSessionByClientID(su.DB, clientID, state, page)
 }
 
 func canDeleteSession(clientID string, session rfrl.Session) error {
@@ -169,12 +329,22 @@ func (su SessionUseCase) DeleteSession(clientID string, ID int) error {
 	return su.SessionStore.DeleteSession(su.DB, ID)
 }
 
+// clientTimezone returns the timezone picked by the client, events they create are shown in it
+func clientTimezone(clients []rfrl.Client, clientID string) string {
+	for i := 0; i < len(clients); i++ {
+		if clients[i].ID == clientID && clients[i].Timezone.Valid {
+			return clients[i].Timezone.String
+		}
+	}
+	return rfrl.DefaultTimezone
+}
+
 func (su SessionUseCase) CreateSessionEvent(clientID string, ID int, event rfrl.Event) (*rfrl.Event, error) {
 	// This will be a problem for the future because there is no guarantees that two parallel transaction will result in a unique event range
 	var err = new(error)
 	var tx *sqlx.Tx
 	var session *rfrl.Session
-	var isOverLapping bool
+	var conflicts *[]rfrl.EventConflict
 	insertedEvents := &([]rfrl.Event{})
 
 	tx, *err = su.DB.Beginx()
@@ -191,8 +361,8 @@ func (su SessionUseCase) CreateSessionEvent(clientID string, ID int, event rfrl.
 		return nil, *err
 	}
 
-	if session.State == rfrl.SCHEDULED {
-		*err = errors.New("Cannot change tutor event after scheduled")
+	if session.State != rfrl.PENDING {
+		*err = errors.Errorf("Cannot change tutor event once session is %s", session.State)
 		return nil, *err
 	}
 
@@ -216,14 +386,16 @@ func (su SessionUseCase) CreateSessionEvent(clientID string, ID int, event rfrl.
 		return nil, *err
 	}
 
-	isOverLapping, *err = su.ClientStore.CheckOverlapingEventsByClientIDs(tx, clientIDs, &[]rfrl.Event{event})
+	event.Timezone = clientTimezone(session.Clients, clientID)
+
+	conflicts, *err = su.ClientStore.GetOverlapingEventsByClientIDs(tx, clientIDs, &[]rfrl.Event{event}, nil)
 
 	if *err != nil {
 		return nil, *err
 	}
 
-	if isOverLapping {
-		*err = errors.New("Events overlap")
+	if len(*conflicts) > 0 {
+		*err = rfrl.NewEventsOverlapError(clientID, *conflicts)
 		return nil, *err
 	}
 
@@ -240,7 +412,6 @@ func (su SessionUseCase) CreateSessionEvent(clientID string, ID int, event rfrl.
 		tx,
 		ID,
 		clientID,
-		"",
 		null.IntFrom(int64(createdEvent.ID)),
 		null.NewString("", false),
 	)
@@ -281,6 +452,14 @@ func (su SessionUseCase) ClientActionOnSessionEvent(clientID string, sessionID i
 		return errors.New("Session does not belong to client")
 	}
 
+	if session.State != rfrl.PENDING {
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Cannot respond to event once session is %s",
+			session.State,
+		)
+	}
+
 	err = su.SessionStore.CreateClientSelectionOfEvent(su.DB, sessionID, clientID, canAttend)
 
 	return err
@@ -292,12 +471,13 @@ func (su SessionUseCase) GetSessionRelatedEvents(
 	start null.Time,
 	end null.Time,
 	state null.String,
-) (*[]rfrl.Event, error) {
+	page rfrl.PageOptions,
+) (*[]rfrl.Event, *rfrl.Cursor, error) {
 	// This will be a problem for the future because there is no guarantees that two parallel transaction will result in a unique event range
 	session, err := su.SessionStore.GetSessionByID(su.DB, clientID, sessionID)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	forClient := false
 	clientIds := make([]string, len(session.Clients))
@@ -310,10 +490,10 @@ func (su SessionUseCase) GetSessionRelatedEvents(
 	}
 
 	if !forClient {
-		return nil, errors.New("Session does not belong to client")
+		return nil, nil, errors.New("Session does not belong to client")
 	}
 
-	return su.ClientStore.GetRelatedEventsByClientIDs(su.DB, clientIds, start, end, state)
+	return su.ClientStore.GetRelatedEventsPageByClientIDs(su.DB, clientIds, start, end, state, page)
 }
 
 func (su SessionUseCase) GetSessionEventByID(sessionID int, ID int) (*rfrl.Event, error) {
@@ -333,3 +513,401 @@ func (su SessionUseCase) CheckSessionsIsForClient(clientID string, sessionIDs []
 func (su SessionUseCase) GetSessionFromConferenceID(conferenceID string) (*rfrl.Session, error) {
 	return su.SessionStore.GetSessionFromConferenceID(su.DB, conferenceID)
 }
+
+func getSessionClientIDs(session rfrl.Session, clientID string) ([]string, bool) {
+	forClient := false
+	clientIDs := make([]string, len(session.Clients))
+
+	for i := 0; i < len(session.Clients); i++ {
+		if session.Clients[i].ID == clientID {
+			forClient = true
+		}
+		clientIDs[i] = session.Clients[i].ID
+	}
+
+	return clientIDs, forClient
+}
+
+func (su SessionUseCase) getSessionEvent(db rfrl.DB, session rfrl.Session) (*rfrl.Event, error) {
+	if !session.TargetedEventID.Valid {
+		return nil, nil
+	}
+
+	return su.SessionStore.GetSessionEventByID(db, session.ID, int(session.TargetedEventID.Int64))
+}
+
+func canRescheduleSession(session rfrl.Session, event *rfrl.Event, now time.Time) error {
+	switch session.State {
+	case rfrl.PENDING:
+		return nil
+	case rfrl.SCHEDULED:
+		if event != nil && !now.Before(event.StartTime) {
+			return errors.Wrap(
+				rfrl.ErrInvalidSessionStateTransition,
+				"Cannot reschedule a session after its event has started",
+			)
+		}
+		return nil
+	default:
+		return errors.Wrapf(
+			rfrl.ErrInvalidSessionStateTransition,
+			"Cannot reschedule a session once it is %s",
+			session.State,
+		)
+	}
+}
+
+func (su SessionUseCase) CreateSessionProposal(
+	clientID string,
+	sessionID int,
+	event rfrl.Event,
+	reason string,
+	counterTo null.Int,
+) (*rfrl.SessionProposal, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+	var session *rfrl.Session
+	var currentEvent *rfrl.Event
+	var conflicts *[]rfrl.EventConflict
+
+	tx, *err = su.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "CreateSessionProposal")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	// Locks the session so that proposals on the same session are serialized
+	_, *err = su.SessionStore.GetSessionByIDForUpdate(tx, clientID, sessionID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	session, *err = su.SessionStore.GetSessionByID(tx, clientID, sessionID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	clientIDs, forClient := getSessionClientIDs(*session, clientID)
+
+	if !forClient {
+		*err = errors.New("Session does not belong to client")
+		return nil, *err
+	}
+
+	currentEvent, *err = su.getSessionEvent(tx, *session)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = canRescheduleSession(*session, currentEvent, time.Now())
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if counterTo.Valid {
+		var countered *rfrl.SessionProposal
+		countered, *err = su.SessionStore.GetSessionProposalForUpdate(tx, sessionID, int(counterTo.Int64))
+
+		if *err != nil {
+			return nil, *err
+		}
+
+		if countered.State != rfrl.PROPOSAL_OPEN {
+			*err = errors.Wrapf(rfrl.ErrSessionProposalClosed, "Proposal %d is %s", countered.ID, countered.State)
+			return nil, *err
+		}
+
+		if countered.ProposedBy == clientID {
+			*err = errors.New("Cannot counter your own proposal")
+			return nil, *err
+		}
+	}
+
+	conflicts, *err = su.ClientStore.GetOverlapingEventsByClientIDs(
+		tx,
+		clientIDs,
+		&[]rfrl.Event{event},
+		[]int{sessionID},
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if len(*conflicts) > 0 {
+		*err = rfrl.NewEventsOverlapError(clientID, *conflicts)
+		return nil, *err
+	}
+
+	*err = su.SessionStore.CloseOpenSessionProposals(tx, sessionID, clientID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var proposal *rfrl.SessionProposal
+	proposal, *err = su.SessionStore.CreateSessionProposal(
+		tx,
+		rfrl.NewSessionProposal(sessionID, clientID, event, reason, counterTo),
+	)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	proposal.Responses = make([]rfrl.SessionProposalResponse, 0)
+
+	return proposal, nil
+}
+
+func (su SessionUseCase) applySessionProposal(
+	tx *sqlx.Tx,
+	clientID string,
+	session rfrl.Session,
+	clientIDs []string,
+	proposal rfrl.SessionProposal,
+) error {
+	currentEvent, err := su.getSessionEvent(tx, session)
+
+	if err != nil {
+		return err
+	}
+
+	err = canRescheduleSession(session, currentEvent, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	event := rfrl.Event{
+		StartTime: proposal.StartTime,
+		EndTime:   proposal.EndTime,
+		Title:     proposal.Title,
+		Timezone:  clientTimezone(session.Clients, proposal.ProposedBy),
+	}
+
+	conflicts, err := su.ClientStore.GetOverlapingEventsByClientIDs(
+		tx,
+		clientIDs,
+		&[]rfrl.Event{event},
+		[]int{session.ID},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if len(*conflicts) > 0 {
+		return rfrl.NewEventsOverlapError(clientID, *conflicts)
+	}
+
+	insertedEvents, err := su.SessionStore.CreateSessionEvents(tx, []rfrl.Event{event})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = su.SessionStore.UpdateSession(
+		tx,
+		session.ID,
+		clientID,
+		null.IntFrom(int64((*insertedEvents)[0].ID)),
+		null.NewString("", false),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if currentEvent != nil {
+		err = su.SessionStore.DeleteSessionEvents(tx, []int{currentEvent.ID})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if session.State != rfrl.PENDING {
+		return nil
+	}
+
+	// Everyone agreed on the new time so every client can attend the pending session
+	for i := 0; i < len(clientIDs); i++ {
+		err = su.SessionStore.CreateClientSelectionOfEvent(tx, session.ID, clientIDs[i], true)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (su SessionUseCase) RespondToSessionProposal(
+	clientID string,
+	sessionID int,
+	ID int,
+	accept bool,
+) (*rfrl.SessionProposal, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+	var session *rfrl.Session
+	var proposal *rfrl.SessionProposal
+	var allAccepted bool
+
+	tx, *err = su.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "RespondToSessionProposal")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	_, *err = su.SessionStore.GetSessionByIDForUpdate(tx, clientID, sessionID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	session, *err = su.SessionStore.GetSessionByID(tx, clientID, sessionID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	clientIDs, forClient := getSessionClientIDs(*session, clientID)
+
+	if !forClient {
+		*err = errors.New("Session does not belong to client")
+		return nil, *err
+	}
+
+	proposal, *err = su.SessionStore.GetSessionProposalForUpdate(tx, sessionID, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if proposal.State != rfrl.PROPOSAL_OPEN {
+		*err = errors.Wrapf(rfrl.ErrSessionProposalClosed, "Proposal %d is %s", proposal.ID, proposal.State)
+		return nil, *err
+	}
+
+	if proposal.ProposedBy == clientID {
+		*err = errors.New("Cannot respond to your own proposal")
+		return nil, *err
+	}
+
+	*err = su.SessionStore.CreateSessionProposalResponse(tx, ID, clientID, accept)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if !accept {
+		proposal, *err = su.SessionStore.UpdateSessionProposalState(tx, ID, rfrl.PROPOSAL_DECLINED)
+		return proposal, *err
+	}
+
+	allAccepted, *err = su.SessionStore.CheckAllClientsAcceptedSessionProposal(tx, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if !allAccepted {
+		return proposal, nil
+	}
+
+	*err = su.applySessionProposal(tx, clientID, *session, clientIDs, *proposal)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	proposal, *err = su.SessionStore.UpdateSessionProposalState(tx, ID, rfrl.PROPOSAL_ACCEPTED)
+
+	return proposal, *err
+}
+
+func (su SessionUseCase) GetSessionProposals(sessionID int, page rfrl.PageOptions) (*[]rfrl.SessionProposal, *rfrl.Cursor, error) {
+	return su.SessionStore.GetSessionProposals(su.DB, sessionID, page)
+}
+
+// FindCommonFreeSlots ranks the slots between start and end where every client is free,
+// returning them in the timezone of the client asking
+func (su SessionUseCase) FindCommonFreeSlots(
+	clientID string,
+	clientIDs []string,
+	start time.Time,
+	end time.Time,
+	duration time.Duration,
+	limit int,
+) ([]rfrl.CandidateSlot, error) {
+	clients, err := su.ClientStore.GetClientFromIDs(su.DB, clientIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := su.PrivacyStore.GetSessionParticipants(su.DB, clientID, clientIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Others' free time is only shown to who they had sessions with, tutors are open to everyone
+	for _, client := range *clients {
+		if client.ID != clientID && !participants[client.ID] && !client.IsTutor.Bool {
+			return nil, rfrl.ErrFreeSlotsNotAllowed
+		}
+	}
+
+	// Widen the range so events that straddle the edges still block slots
+	busy, err := su.ClientStore.GetRelatedEventsByClientIDs(
+		su.DB,
+		clientIDs,
+		null.TimeFrom(start.AddDate(0, 0, -1)),
+		null.TimeFrom(end.AddDate(0, 0, 1)),
+		null.StringFrom(string(rfrl.SCHEDULED)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	externalBusy, err := su.ClientStore.GetExternalBusyEventsByClientIDs(
+		su.DB,
+		clientIDs,
+		start.AddDate(0, 0, -1),
+		end.AddDate(0, 0, 1),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	*busy = append(*busy, *externalBusy...)
+
+	locations := make([]*time.Location, len(*clients))
+
+	for i := 0; i < len(*clients); i++ {
+		locations[i] = rfrl.LoadTimezone((*clients)[i].Timezone.String)
+	}
+
+	slots := rfrl.FindCommonFreeSlots(*busy, locations, start, end, duration, rfrl.FreeSlotStep, limit)
+	location := rfrl.LoadTimezone(clientTimezone(*clients, clientID))
+
+	for i := 0; i < len(slots); i++ {
+		slots[i].Start = slots[i].Start.In(location)
+		slots[i].End = slots[i].End.In(location)
+	}
+
+	return slots, nil
+}
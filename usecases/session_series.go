@@ -0,0 +1,356 @@
+package usecases
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// SessionSeriesUseCase holds all business related functions for recurring sessions
+type SessionSeriesUseCase struct {
+	DB                 *sqlx.DB
+	SessionSeriesStore rfrl.SessionSeriesStore
+	SessionStore       rfrl.SessionStore
+	ClientStore        rfrl.ClientStore
+}
+
+func NewSessionSeriesUseCase(
+	db sqlx.DB,
+	sessionSeriesStore rfrl.SessionSeriesStore,
+	sessionStore rfrl.SessionStore,
+	clientStore rfrl.ClientStore,
+) *SessionSeriesUseCase {
+	return &SessionSeriesUseCase{&db, sessionSeriesStore, sessionStore, clientStore}
+}
+
+func containsClient(clients []string, clientID string) bool {
+	for i := 0; i < len(clients); i++ {
+		if clients[i] == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+func (ssu SessionSeriesUseCase) CreateSessionSeries(
+	clientID string,
+	tutorID string,
+	roomID string,
+	clients []string,
+	first rfrl.Event,
+	rule string,
+) (*rfrl.SessionSeries, error) {
+	recurrence, err := rfrl.ParseRecurrenceRule(rule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsClient(clients, clientID) || !containsClient(clients, tutorID) {
+		return nil, errors.New("Series has to include the tutor and the creator")
+	}
+
+	creator, err := ssu.ClientStore.GetClientFromID(ssu.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	first.Timezone = clientTimezone([]rfrl.Client{*creator}, clientID)
+
+	occurrences, err := recurrence.Occurrences(first)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(occurrences) == 0 {
+		return nil, errors.New("Recurrence rule does not generate any sessions")
+	}
+
+	var txErr = new(error)
+	var tx *sqlx.Tx
+
+	tx, *txErr = ssu.DB.Beginx()
+
+	if *txErr != nil {
+		return nil, errors.Wrap(*txErr, "CreateSessionSeries")
+	}
+
+	defer rfrl.HandleTransactions(tx, txErr)
+
+	*txErr = checkIsTutor(tx, ssu.ClientStore, tutorID)
+
+	if *txErr != nil {
+		return nil, *txErr
+	}
+
+	var conflicts *[]rfrl.EventConflict
+	conflicts, *txErr = ssu.ClientStore.GetOverlapingEventsByClientIDs(tx, clients, &occurrences, nil)
+
+	if *txErr != nil {
+		return nil, *txErr
+	}
+
+	if len(*conflicts) > 0 {
+		*txErr = rfrl.NewEventsOverlapError(clientID, *conflicts)
+		return nil, *txErr
+	}
+
+	var series *rfrl.SessionSeries
+	series, *txErr = ssu.SessionSeriesStore.CreateSessionSeries(
+		tx,
+		rfrl.NewSessionSeries(tutorID, clientID, roomID, *recurrence, first),
+	)
+
+	if *txErr != nil {
+		return nil, *txErr
+	}
+
+	var insertedEvents *[]rfrl.Event
+	insertedEvents, *txErr = ssu.SessionStore.CreateSessionEvents(tx, occurrences)
+
+	if *txErr != nil {
+		return nil, *txErr
+	}
+
+	series.Sessions = make([]rfrl.Session, 0, len(*insertedEvents))
+
+	for i := 0; i < len(*insertedEvents); i++ {
+		event := (*insertedEvents)[i]
+		session := rfrl.NewSession(tutorID, clientID, roomID, rfrl.PENDING)
+		session.SeriesID = null.IntFrom(int64(series.ID))
+
+		session, *txErr = ssu.SessionStore.CreateSession(tx, session)
+
+		if *txErr != nil {
+			return nil, *txErr
+		}
+
+		var sessionClients *[]rfrl.Client
+		sessionClients, *txErr = ssu.SessionStore.CreateSessionClients(tx, session.ID, clients)
+
+		if *txErr != nil {
+			return nil, *txErr
+		}
+
+		session, *txErr = ssu.SessionStore.UpdateSession(
+			tx,
+			session.ID,
+			clientID,
+			null.IntFrom(int64(event.ID)),
+			null.NewString("", false),
+		)
+
+		if *txErr != nil {
+			return nil, *txErr
+		}
+
+		*txErr = ssu.SessionStore.CreateClientSelectionOfEvent(tx, session.ID, clientID, true)
+
+		if *txErr != nil {
+			return nil, *txErr
+		}
+
+		session.Clients = *sessionClients
+		session.CanAttend = null.BoolFrom(true)
+		session.Event = &event
+		series.Sessions = append(series.Sessions, *session)
+	}
+
+	return series, nil
+}
+
+func (ssu SessionSeriesUseCase) GetSessionSeries(clientID string, ID int) (*rfrl.SessionSeries, error) {
+	return ssu.getSessionSeries(ssu.DB, clientID, ID)
+}
+
+func (ssu SessionSeriesUseCase) getSessionSeries(db rfrl.DB, clientID string, ID int) (*rfrl.SessionSeries, error) {
+	series, err := ssu.SessionSeriesStore.GetSessionSeries(db, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := ssu.SessionSeriesStore.GetSessionSeriesSessions(db, clientID, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessionIDs := make([]int, len(*sessions))
+
+	for i := 0; i < len(*sessions); i++ {
+		sessionIDs[i] = (*sessions)[i].ID
+	}
+
+	if len(sessionIDs) > 0 {
+		events, err := ssu.SessionStore.GetSessionsEvent(db, sessionIDs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(*sessions); i++ {
+			(*sessions)[i].Event = events[(*sessions)[i].ID]
+		}
+	}
+
+	series.Sessions = *sessions
+
+	return series, nil
+}
+
+// RespondToSessionSeries records the client's response on every upcoming pending
+// session of the series and schedules the ones everyone has responded to
+func (ssu SessionSeriesUseCase) RespondToSessionSeries(
+	clientID string,
+	ID int,
+	canAttend bool,
+) (*rfrl.SessionSeries, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ssu.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "RespondToSessionSeries")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = ssu.respondToSessionSeries(tx, clientID, ID, canAttend)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var series *rfrl.SessionSeries
+	series, *err = ssu.getSessionSeries(tx, clientID, ID)
+
+	return series, *err
+}
+
+func (ssu SessionSeriesUseCase) respondToSessionSeries(
+	tx *sqlx.Tx,
+	clientID string,
+	ID int,
+	canAttend bool,
+) error {
+	series, err := ssu.SessionSeriesStore.GetSessionSeriesForUpdate(tx, ID)
+
+	if err != nil {
+		return err
+	}
+
+	if series.State != rfrl.SERIES_ACTIVE {
+		return errors.Wrapf(rfrl.ErrInvalidSessionStateTransition, "Series is %s", series.State)
+	}
+
+	sessions, err := ssu.SessionSeriesStore.GetUpcomingSessionSeriesSessionsForUpdate(tx, ID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(*sessions); i++ {
+		session := (*sessions)[i]
+
+		if session.State != rfrl.PENDING {
+			continue
+		}
+
+		err = ssu.SessionStore.CreateClientSelectionOfEvent(tx, session.ID, clientID, canAttend)
+
+		if err != nil {
+			return err
+		}
+
+		allCanAttend, err := ssu.SessionStore.CheckAllClientsCanAttendSession(tx, session.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if !allCanAttend {
+			continue
+		}
+
+		_, err = transitionSession(tx, ssu.SessionStore, session.ID, clientID, rfrl.SCHEDULED, "")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CancelSessionSeries cancels every upcoming occurrence of the series and closes it
+func (ssu SessionSeriesUseCase) CancelSessionSeries(
+	clientID string,
+	ID int,
+	reason string,
+) (*rfrl.SessionSeries, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = ssu.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "CancelSessionSeries")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	*err = ssu.cancelSessionSeries(tx, clientID, ID, reason)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var series *rfrl.SessionSeries
+	series, *err = ssu.getSessionSeries(tx, clientID, ID)
+
+	return series, *err
+}
+
+func (ssu SessionSeriesUseCase) cancelSessionSeries(
+	tx *sqlx.Tx,
+	clientID string,
+	ID int,
+	reason string,
+) error {
+	series, err := ssu.SessionSeriesStore.GetSessionSeriesForUpdate(tx, ID)
+
+	if err != nil {
+		return err
+	}
+
+	if series.State != rfrl.SERIES_ACTIVE {
+		return errors.Wrapf(rfrl.ErrInvalidSessionStateTransition, "Series is already %s", series.State)
+	}
+
+	sessions, err := ssu.SessionSeriesStore.GetUpcomingSessionSeriesSessionsForUpdate(tx, ID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(*sessions); i++ {
+		_, err = transitionSession(tx, ssu.SessionStore, (*sessions)[i].ID, clientID, rfrl.CANCELLED, reason)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = ssu.SessionSeriesStore.UpdateSessionSeriesState(tx, ID, rfrl.SERIES_CANCELLED)
+
+	return err
+}
+
+func (ssu SessionSeriesUseCase) CheckSessionSeriesIsForClient(clientID string, ID int) (bool, error) {
+	return ssu.SessionSeriesStore.CheckSessionSeriesIsForClient(ssu.DB, clientID, ID)
+}
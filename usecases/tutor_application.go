@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// TutorApplicationUseCase holds all business related functions for tutor applications
+type TutorApplicationUseCase struct {
+	DB                    *sqlx.DB
+	TutorApplicationStore rfrl.TutorApplicationStore
+	ClientStore           rfrl.ClientStore
+	TutorReviewStore      rfrl.TutorReviewStore
+}
+
+func NewTutorApplicationUseCase(
+	db sqlx.DB,
+	tutorApplicationStore rfrl.TutorApplicationStore,
+	clientStore rfrl.ClientStore,
+	tutorReviewStore rfrl.TutorReviewStore,
+) *TutorApplicationUseCase {
+	return &TutorApplicationUseCase{&db, tutorApplicationStore, clientStore, tutorReviewStore}
+}
+
+// takeTutorRequest removes a request to become a tutor from the client fields, clients become tutors once
+// their application is approved. Stopping to be a tutor does not need an application
+func takeTutorRequest(client *rfrl.Client) bool {
+	if !client.IsTutor.Valid || !client.IsTutor.Bool {
+		return false
+	}
+
+	client.IsTutor = null.NewBool(false, false)
+
+	return true
+}
+
+// applyToTutor creates a pending application unless the client is a tutor or is already waiting on one
+func applyToTutor(
+	db rfrl.DB,
+	tutorApplicationStore rfrl.TutorApplicationStore,
+	client rfrl.Client,
+	motivation string,
+) (*rfrl.TutorApplication, error) {
+	if client.IsTutor.Valid && client.IsTutor.Bool {
+		return nil, rfrl.ErrAlreadyTutor
+	}
+
+	pending, err := tutorApplicationStore.HasPendingTutorApplication(db, client.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pending {
+		return nil, rfrl.ErrTutorApplicationPending
+	}
+
+	return tutorApplicationStore.CreateTutorApplication(db, rfrl.NewTutorApplication(client.ID, motivation))
+}
+
+// applyToTutorIfNeeded applies for clients asking to be tutors while signing up or updating their profile,
+// clients that are tutors or already applied are left as they are
+func applyToTutorIfNeeded(db rfrl.DB, tutorApplicationStore rfrl.TutorApplicationStore, client rfrl.Client) error {
+	_, err := applyToTutor(db, tutorApplicationStore, client, "")
+
+	switch errors.Cause(err) {
+	case rfrl.ErrAlreadyTutor, rfrl.ErrTutorApplicationPending:
+		return nil
+	default:
+		return err
+	}
+}
+
+// checkIsTutor makes sure sessions are only booked with approved tutors
+func checkIsTutor(db rfrl.DB, clientStore rfrl.ClientStore, tutorID string) error {
+	tutor, err := clientStore.GetClientFromID(db, tutorID)
+
+	if err != nil {
+		return err
+	}
+
+	if !tutor.IsTutor.Valid || !tutor.IsTutor.Bool {
+		return rfrl.ErrNotApprovedTutor
+	}
+
+	return nil
+}
+
+func (tau TutorApplicationUseCase) SubmitTutorApplication(clientID string, motivation string) (*rfrl.TutorApplication, error) {
+	client, err := tau.ClientStore.GetClientFromID(tau.DB, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return applyToTutor(tau.DB, tau.TutorApplicationStore, *client, motivation)
+}
+
+func (tau TutorApplicationUseCase) GetTutorApplications(
+	options rfrl.GetTutorApplicationsOptions,
+	page rfrl.PageOptions,
+) (*[]rfrl.TutorApplication, *rfrl.Cursor, error) {
+	return tau.TutorApplicationStore.GetTutorApplications(tau.DB, options, page)
+}
+
+// GetTutorApplicationReview gathers the application with the applicant's profile, reviews and past applications
+func (tau TutorApplicationUseCase) GetTutorApplicationReview(ID int) (*rfrl.TutorApplicationReview, error) {
+	application, err := tau.TutorApplicationStore.GetTutorApplication(tau.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review := rfrl.TutorApplicationReview{Application: *application}
+
+	applicant, err := tau.ClientStore.GetClientFromID(tau.DB, application.ClientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.Applicant = *applicant
+
+	aggregate, err := tau.TutorReviewStore.GetTutorReviewsAggregate(tau.DB, application.ClientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.ReviewAggregate = *aggregate
+
+	reviews, _, err := tau.TutorReviewStore.GetTutorReviews(
+		tau.DB,
+		application.ClientID,
+		rfrl.PageOptions{Size: rfrl.TutorApplicationReviewsShown},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.RecentReviews = *reviews
+
+	applications, err := tau.TutorApplicationStore.GetClientTutorApplications(tau.DB, application.ClientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.PastApplications = make([]rfrl.TutorApplication, 0, len(*applications))
+
+	for _, past := range *applications {
+		if past.ID != application.ID {
+			review.PastApplications = append(review.PastApplications, past)
+		}
+	}
+
+	return &review, nil
+}
+
+// ReviewTutorApplication approves or rejects a pending application, approved applicants become tutors
+func (tau TutorApplicationUseCase) ReviewTutorApplication(
+	ID int,
+	adminID string,
+	state string,
+	notes string,
+) (*rfrl.TutorApplication, error) {
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = tau.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "ReviewTutorApplication")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	var application *rfrl.TutorApplication
+	application, *err = tau.TutorApplicationStore.GetTutorApplicationForUpdate(tx, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = application.CanBeReviewedAs(state)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var reviewed *rfrl.TutorApplication
+	reviewed, *err = tau.TutorApplicationStore.ReviewTutorApplication(tx, ID, state, adminID, notes)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if state == rfrl.TUTOR_APPLICATION_APPROVED {
+		_, *err = tau.ClientStore.UpdateClient(tx, application.ClientID, &rfrl.Client{IsTutor: null.BoolFrom(true)})
+
+		if *err != nil {
+			return nil, *err
+		}
+	}
+
+	return reviewed, nil
+}
@@ -1,43 +1,61 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
 )
 
 type TutorReviewUseCase struct {
-	DB               rfrl.DB
+	DB               *sqlx.DB
 	TutorReviewStore rfrl.TutorReviewStore
 	SessionStore     rfrl.SessionStore
 	ClientStore      rfrl.ClientStore
 }
 
 func NewTutorReviewUseCase(
-	db rfrl.DB,
+	db sqlx.DB,
 	tutorReviewStore rfrl.TutorReviewStore,
 	sessionStore rfrl.SessionStore,
 	clientStore rfrl.ClientStore,
 ) *TutorReviewUseCase {
-	return &TutorReviewUseCase{db, tutorReviewStore, sessionStore, clientStore}
+	return &TutorReviewUseCase{&db, tutorReviewStore, sessionStore, clientStore}
 }
 
-func (tru *TutorReviewUseCase) CreateTutorReview(ClientID string, TutorID string, Stars int, Review string, Headline string) (*rfrl.TutorReview, error) {
-	if ClientID == TutorID {
-		return nil, errors.Errorf("Client cannot create a review for said client")
+// CreateTutorReview reviews the tutor of a session the client attended, each session can be reviewed once
+func (tru *TutorReviewUseCase) CreateTutorReview(ClientID string, SessionID int, Stars int, Review string, Headline string) (*rfrl.TutorReview, error) {
+	session, err := tru.SessionStore.GetSessionByID(tru.DB, ClientID, SessionID)
+
+	if err != nil {
+		return nil, err
 	}
 
-	checkOverlap, err := tru.SessionStore.CheckClientsAttendedTutorSession(tru.DB, TutorID, []string{ClientID})
+	if session.TargetedEventID.Valid {
+		session.Event, err = tru.SessionStore.GetSessionEventFromSessionID(tru.DB, session.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !session.AttendedBy(ClientID, time.Now()) {
+		return nil, rfrl.ErrSessionNotAttended
+	}
+
+	alreadyExist, err := tru.TutorReviewStore.CheckIfReviewAlreadyExists(tru.DB, ClientID, SessionID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if checkOverlap == false {
-		return nil, errors.Errorf("Client did not get tutored by Tutor")
+	if alreadyExist {
+		return nil, rfrl.ErrTutorReviewExists
 	}
 
-	tutorReview := rfrl.NewTutorReview(TutorID, Stars, Review, Headline)
+	tutorReview := rfrl.NewTutorReview(session.TutorID, SessionID, Stars, Review, Headline)
 
 	createdTutorReview, err := tru.TutorReviewStore.CreateTutorReview(tru.DB, ClientID, &tutorReview)
 
@@ -45,7 +63,7 @@ func (tru *TutorReviewUseCase) CreateTutorReview(ClientID string, TutorID string
 		return nil, err
 	}
 
-	err = tru.TutorReviewStore.DeletePendingReview(tru.DB, ClientID, TutorID)
+	err = tru.TutorReviewStore.DeletePendingReview(tru.DB, ClientID, SessionID)
 
 	if err != nil {
 		return nil, err
@@ -62,15 +80,35 @@ func (tru *TutorReviewUseCase) CreateTutorReview(ClientID string, TutorID string
 	return createdTutorReview, err
 }
 
+// UpdateTutorReview edits the client's review, the review as it was before is kept as a revision
 func (tru *TutorReviewUseCase) UpdateTutorReview(ClientID string, ID int, Stars int, Review string, Headline string) (*rfrl.TutorReview, error) {
-	forClient, err := tru.TutorReviewStore.CheckTutorReviewForClient(tru.DB, ClientID, ID)
+	var err = new(error)
+	var tx *sqlx.Tx
 
-	if err != nil {
-		return nil, err
+	tx, *err = tru.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "UpdateTutorReview")
 	}
 
-	if forClient == false {
-		return nil, errors.Errorf("Tutor Review  (%d) does not belong to this client %s", ID, ClientID)
+	defer rfrl.HandleTransactions(tx, err)
+
+	var previous *rfrl.TutorReview
+	previous, *err = tru.TutorReviewStore.GetTutorReviewForUpdate(tx, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	if previous.FromID != ClientID {
+		*err = rfrl.ErrTutorReviewNotForClient
+		return nil, *err
+	}
+
+	*err = tru.TutorReviewStore.CreateTutorReviewRevision(tx, previous, ClientID)
+
+	if *err != nil {
+		return nil, *err
 	}
 
 	tutorReview := rfrl.TutorReview{}
@@ -79,7 +117,10 @@ func (tru *TutorReviewUseCase) UpdateTutorReview(ClientID string, ID int, Stars
 	tutorReview.Review = null.NewString(Review, true)
 	tutorReview.Headline = null.NewString(Headline, true)
 
-	return tru.TutorReviewStore.UpdateTutorReview(tru.DB, &tutorReview)
+	var updated *rfrl.TutorReview
+	updated, *err = tru.TutorReviewStore.UpdateTutorReview(tx, &tutorReview)
+
+	return updated, *err
 }
 
 func (tru *TutorReviewUseCase) DeleteTutorReview(ClientID string, ID int) error {
@@ -109,48 +150,105 @@ func (tru *TutorReviewUseCase) GetTutorReview(ID int) (*rfrl.TutorReview, error)
 
 	tutorReview.FromClient = *fromClient
 
+	replies, err := tru.TutorReviewStore.GetTutorReviewReplies(tru.DB, []int{tutorReview.ID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*replies) > 0 {
+		tutorReview.Reply = &(*replies)[0]
+	}
+
 	return tutorReview, nil
 }
 
-func (tru *TutorReviewUseCase) GetTutorReviews(TutorID string) (*[]rfrl.TutorReview, error) {
-	tutorReviews, err := tru.TutorReviewStore.GetTutorReviews(tru.DB, TutorID)
+func (tru *TutorReviewUseCase) GetTutorReviews(TutorID string, page rfrl.PageOptions) (*[]rfrl.TutorReview, *rfrl.Cursor, error) {
+	tutorReviews, next, err := tru.TutorReviewStore.GetTutorReviews(tru.DB, TutorID, page)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	err = tru.addReviewDetails(tutorReviews)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tutorReviews, next, nil
+}
+
+// addReviewDetails adds who wrote the reviews and the replies of the tutor. A client can review many
+// sessions of the same tutor so a reviewer can be on more than one review
+func (tru *TutorReviewUseCase) addReviewDetails(tutorReviews *[]rfrl.TutorReview) error {
+	if len(*tutorReviews) == 0 {
+		return nil
 	}
 
-	clientIDToIndex := make(map[string]int)
 	var clientIDs []string
+	var reviewIDs []int
 	for i := 0; i < len(*tutorReviews); i++ {
 		clientIDs = append(clientIDs, (*tutorReviews)[i].FromID)
-		clientIDToIndex[(*tutorReviews)[i].FromID] = i
+		reviewIDs = append(reviewIDs, (*tutorReviews)[i].ID)
 	}
 
-	if len(clientIDs) > 0 {
-		clients, err := tru.ClientStore.GetClientFromIDs(tru.DB, clientIDs)
+	clients, err := tru.ClientStore.GetClientFromIDs(tru.DB, clientIDs)
 
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return err
+	}
 
-		for i := 0; i < len(*clients); i++ {
-			index := clientIDToIndex[(*clients)[i].ID]
-			(*tutorReviews)[index].FromClient = (*clients)[i]
-		}
+	clientsByID := make(map[string]rfrl.Client)
+	for _, client := range *clients {
+		clientsByID[client.ID] = client
+	}
+
+	replies, err := tru.TutorReviewStore.GetTutorReviewReplies(tru.DB, reviewIDs)
+
+	if err != nil {
+		return err
+	}
+
+	repliesByReviewID := make(map[int]*rfrl.TutorReviewReply)
+	for i := range *replies {
+		repliesByReviewID[(*replies)[i].ReviewID] = &(*replies)[i]
 	}
 
-	return tutorReviews, nil
+	for i := range *tutorReviews {
+		review := &(*tutorReviews)[i]
+		review.FromClient = clientsByID[review.FromID]
+		review.Reply = repliesByReviewID[review.ID]
+	}
+
+	return nil
 }
 
 func (tru *TutorReviewUseCase) GetTutorReviewsAggregate(ClientID string) (*rfrl.TutorReviewAggregate, error) {
 	return tru.TutorReviewStore.GetTutorReviewsAggregate(tru.DB, ClientID)
 }
 
+func (tru *TutorReviewUseCase) GetTutorReviewsAggregateBySessionType(ClientID string) (*[]rfrl.TutorReviewSessionTypeAggregate, error) {
+	return tru.TutorReviewStore.GetTutorReviewsAggregateBySessionType(tru.DB, ClientID)
+}
+
+func (tru *TutorReviewUseCase) GetTutorReviewsAggregateByPeriod(
+	ClientID string,
+	period string,
+	since null.Time,
+) (*[]rfrl.TutorReviewPeriodAggregate, error) {
+	if !rfrl.IsValidReviewPeriod(period) {
+		return nil, rfrl.ErrInvalidReviewPeriod
+	}
+
+	return tru.TutorReviewStore.GetTutorReviewsAggregateByPeriod(tru.DB, ClientID, period, since)
+}
+
 func (tru *TutorReviewUseCase) GetPendingReviews(ClientID string) (*[]rfrl.PendingTutorReview, error) {
 	return tru.TutorReviewStore.GetPendingReviews(tru.DB, ClientID)
 }
 
-func (tru *TutorReviewUseCase) CreatePendingReview(menteeID string, tutorID string) error {
-	alreadyExist, err := tru.TutorReviewStore.CheckIfReviewAlreadyExists(tru.DB, menteeID, tutorID)
+func (tru *TutorReviewUseCase) CreatePendingReview(menteeID string, tutorID string, sessionID int) error {
+	alreadyExist, err := tru.TutorReviewStore.CheckIfReviewAlreadyExists(tru.DB, menteeID, sessionID)
 
 	if err != nil {
 		return err
@@ -160,9 +258,9 @@ func (tru *TutorReviewUseCase) CreatePendingReview(menteeID string, tutorID stri
 		return nil
 	}
 
-	return tru.TutorReviewStore.CreatePendingReview(tru.DB, menteeID, tutorID)
+	return tru.TutorReviewStore.CreatePendingReview(tru.DB, menteeID, tutorID, sessionID)
 }
 
-func (tru *TutorReviewUseCase) DeletePendingReview(menteeID string, tutorID string) error {
-	return tru.TutorReviewStore.DeletePendingReview(tru.DB, menteeID, tutorID)
+func (tru *TutorReviewUseCase) DeletePendingReview(menteeID string, sessionID int) error {
+	return tru.TutorReviewStore.DeletePendingReview(tru.DB, menteeID, sessionID)
 }
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"database/sql"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// FlagTutorReview sends a visible review to the moderation queue
+func (tru *TutorReviewUseCase) FlagTutorReview(clientID string, ID int, reason string) (*rfrl.TutorReviewFlag, error) {
+	review, err := tru.TutorReviewStore.GetTutorReview(tru.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review.HiddenAt.Valid {
+		return nil, sql.ErrNoRows
+	}
+
+	flagged, err := tru.TutorReviewStore.CheckOpenTutorReviewFlag(tru.DB, ID, clientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if flagged {
+		return nil, rfrl.ErrTutorReviewAlreadyFlagged
+	}
+
+	return tru.TutorReviewStore.CreateTutorReviewFlag(tru.DB, rfrl.NewTutorReviewFlag(ID, clientID, reason))
+}
+
+// GetFlaggedTutorReviews is the moderation queue, every review with open flags
+func (tru *TutorReviewUseCase) GetFlaggedTutorReviews(page rfrl.PageOptions) (*[]rfrl.FlaggedTutorReview, *rfrl.Cursor, error) {
+	tutorReviews, next, err := tru.TutorReviewStore.GetFlaggedTutorReviews(tru.DB, page)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tru.addReviewDetails(tutorReviews)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flaggedReviews := make([]rfrl.FlaggedTutorReview, len(*tutorReviews))
+
+	if len(*tutorReviews) == 0 {
+		return &flaggedReviews, next, nil
+	}
+
+	reviewIDToIndex := make(map[int]int)
+	reviewIDs := make([]int, len(*tutorReviews))
+
+	for i, review := range *tutorReviews {
+		flaggedReviews[i] = rfrl.FlaggedTutorReview{Review: review, Flags: make([]rfrl.TutorReviewFlag, 0)}
+		reviewIDToIndex[review.ID] = i
+		reviewIDs[i] = review.ID
+	}
+
+	flags, err := tru.TutorReviewStore.GetOpenTutorReviewFlags(tru.DB, reviewIDs)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, flag := range *flags {
+		index := reviewIDToIndex[flag.ReviewID]
+		flaggedReviews[index].Flags = append(flaggedReviews[index].Flags, flag)
+	}
+
+	return &flaggedReviews, next, nil
+}
+
+// ModerateTutorReview hides or restores a review and resolves its open flags
+func (tru *TutorReviewUseCase) ModerateTutorReview(adminID string, ID int, action string) (*rfrl.TutorReview, error) {
+	flagState, moderationErr := rfrl.ModerationStateFor(action)
+
+	if moderationErr != nil {
+		return nil, moderationErr
+	}
+
+	var err = new(error)
+	var tx *sqlx.Tx
+
+	tx, *err = tru.DB.Beginx()
+
+	if *err != nil {
+		return nil, errors.Wrap(*err, "ModerateTutorReview")
+	}
+
+	defer rfrl.HandleTransactions(tx, err)
+
+	_, *err = tru.TutorReviewStore.GetTutorReviewForUpdate(tx, ID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	var review *rfrl.TutorReview
+	review, *err = tru.TutorReviewStore.SetTutorReviewHidden(tx, ID, action == rfrl.REVIEW_MODERATION_HIDE, adminID)
+
+	if *err != nil {
+		return nil, *err
+	}
+
+	*err = tru.TutorReviewStore.ResolveTutorReviewFlags(tx, ID, flagState, adminID)
+
+	return review, *err
+}
+
+// ReplyToTutorReview creates or edits the reply of the reviewed tutor
+func (tru *TutorReviewUseCase) ReplyToTutorReview(clientID string, ID int, reply string) (*rfrl.TutorReviewReply, error) {
+	review, err := tru.TutorReviewStore.GetTutorReview(tru.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review.HiddenAt.Valid {
+		return nil, sql.ErrNoRows
+	}
+
+	if review.TutorID != clientID {
+		return nil, rfrl.ErrNotReviewedTutor
+	}
+
+	return tru.TutorReviewStore.UpsertTutorReviewReply(tru.DB, ID, clientID, reply)
+}
+
+func (tru *TutorReviewUseCase) DeleteTutorReviewReply(clientID string, ID int) error {
+	review, err := tru.TutorReviewStore.GetTutorReview(tru.DB, ID)
+
+	if err != nil {
+		return err
+	}
+
+	if review.TutorID != clientID {
+		return rfrl.ErrNotReviewedTutor
+	}
+
+	return tru.TutorReviewStore.DeleteTutorReviewReply(tru.DB, ID)
+}
+
+// GetTutorReviewRevisions shows how a review was edited to admins and to the author and tutor of the review
+func (tru *TutorReviewUseCase) GetTutorReviewRevisions(viewer rfrl.Viewer, ID int) (*[]rfrl.TutorReviewRevision, error) {
+	review, err := tru.TutorReviewStore.GetTutorReview(tru.DB, ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !viewer.Admin && viewer.ClientID != review.FromID && viewer.ClientID != review.TutorID {
+		return nil, rfrl.ErrTutorReviewNotForClient
+	}
+
+	return tru.TutorReviewStore.GetTutorReviewRevisions(tru.DB, ID)
+}
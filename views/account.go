@@ -0,0 +1,111 @@
+package views
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type (
+	// ProcessAccountDeletionsResponse is the response of /account-deletion/process
+	ProcessAccountDeletionsResponse struct {
+		Deleted int `json:"deleted"`
+	}
+)
+
+type AccountView struct {
+	AccountUseCase rfrl.AccountUseCase
+}
+
+func accountHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Account deletion is not found").SetInternal(err)
+	case rfrl.ErrAccountDeletionScheduled, rfrl.ErrAccountDeleted:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+// ExportAccountEndpoint sends the client's data as a JSON file
+func (av *AccountView) ExportAccountEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	export, err := av.AccountUseCase.ExportAccount(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"rfrl-export-%s.json\"", claims.ClientID))
+
+	return c.JSON(http.StatusOK, export)
+}
+
+func (av *AccountView) GetAccountDeletionEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	deletion, err := av.AccountUseCase.GetAccountDeletion(claims.ClientID)
+
+	if err != nil {
+		return accountHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, deletion)
+}
+
+func (av *AccountView) ScheduleAccountDeletionEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	deletion, err := av.AccountUseCase.ScheduleAccountDeletion(claims.ClientID)
+
+	if err != nil {
+		return accountHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, deletion)
+}
+
+func (av *AccountView) CancelAccountDeletionEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = av.AccountUseCase.CancelAccountDeletion(claims.ClientID)
+
+	if err != nil {
+		return accountHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ProcessAccountDeletionsEndpoint is called on a schedule to delete the accounts whose grace period is over
+func (av *AccountView) ProcessAccountDeletionsEndpoint(c echo.Context) error {
+	deleted, err := av.AccountUseCase.ProcessAccountDeletions()
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ProcessAccountDeletionsResponse{Deleted: deleted})
+}
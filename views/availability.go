@@ -0,0 +1,246 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// AvailabilityWindowPayload holds a weekly window with times formatted as HH:MM
+	AvailabilityWindowPayload struct {
+		DayOfWeek int    `json:"dayOfWeek" validate:"gte=0,lte=6"`
+		Start     string `json:"start" validate:"required"`
+		End       string `json:"end" validate:"required"`
+	}
+
+	// TutorAvailabilityPayload is the struct used to hold payload from /tutor-availability
+	TutorAvailabilityPayload struct {
+		Timezone            string                      `json:"timezone"`
+		SlotMinutes         int                         `json:"slotMinutes" validate:"gte=15,lte=240"`
+		BufferBeforeMinutes int                         `json:"bufferBeforeMinutes" validate:"gte=0,lte=120"`
+		BufferAfterMinutes  int                         `json:"bufferAfterMinutes" validate:"gte=0,lte=120"`
+		MaxSessionsPerDay   null.Int                    `json:"maxSessionsPerDay"`
+		Windows             []AvailabilityWindowPayload `json:"windows" validate:"dive"`
+	}
+
+	// GetBookableSlotsPayload is the struct used to hold payload from /tutor-availability/:tutorID/slots
+	GetBookableSlotsPayload struct {
+		TutorID string `path:"tutorID"`
+		Start   string `query:"start" validate:"required"`
+		End     string `query:"end" validate:"required"`
+	}
+
+	// BookTutorSlotPayload is the struct used to hold payload from /tutor-availability/:tutorID/book
+	BookTutorSlotPayload struct {
+		TutorID string `path:"tutorID"`
+		RoomID  string `json:"roomId" validate:"required,lte=40"`
+		Start   string `json:"start" validate:"required"`
+		Title   string `json:"title" validate:"omitempty,lte=40"`
+	}
+)
+
+type AvailabilityView struct {
+	AvailabilityUseCase rfrl.AvailabilityUseCase
+	PrivacyUseCase      rfrl.PrivacyUseCase
+}
+
+// parseMinuteOfDay converts HH:MM into minutes from midnight, allowing 24:00 as the end of day
+func parseMinuteOfDay(value string) (int, error) {
+	parts := strings.Split(value, ":")
+
+	if len(parts) != 2 {
+		return 0, errors.Errorf("Time (%s) has to be formatted as HH:MM", value)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return 0, errors.Wrap(err, "parseMinuteOfDay")
+	}
+
+	minutes, err := strconv.Atoi(parts[1])
+
+	if err != nil {
+		return 0, errors.Wrap(err, "parseMinuteOfDay")
+	}
+
+	if hours < 0 || hours > 24 || minutes < 0 || minutes > 59 || (hours == 24 && minutes != 0) {
+		return 0, errors.Errorf("Time (%s) is not a valid time of day", value)
+	}
+
+	return hours*60 + minutes, nil
+}
+
+func (av *AvailabilityView) SetTutorAvailabilityEndpoint(c echo.Context) error {
+	payload := TutorAvailabilityPayload{SlotMinutes: 60}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SetTutorAvailabilityEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SetTutorAvailabilityEndpoint - Validate"))
+	}
+
+	if payload.MaxSessionsPerDay.Valid && payload.MaxSessionsPerDay.Int64 < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Max sessions per day has to be at least 1")
+	}
+
+	if payload.Timezone != "" {
+		if err := rfrl.ValidateTimezone(payload.Timezone); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SetTutorAvailabilityEndpoint - ValidateTimezone"))
+		}
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	windows := make([]rfrl.AvailabilityWindow, len(payload.Windows))
+
+	for i := 0; i < len(payload.Windows); i++ {
+		start, err := parseMinuteOfDay(payload.Windows[i].Start)
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+
+		end, err := parseMinuteOfDay(payload.Windows[i].End)
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+
+		window, err := rfrl.NewAvailabilityWindow(payload.Windows[i].DayOfWeek, start, end)
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+
+		windows[i] = *window
+	}
+
+	availability, err := av.AvailabilityUseCase.SetTutorAvailability(
+		claims.ClientID,
+		*rfrl.NewTutorAvailability(
+			claims.ClientID,
+			payload.Timezone,
+			payload.SlotMinutes,
+			payload.BufferBeforeMinutes,
+			payload.BufferAfterMinutes,
+			payload.MaxSessionsPerDay,
+		),
+		windows,
+	)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, availability)
+}
+
+func (av *AvailabilityView) GetTutorAvailabilityEndpoint(c echo.Context) error {
+	tutorID := c.Param("tutorID")
+
+	availability, err := av.AvailabilityUseCase.GetTutorAvailability(tutorID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, availability)
+}
+
+func (av *AvailabilityView) GetBookableSlotsEndpoint(c echo.Context) error {
+	payload := GetBookableSlotsPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetBookableSlotsEndpoint - Bind"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetBookableSlotsEndpoint - time.Parse"))
+	}
+
+	end, err := time.Parse(time.RFC3339, payload.End)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetBookableSlotsEndpoint - time.Parse"))
+	}
+
+	if !end.After(start) {
+		return echo.NewHTTPError(http.StatusBadRequest, "End has to be after start")
+	}
+
+	if end.Sub(start) > rfrl.MaxSlotSearchDays*24*time.Hour {
+		return echo.NewHTTPError(http.StatusBadRequest, "Slots can only be searched a month at a time")
+	}
+
+	slots, err := av.AvailabilityUseCase.GetBookableSlots(payload.TutorID, claims.ClientID, start, end)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, slots)
+}
+
+func (av *AvailabilityView) BookTutorSlotEndpoint(c echo.Context) error {
+	payload := BookTutorSlotPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "BookTutorSlotEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "BookTutorSlotEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "BookTutorSlotEndpoint - time.Parse"))
+	}
+
+	session, err := av.AvailabilityUseCase.BookTutorSlot(
+		claims.ClientID,
+		payload.TutorID,
+		payload.RoomID,
+		start,
+		payload.Title,
+	)
+
+	if errors.Cause(err) == rfrl.ErrSlotNotAvailable {
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	}
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return sessionsResponse(c, av.PrivacyUseCase, http.StatusCreated, session, session)
+}
@@ -0,0 +1,103 @@
+package views
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type (
+	// CalendarFeedResponse is the feed along with the url calendar apps subscribe to
+	CalendarFeedResponse struct {
+		rfrl.CalendarFeed
+		URL string `json:"url"`
+	}
+)
+
+type CalendarView struct {
+	CalendarUseCase rfrl.CalendarUseCase
+}
+
+func calendarFeedResponse(c echo.Context, feed *rfrl.CalendarFeed) CalendarFeedResponse {
+	return CalendarFeedResponse{
+		CalendarFeed: *feed,
+		URL:          fmt.Sprintf("%s://%s/calendar/%s/feed.ics", c.Scheme(), c.Request().Host, feed.Secret),
+	}
+}
+
+func (cv *CalendarView) GetCalendarFeedEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feed, err := cv.CalendarUseCase.GetCalendarFeed(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, calendarFeedResponse(c, feed))
+}
+
+func (cv *CalendarView) RotateCalendarFeedEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feed, err := cv.CalendarUseCase.RotateCalendarFeed(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, calendarFeedResponse(c, feed))
+}
+
+func (cv *CalendarView) GetCalendarFeedICSEndpoint(c echo.Context) error {
+	calendar, err := cv.CalendarUseCase.GetCalendarFeedICS(c.Param("secret"))
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		return echo.NewHTTPError(http.StatusNotFound, "Calendar feed is not found")
+	}
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.Blob(http.StatusOK, calendarContentType, []byte(calendar))
+}
+
+func (cv *CalendarView) GetSessionICSEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetSessionICSEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	calendar, err := cv.CalendarUseCase.GetSessionICS(claims.ClientID, ID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"session-%d.ics\"", ID))
+
+	return c.Blob(http.StatusOK, calendarContentType, []byte(calendar))
+}
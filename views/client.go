@@ -17,26 +17,17 @@ import (
 type (
 	// ClientPayload is the struct used to hold payload from /client
 	ClientPayload struct {
-		ID                string    `path:"id"`
-		Email             string    `json:"email" validate:"omitempty,email"`
-		FirstName         string    `json:"firstName"`
-		LastName          string    `json:"lastName"`
-		Photo             string    `json:"photo"`
-		About             string    `json:"about"`
-		IsTutor           null.Bool `json:"isTutor"`
-		LinkedInProfile   string    `json:"linkedInProfile"`
-		GithubProfile     string    `json:"githubProfile"`
-		YearsOfExperience null.Int  `json:"yearsOfExperience"`
-		WorkTitle         string    `json:"workTitle"`
-	}
-
-	// EducationPayload is the struct used to create education
-	EducationPayload struct {
-		Institution  string `json:"institution"`
-		Degree       string `json:"degree"`
-		FieldOfStudy string `json:"fieldOfStudy"`
-		StartYear    int    `json:"startYear"`
-		EndYear      int    `json:"endYear"`
+		ID              string    `path:"id"`
+		Email           string    `json:"email" validate:"omitempty,email"`
+		FirstName       string    `json:"firstName"`
+		LastName        string    `json:"lastName"`
+		Photo           string    `json:"photo"`
+		About           string    `json:"about"`
+		IsTutor         null.Bool `json:"isTutor"`
+		LinkedInProfile string    `json:"linkedInProfile"`
+		GithubProfile   string    `json:"githubProfile"`
+		WorkTitle       string    `json:"workTitle"`
+		Timezone        string    `json:"timezone"`
 	}
 
 	// VerifyEmailPayload is the struct used to verify email
@@ -50,24 +41,52 @@ type (
 		ClientID  string `path:"clientID"`
 		StartTime string `query:"start" validate:"omitempty, datetime"`
 		EndTime   string `query:"end" validate:"omitempty, datetime"`
-		State     string `query:"state" validate:"omitempty,oneof= scheduled pending"`
+		State     string `query:"state" validate:"omitempty,oneof= scheduled pending completed cancelled no_show"`
 	}
 
 	ClientCompanyReferralPayload struct {
 		CompanyIds           []int `json:"companyIds"`
+		JobPostingIds        []int `json:"jobPostingIds"`
 		IsLookingForReferral bool  `json:"isLookingForReferral"`
 	}
 
+	// ClientTagPayload is a tag a client declares with their proficiency in it
+	ClientTagPayload struct {
+		TagID       int    `json:"tagId" validate:"required"`
+		Proficiency string `json:"proficiency" validate:"required,oneof=beginner intermediate advanced expert"`
+	}
+
+	ClientTagsPayload struct {
+		Tags []ClientTagPayload `json:"tags" validate:"lte=30,dive"`
+	}
+
 	GetReferralCompanyResponse struct {
-		CompanyIds []int `json:"companyIds"`
+		CompanyIds    []int `json:"companyIds"`
+		JobPostingIds []int `json:"jobPostingIds"`
 	}
 
 	GetClientsEndpointPayload struct {
-		FromCompanyIds           []int       `query:"fromCompanyIds"`
-		IsTutor                  null.Bool   `query:"isTutor"`
-		WantingReferralCompanyId null.Int    `query:"wantingReferralCompanyId"`
-		LastTutor                null.String `query:"lastClient"`
-		IncludeSelf              null.Bool   `query:"includeSelf"`
+		FromCompanyIds              []int       `query:"fromCompanyIds"`
+		IsTutor                     null.Bool   `query:"isTutor"`
+		WantingReferralCompanyId    null.Int    `query:"wantingReferralCompanyId"`
+		WantingReferralJobPostingId null.Int    `query:"wantingReferralJobPostingId"`
+		TagIds                      []int       `query:"tagIds"`
+		MinProficiency              null.String `query:"minProficiency"`
+		IncludeSelf                 null.Bool   `query:"includeSelf"`
+	}
+
+	SearchClientsEndpointPayload struct {
+		Query                null.String `query:"q"`
+		IsTutor              null.Bool   `query:"isTutor"`
+		VerifiedWorkEmail    null.Bool   `query:"verifiedWorkEmail"`
+		CompanyIds           []int       `query:"companyIds"`
+		Institution          null.String `query:"institution"`
+		MinYearsOfExperience null.Int    `query:"minYearsOfExperience"`
+		MaxYearsOfExperience null.Int    `query:"maxYearsOfExperience"`
+		TagIds               []int       `query:"tagIds"`
+		MinProficiency       null.String `query:"minProficiency"`
+		Sort                 string      `query:"sort" validate:"omitempty,oneof=relevance rating newest"`
+		IncludeSelf          null.Bool   `query:"includeSelf"`
 	}
 )
 
@@ -87,7 +106,8 @@ func ClientPayloadValidation(sl validator.StructLevel) {
 }
 
 type ClientView struct {
-	ClientUseCase rfrl.ClientUseCase
+	ClientUseCase  rfrl.ClientUseCase
+	PrivacyUseCase rfrl.PrivacyUseCase
 }
 
 // CreateClientEndpoint view is an endpoint used to create client
@@ -142,17 +162,23 @@ func (cv *ClientView) UpdateClientEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "You cannot update this client")
 	}
 
+	if payload.Timezone != "" {
+		if err := rfrl.ValidateTimezone(payload.Timezone); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateClientEndpoint - ValidateTimezone"))
+		}
+	}
+
 	params := rfrl.UpdateClientPayload{
-		FirstName:         payload.FirstName,
-		LastName:          payload.LastName,
-		About:             payload.About,
-		Email:             payload.Email,
-		Photo:             payload.Photo,
-		IsTutor:           payload.IsTutor,
-		LinkedInProfile:   payload.LinkedInProfile,
-		GithubProfile:     payload.GithubProfile,
-		YearsOfExperience: payload.YearsOfExperience,
-		WorkTitle:         payload.WorkTitle,
+		FirstName:       payload.FirstName,
+		LastName:        payload.LastName,
+		About:           payload.About,
+		Email:           payload.Email,
+		Photo:           payload.Photo,
+		IsTutor:         payload.IsTutor,
+		LinkedInProfile: payload.LinkedInProfile,
+		GithubProfile:   payload.GithubProfile,
+		WorkTitle:       payload.WorkTitle,
+		Timezone:        payload.Timezone,
 	}
 
 	client, err := cv.ClientUseCase.UpdateClient(
@@ -176,6 +202,12 @@ func (cv *ClientView) UpdateClientEndpoint(c echo.Context) error {
 func (cv *ClientView) GetClientEndpoint(c echo.Context) error {
 	id := c.Param("id")
 
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
 	client, err := cv.ClientUseCase.GetClient(id)
 
 	if err != nil {
@@ -187,6 +219,12 @@ func (cv *ClientView) GetClientEndpoint(c echo.Context) error {
 		}
 	}
 
+	err = cv.PrivacyUseCase.HideClientFields(viewer, client)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
 	return c.JSON(http.StatusOK, client)
 }
 
@@ -197,7 +235,8 @@ func (cv *ClientView) GetClientsEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetClientsEndpoint - Bind"))
 	}
 
-	if payload.IsTutor.Valid && payload.IsTutor.Bool && payload.WantingReferralCompanyId.Valid {
+	if payload.IsTutor.Valid && payload.IsTutor.Bool &&
+		(payload.WantingReferralCompanyId.Valid || payload.WantingReferralJobPostingId.Valid) {
 		return echo.NewHTTPError(http.StatusBadRequest, "Cannot be looking for tutors wanting referrals")
 	}
 
@@ -205,6 +244,10 @@ func (cv *ClientView) GetClientsEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Cannot be looking for clients from certain companies")
 	}
 
+	if payload.MinProficiency.Valid && len(rfrl.ProficienciesFrom(payload.MinProficiency.String)) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Min proficiency is not a valid proficiency")
+	}
+
 	claims, err := rfrl.GetClaims(c)
 
 	if err != nil {
@@ -219,20 +262,118 @@ func (cv *ClientView) GetClientsEndpoint(c echo.Context) error {
 	}
 
 	options := rfrl.GetClientsOptions{
-		IsTutor:                  payload.IsTutor,
-		CompanyIds:               payload.FromCompanyIds,
-		WantingReferralCompanyId: payload.WantingReferralCompanyId,
-		LastTutor:                payload.LastTutor,
-		ExcludeClients:           excludeClients,
+		IsTutor:                     payload.IsTutor,
+		CompanyIds:                  payload.FromCompanyIds,
+		WantingReferralCompanyId:    payload.WantingReferralCompanyId,
+		WantingReferralJobPostingId: payload.WantingReferralJobPostingId,
+		TagIds:                      payload.TagIds,
+		MinProficiency:              payload.MinProficiency,
+		ExcludeClients:              excludeClients,
+		IncludeHiddenFromSearch:     claims.Admin,
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	clients, next, err := cv.ClientUseCase.GetClients(options, page)
+
+	if err != nil {
+		return pageHTTPError(err)
 	}
 
-	clients, err := cv.ClientUseCase.GetClients(options)
+	visible := make([]*rfrl.Client, len(*clients))
+
+	for i := range *clients {
+		visible[i] = &(*clients)[i]
+	}
+
+	err = cv.PrivacyUseCase.HideClientFields(rfrl.Viewer{ClientID: claims.ClientID, Admin: claims.Admin}, visible...)
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, clients)
+	return c.JSON(http.StatusOK, rfrl.NewPage(clients, next))
+}
+
+func (cv *ClientView) SearchClientsEndpoint(c echo.Context) error {
+	payload := SearchClientsEndpointPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SearchClientsEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SearchClientsEndpoint - Validate"))
+	}
+
+	if payload.MinProficiency.Valid && len(rfrl.ProficienciesFrom(payload.MinProficiency.String)) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Min proficiency is not a valid proficiency")
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	// Exclude Support
+	excludeClients := []string{"af496484-7c7c-45a8-a409-96d61351f43a"}
+
+	if !payload.IncludeSelf.Valid || !payload.IncludeSelf.Bool {
+		excludeClients = append(excludeClients, claims.ClientID)
+	}
+
+	// Without a query there is nothing to rank by relevance
+	sort := payload.Sort
+
+	if sort == "" && payload.Query.Valid && payload.Query.String != "" {
+		sort = rfrl.SEARCH_SORT_RELEVANCE
+	} else if sort == "" {
+		sort = rfrl.SEARCH_SORT_NEWEST
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	results, next, err := cv.ClientUseCase.SearchClients(rfrl.SearchClientsOptions{
+		Query:                   payload.Query,
+		IsTutor:                 payload.IsTutor,
+		VerifiedWorkEmail:       payload.VerifiedWorkEmail,
+		CompanyIds:              payload.CompanyIds,
+		Institution:             payload.Institution,
+		MinYearsOfExperience:    payload.MinYearsOfExperience,
+		MaxYearsOfExperience:    payload.MaxYearsOfExperience,
+		TagIds:                  payload.TagIds,
+		MinProficiency:          payload.MinProficiency,
+		Sort:                    sort,
+		ExcludeClients:          excludeClients,
+		IncludeHiddenFromSearch: claims.Admin,
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	visible := make([]*rfrl.Client, len(*results))
+
+	for i := range *results {
+		visible[i] = &(*results)[i].Client
+	}
+
+	err = cv.PrivacyUseCase.HideClientFields(rfrl.Viewer{ClientID: claims.ClientID, Admin: claims.Admin}, visible...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(results, next))
 }
 
 func (cv *ClientView) VerifyEmail(c echo.Context) error {
@@ -375,18 +516,24 @@ func (cv *ClientView) GetClientEventsEndpoint(c echo.Context) error {
 
 	var state null.String
 	if payload.State == "" {
-		state = null.NewString(rfrl.SCHEDULED, true)
+		state = null.NewString(string(rfrl.SCHEDULED), true)
 	} else {
 		state = null.NewString(payload.State, true)
 	}
 
-	events, err := cv.ClientUseCase.GetClientEvents(payload.ClientID, start, end, state)
+	page, err := getPageOptions(c)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, err.Error()).SetInternal(err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, *events)
+	events, next, err := cv.ClientUseCase.GetClientEvents(payload.ClientID, start, end, state, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(events, next))
 }
 
 func (cv *ClientView) DeleteVerifyEmail(c echo.Context) error {
@@ -443,8 +590,17 @@ func (cv *ClientView) GetWantingReferralCompany(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, err.Error()).SetInternal(err)
 	}
 
+	jobPostings, err := cv.ClientUseCase.GetClientWantingJobPostingReferrals(
+		clientID,
+	)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error()).SetInternal(err)
+	}
+
 	response := GetReferralCompanyResponse{
-		CompanyIds: companies,
+		CompanyIds:    companies,
+		JobPostingIds: jobPostings,
 	}
 	return c.JSON(http.StatusOK, response)
 }
@@ -472,6 +628,7 @@ func (cv *ClientView) CreateWantingReferralCompany(c echo.Context) error {
 		clientID,
 		payload.IsLookingForReferral,
 		payload.CompanyIds,
+		payload.JobPostingIds,
 	)
 
 	if err != nil {
@@ -481,7 +638,18 @@ func (cv *ClientView) CreateWantingReferralCompany(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
-func (cv *ClientView) CreateEducation(c echo.Context) error {
+func (cv *ClientView) GetClientTagsEndpoint(c echo.Context) error {
+	tags, err := cv.ClientUseCase.GetClientTags(c.Param("clientID"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// SetClientTagsEndpoint replaces the tags a client declared expertise in
+func (cv *ClientView) SetClientTagsEndpoint(c echo.Context) error {
 	claims, err := rfrl.GetClaims(c)
 
 	if err != nil {
@@ -494,37 +662,32 @@ func (cv *ClientView) CreateEducation(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to edit this client")
 	}
 
-	payload := EducationPayload{}
+	payload := ClientTagsPayload{}
 
 	if err := c.Bind(&payload); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateEducation - Bind"))
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SetClientTagsEndpoint - Bind"))
 	}
 
-	if payload.StartYear < 1950 || payload.StartYear > time.Now().Year()+5 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Wrong StartYear")
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SetClientTagsEndpoint - Validate"))
 	}
 
-	if payload.EndYear < 1950 || payload.StartYear > time.Now().Year()+10 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Wrong EndYear")
-	}
-
-	err = cv.ClientUseCase.CreateOrUpdateClientEducation(
-		clientID,
-		payload.Institution,
-		payload.Degree,
-		payload.FieldOfStudy,
-		payload.StartYear,
-		payload.EndYear,
-	)
+	tags := make([]rfrl.ClientTag, 0)
+	seen := make(map[int]bool)
 
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	for _, tag := range payload.Tags {
+		if seen[tag.TagID] {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Tag %d is repeated", tag.TagID))
+		}
+		seen[tag.TagID] = true
+		tags = append(tags, rfrl.NewClientTag(tag.TagID, tag.Proficiency))
 	}
 
-	client, err := cv.ClientUseCase.GetClient(clientID)
+	clientTags, err := cv.ClientUseCase.SetClientTags(clientID, tags)
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
-	return c.JSON(http.StatusOK, client)
+
+	return c.JSON(http.StatusOK, clientTags)
 }
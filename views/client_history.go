@@ -0,0 +1,312 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// EducationPayload is the struct used to hold payload from /client/:clientID/education
+	EducationPayload struct {
+		ClientID     string `path:"clientID"`
+		ID           int    `path:"id"`
+		Institution  string `json:"institution" validate:"required,lte=100"`
+		Degree       string `json:"degree" validate:"lte=100"`
+		FieldOfStudy string `json:"fieldOfStudy" validate:"lte=100"`
+		StartYear    int    `json:"startYear"`
+		EndYear      int    `json:"endYear"`
+	}
+
+	// WorkPositionPayload is the struct used to hold payload from /client/:clientID/work-position
+	WorkPositionPayload struct {
+		ClientID    string   `path:"clientID"`
+		ID          int      `path:"id"`
+		CompanyID   null.Int `json:"companyId"`
+		CompanyName string   `json:"companyName" validate:"lte=100"`
+		Title       string   `json:"title" validate:"required,lte=100"`
+		StartDate   string   `json:"startDate" validate:"required"`
+		EndDate     string   `json:"endDate"`
+		Description string   `json:"description" validate:"lte=2000"`
+	}
+)
+
+// canEditClient lets clients edit their own profile, admins can edit every profile
+func canEditClient(c echo.Context, clientID string) *echo.HTTPError {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if claims.ClientID != clientID && !claims.Admin {
+		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to edit this client")
+	}
+
+	return nil
+}
+
+func clientHistoryHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Entry is not found").SetInternal(err)
+	case rfrl.ErrWorkPositionCompanyNotFound:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+// educationFromPayload checks the years, 0 is used when a year is unknown
+func educationFromPayload(payload EducationPayload) (*rfrl.Education, error) {
+	if payload.StartYear != 0 && (payload.StartYear < 1950 || payload.StartYear > time.Now().Year()+5) {
+		return nil, errors.New("Wrong StartYear")
+	}
+
+	if payload.EndYear != 0 && (payload.EndYear < 1950 || payload.EndYear > time.Now().Year()+10) {
+		return nil, errors.New("Wrong EndYear")
+	}
+
+	if payload.StartYear != 0 && payload.EndYear != 0 && payload.EndYear < payload.StartYear {
+		return nil, errors.New("EndYear is before StartYear")
+	}
+
+	education := rfrl.NewEducation(
+		payload.ClientID,
+		payload.Institution,
+		payload.Degree,
+		payload.FieldOfStudy,
+		payload.StartYear,
+		payload.EndYear,
+	)
+	education.ID = payload.ID
+
+	return education, nil
+}
+
+// workPositionFromPayload parses the dates, positions without an end date are current
+func workPositionFromPayload(payload WorkPositionPayload) (*rfrl.WorkPosition, error) {
+	if !payload.CompanyID.Valid && payload.CompanyName == "" {
+		return nil, errors.New("Company is required")
+	}
+
+	startDate, err := time.Parse(outcomeDateLayout, payload.StartDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	endDate := null.Time{}
+
+	if payload.EndDate != "" {
+		end, err := time.Parse(outcomeDateLayout, payload.EndDate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if end.Before(startDate) {
+			return nil, errors.New("End date is before start date")
+		}
+
+		endDate = null.TimeFrom(end)
+	}
+
+	position := rfrl.NewWorkPosition(
+		payload.ClientID,
+		payload.CompanyID,
+		payload.CompanyName,
+		payload.Title,
+		startDate,
+		endDate,
+		payload.Description,
+	)
+	position.ID = payload.ID
+
+	return position, nil
+}
+
+func (cv *ClientView) GetEducationsEndpoint(c echo.Context) error {
+	educations, err := cv.ClientUseCase.GetEducations(c.Param("clientID"))
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, educations)
+}
+
+func (cv *ClientView) CreateEducationEndpoint(c echo.Context) error {
+	payload := EducationPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateEducationEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateEducationEndpoint - Validate"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	education, err := educationFromPayload(payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateEducationEndpoint - Parse"))
+	}
+
+	education, err = cv.ClientUseCase.CreateEducation(education)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, education)
+}
+
+func (cv *ClientView) UpdateEducationEndpoint(c echo.Context) error {
+	payload := EducationPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateEducationEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateEducationEndpoint - Validate"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	education, err := educationFromPayload(payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateEducationEndpoint - Parse"))
+	}
+
+	education, err = cv.ClientUseCase.UpdateEducation(education)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, education)
+}
+
+func (cv *ClientView) DeleteEducationEndpoint(c echo.Context) error {
+	payload := EducationPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteEducationEndpoint - Bind"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	err := cv.ClientUseCase.DeleteEducation(payload.ClientID, payload.ID)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (cv *ClientView) GetWorkPositionsEndpoint(c echo.Context) error {
+	positions, err := cv.ClientUseCase.GetWorkPositions(c.Param("clientID"))
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, positions)
+}
+
+func (cv *ClientView) CreateWorkPositionEndpoint(c echo.Context) error {
+	payload := WorkPositionPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateWorkPositionEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateWorkPositionEndpoint - Validate"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	position, err := workPositionFromPayload(payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateWorkPositionEndpoint - Parse"))
+	}
+
+	position, err = cv.ClientUseCase.CreateWorkPosition(position)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, position)
+}
+
+func (cv *ClientView) UpdateWorkPositionEndpoint(c echo.Context) error {
+	payload := WorkPositionPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateWorkPositionEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateWorkPositionEndpoint - Validate"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	position, err := workPositionFromPayload(payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateWorkPositionEndpoint - Parse"))
+	}
+
+	position, err = cv.ClientUseCase.UpdateWorkPosition(position)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, position)
+}
+
+func (cv *ClientView) DeleteWorkPositionEndpoint(c echo.Context) error {
+	payload := WorkPositionPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteWorkPositionEndpoint - Bind"))
+	}
+
+	if err := canEditClient(c, payload.ClientID); err != nil {
+		return err
+	}
+
+	err := cv.ClientUseCase.DeleteWorkPosition(payload.ClientID, payload.ID)
+
+	if err != nil {
+		return clientHistoryHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
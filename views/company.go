@@ -146,13 +146,19 @@ func (comv *CompanyView) GetCompanyEmailsView(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetCompanyEmailsView - UnmarshalText"))
 	}
 
-	companyEmails, err := comv.CompanyUseCase.GetCompanyEmails(withCompany)
+	page, err := getPageOptions(c)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	companyEmails, next, err := comv.CompanyUseCase.GetCompanyEmails(withCompany, page)
+
+	if err != nil {
+		return pageHTTPError(err)
 	}
 
-	return c.JSON(http.StatusOK, companyEmails)
+	return c.JSON(http.StatusOK, rfrl.NewPage(companyEmails, next))
 }
 
 func (comv *CompanyView) UpdateCompanyEmailView(c echo.Context) error {
@@ -207,13 +213,19 @@ func (comv *CompanyView) GetCompanies(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Cannot filter for non active companies")
 	}
 
-	companies, err := comv.CompanyUseCase.GetCompanies(active.Bool)
+	page, err := getPageOptions(c)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	companies, next, err := comv.CompanyUseCase.GetCompanies(active.Bool, page)
+
+	if err != nil {
+		return pageHTTPError(err)
 	}
 
-	return c.JSON(http.StatusOK, companies)
+	return c.JSON(http.StatusOK, rfrl.NewPage(companies, next))
 }
 
 func (comv *CompanyView) GetCompanyEmailView(c echo.Context) error {
@@ -0,0 +1,141 @@
+package views
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type (
+	// ImportExternalCalendarPayload is the struct used to hold payload from /external-calendar,
+	// the calendar is either sent as a multipart file or linked through url
+	ImportExternalCalendarPayload struct {
+		Name string `json:"name" form:"name" validate:"required,lte=100"`
+		URL  string `json:"url" form:"url" validate:"omitempty,url"`
+	}
+)
+
+type ExternalCalendarView struct {
+	ExternalCalendarUseCase rfrl.ExternalCalendarUseCase
+}
+
+func externalCalendarHTTPError(err error) error {
+	if errors.Cause(err) == sql.ErrNoRows {
+		return echo.NewHTTPError(http.StatusNotFound, "External calendar is not found").SetInternal(err)
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+}
+
+func (ecv *ExternalCalendarView) ImportExternalCalendarEndpoint(c echo.Context) error {
+	payload := ImportExternalCalendarPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ImportExternalCalendarEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ImportExternalCalendarEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	var file io.Reader
+
+	fileHeader, err := c.FormFile("file")
+
+	switch {
+	case err == nil:
+		if fileHeader.Size > rfrl.MaxExternalCalendarSize {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Calendar file is too large")
+		}
+
+		opened, err := fileHeader.Open()
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ImportExternalCalendarEndpoint - Open"))
+		}
+
+		defer opened.Close()
+		file = opened
+	case payload.URL == "":
+		return echo.NewHTTPError(http.StatusBadRequest, "Either a calendar file or a calendar url is required")
+	}
+
+	calendar, err := ecv.ExternalCalendarUseCase.ImportExternalCalendar(claims.ClientID, payload.Name, payload.URL, file)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, calendar)
+}
+
+func (ecv *ExternalCalendarView) GetExternalCalendarsEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	calendars, err := ecv.ExternalCalendarUseCase.GetExternalCalendars(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, calendars)
+}
+
+func (ecv *ExternalCalendarView) SyncExternalCalendarEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SyncExternalCalendarEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	calendar, err := ecv.ExternalCalendarUseCase.SyncExternalCalendar(claims.ClientID, ID)
+
+	if err != nil {
+		return externalCalendarHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, calendar)
+}
+
+func (ecv *ExternalCalendarView) DeleteExternalCalendarEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteExternalCalendarEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = ecv.ExternalCalendarUseCase.DeleteExternalCalendar(claims.ClientID, ID)
+
+	if err != nil {
+		return externalCalendarHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
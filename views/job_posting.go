@@ -0,0 +1,214 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// JobPostingPayload is the struct used to hold payload from /job-posting
+	JobPostingPayload struct {
+		ID        int    `path:"id"`
+		CompanyID int    `json:"companyId"`
+		Title     string `json:"title" validate:"required,lte=200"`
+		Location  string `json:"location" validate:"omitempty,lte=200"`
+		Remote    bool   `json:"remote"`
+		Level     string `json:"level" validate:"omitempty,oneof=intern entry mid senior staff principal manager director executive"`
+		URL       string `json:"url" validate:"required,url,startswith=http://|startswith=https://"`
+		ExpiresOn string `json:"expiresOn" validate:"required"`
+	}
+
+	// GetJobPostingsPayload is the struct used to hold payload from GET /job-posting
+	GetJobPostingsPayload struct {
+		CompanyIds     []int       `query:"companyIds"`
+		Title          null.String `query:"title"`
+		Location       null.String `query:"location"`
+		Remote         null.Bool   `query:"remote"`
+		Level          null.String `query:"level"`
+		IncludeExpired bool        `query:"includeExpired"`
+	}
+)
+
+type JobPostingView struct {
+	JobPostingUseCase rfrl.JobPostingUseCase
+}
+
+func jobPostingHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Job posting is not found").SetInternal(err)
+	case rfrl.ErrJobPostingNotAllowed, rfrl.ErrJobPostingNotPoster:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+// jobPostingFromPayload parses the expiry day, postings cannot already be expired
+func jobPostingFromPayload(clientID string, payload JobPostingPayload) (*rfrl.JobPosting, error) {
+	expiresOn, err := time.Parse(outcomeDateLayout, payload.ExpiresOn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	posting := rfrl.NewJobPosting(
+		payload.CompanyID,
+		clientID,
+		payload.Title,
+		payload.Location,
+		payload.Remote,
+		payload.Level,
+		payload.URL,
+		expiresOn,
+	)
+	posting.ID = payload.ID
+
+	if posting.IsExpired(time.Now()) {
+		return nil, rfrl.ErrJobPostingExpired
+	}
+
+	return posting, nil
+}
+
+func (jpv *JobPostingView) CreateJobPostingEndpoint(c echo.Context) error {
+	payload := JobPostingPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateJobPostingEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateJobPostingEndpoint - Validate"))
+	}
+
+	if payload.CompanyID == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Company is required")
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	posting, err := jobPostingFromPayload(claims.ClientID, payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateJobPostingEndpoint - Parse"))
+	}
+
+	posting, err = jpv.JobPostingUseCase.CreateJobPosting(claims.ClientID, claims.Admin, posting)
+
+	if err != nil {
+		return jobPostingHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, posting)
+}
+
+func (jpv *JobPostingView) GetJobPostingsEndpoint(c echo.Context) error {
+	payload := GetJobPostingsPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetJobPostingsEndpoint - Bind"))
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	postings, next, err := jpv.JobPostingUseCase.GetJobPostings(rfrl.GetJobPostingsOptions{
+		CompanyIDs:     payload.CompanyIds,
+		Title:          payload.Title,
+		Location:       payload.Location,
+		Remote:         payload.Remote,
+		Level:          payload.Level,
+		IncludeExpired: payload.IncludeExpired,
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(postings, next))
+}
+
+func (jpv *JobPostingView) GetJobPostingEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetJobPostingEndpoint - Atoi"))
+	}
+
+	posting, err := jpv.JobPostingUseCase.GetJobPosting(ID)
+
+	if err != nil {
+		return jobPostingHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, posting)
+}
+
+func (jpv *JobPostingView) UpdateJobPostingEndpoint(c echo.Context) error {
+	payload := JobPostingPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateJobPostingEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateJobPostingEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	posting, err := jobPostingFromPayload(claims.ClientID, payload)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateJobPostingEndpoint - Parse"))
+	}
+
+	posting, err = jpv.JobPostingUseCase.UpdateJobPosting(claims.ClientID, claims.Admin, posting)
+
+	if err != nil {
+		return jobPostingHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, posting)
+}
+
+func (jpv *JobPostingView) DeleteJobPostingEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteJobPostingEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = jpv.JobPostingUseCase.DeleteJobPosting(claims.ClientID, claims.Admin, ID)
+
+	if err != nil {
+		return jobPostingHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
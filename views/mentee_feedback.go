@@ -0,0 +1,233 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// MenteeFeedbackPayload is the struct used to hold payload from POST /mentee-feedback
+	MenteeFeedbackPayload struct {
+		SessionID       int    `json:"sessionId" validate:"required"`
+		MenteeID        string `json:"menteeId" validate:"required,uuid"`
+		Communication   int    `json:"communication" validate:"required,gte=1,lte=5"`
+		ProblemSolving  int    `json:"problemSolving" validate:"required,gte=1,lte=5"`
+		Preparation     int    `json:"preparation" validate:"required,gte=1,lte=5"`
+		Notes           string `json:"notes" validate:"lte=2000"`
+		VisibleToMentee bool   `json:"visibleToMentee"`
+	}
+
+	// UpdateMenteeFeedbackPayload is the struct used to hold payload from PUT /mentee-feedback/:id
+	UpdateMenteeFeedbackPayload struct {
+		ID              int    `path:"id"`
+		Communication   int    `json:"communication" validate:"required,gte=1,lte=5"`
+		ProblemSolving  int    `json:"problemSolving" validate:"required,gte=1,lte=5"`
+		Preparation     int    `json:"preparation" validate:"required,gte=1,lte=5"`
+		Notes           string `json:"notes" validate:"lte=2000"`
+		VisibleToMentee bool   `json:"visibleToMentee"`
+	}
+
+	// GivenMenteeFeedbacksPayload is the struct used to hold payload from GET /mentee-feedback/given
+	GivenMenteeFeedbacksPayload struct {
+		MenteeID null.String `query:"menteeId"`
+	}
+)
+
+type MenteeFeedbackView struct {
+	MenteeFeedbackUseCase rfrl.MenteeFeedbackUseCase
+}
+
+func menteeFeedbackHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Mentee feedback is not found").SetInternal(err)
+	case rfrl.ErrMenteeFeedbackNotAllowed:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	case rfrl.ErrMenteeFeedbackExists:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+func (mfv *MenteeFeedbackView) CreateMenteeFeedbackEndpoint(c echo.Context) error {
+	payload := MenteeFeedbackPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateMenteeFeedbackEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateMenteeFeedbackEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if claims.ClientID == payload.MenteeID {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot give feedback to yourself")
+	}
+
+	feedback, err := mfv.MenteeFeedbackUseCase.CreateMenteeFeedback(rfrl.NewMenteeFeedback(
+		payload.SessionID,
+		claims.ClientID,
+		payload.MenteeID,
+		payload.Communication,
+		payload.ProblemSolving,
+		payload.Preparation,
+		payload.Notes,
+		payload.VisibleToMentee,
+	))
+
+	if err != nil {
+		return menteeFeedbackHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, feedback)
+}
+
+func (mfv *MenteeFeedbackView) UpdateMenteeFeedbackEndpoint(c echo.Context) error {
+	payload := UpdateMenteeFeedbackPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateMenteeFeedbackEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateMenteeFeedbackEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feedback := rfrl.MenteeFeedback{
+		ID:              payload.ID,
+		Communication:   payload.Communication,
+		ProblemSolving:  payload.ProblemSolving,
+		Preparation:     payload.Preparation,
+		Notes:           null.NewString(payload.Notes, payload.Notes != ""),
+		VisibleToMentee: payload.VisibleToMentee,
+	}
+
+	updated, err := mfv.MenteeFeedbackUseCase.UpdateMenteeFeedback(claims.ClientID, &feedback)
+
+	if err != nil {
+		return menteeFeedbackHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+func (mfv *MenteeFeedbackView) DeleteMenteeFeedbackEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteMenteeFeedbackEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = mfv.MenteeFeedbackUseCase.DeleteMenteeFeedback(claims.ClientID, ID)
+
+	if err != nil {
+		return menteeFeedbackHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (mfv *MenteeFeedbackView) GetMenteeFeedbackEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetMenteeFeedbackEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feedback, err := mfv.MenteeFeedbackUseCase.GetMenteeFeedback(claims.ClientID, ID)
+
+	if err != nil {
+		return menteeFeedbackHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, feedback)
+}
+
+// GetReceivedMenteeFeedbacksEndpoint is the history of feedback shared with the client
+func (mfv *MenteeFeedbackView) GetReceivedMenteeFeedbacksEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feedbacks, next, err := mfv.MenteeFeedbackUseCase.GetMenteeFeedbacks(rfrl.GetMenteeFeedbacksOptions{
+		MenteeID:    null.StringFrom(claims.ClientID),
+		VisibleOnly: true,
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(feedbacks, next))
+}
+
+// GetGivenMenteeFeedbacksEndpoint is the feedback the client gave as a tutor, optionally for one mentee
+func (mfv *MenteeFeedbackView) GetGivenMenteeFeedbacksEndpoint(c echo.Context) error {
+	payload := GivenMenteeFeedbacksPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetGivenMenteeFeedbacksEndpoint - Bind"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	feedbacks, next, err := mfv.MenteeFeedbackUseCase.GetMenteeFeedbacks(rfrl.GetMenteeFeedbacksOptions{
+		TutorID:  null.StringFrom(claims.ClientID),
+		MenteeID: payload.MenteeID,
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(feedbacks, next))
+}
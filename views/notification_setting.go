@@ -0,0 +1,64 @@
+package views
+
+import (
+	"net/http"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// NotificationSettingPayload is the struct used to hold payload from /notification-setting
+	NotificationSettingPayload struct {
+		ReferrerAvailable    null.Bool `json:"referrerAvailable"`
+		PendingSeekersDigest null.Bool `json:"pendingSeekersDigest"`
+	}
+)
+
+type NotificationSettingView struct {
+	ReferralNotificationUseCase rfrl.ReferralNotificationUseCase
+}
+
+func (nsv *NotificationSettingView) GetNotificationSettingEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	setting, err := nsv.ReferralNotificationUseCase.GetNotificationSetting(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
+
+func (nsv *NotificationSettingView) UpdateNotificationSettingEndpoint(c echo.Context) error {
+	payload := NotificationSettingPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateNotificationSettingEndpoint - Bind"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	setting, err := nsv.ReferralNotificationUseCase.UpdateNotificationSetting(
+		claims.ClientID,
+		payload.ReferrerAvailable,
+		payload.PendingSeekersDigest,
+	)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
@@ -0,0 +1,35 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// getPageOptions reads the cursor and pageSize query params shared by every list endpoint
+func getPageOptions(c echo.Context) (rfrl.PageOptions, error) {
+	size := 0
+
+	if pageSize := c.QueryParam("pageSize"); pageSize != "" {
+		var err error
+		size, err = strconv.Atoi(pageSize)
+
+		if err != nil {
+			return rfrl.PageOptions{}, errors.Wrap(err, "getPageOptions - Atoi")
+		}
+	}
+
+	return rfrl.NewPageOptions(c.QueryParam("cursor"), size)
+}
+
+// pageHTTPError is a bad request for cursors clients changed, everything else is a server error
+func pageHTTPError(err error) *echo.HTTPError {
+	if errors.Cause(err) == rfrl.ErrInvalidCursor {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+}
@@ -0,0 +1,131 @@
+package views
+
+import (
+	"net/http"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// PrivacySettingPayload is the struct used to hold payload from /privacy-setting
+	PrivacySettingPayload struct {
+		HideEmail           null.Bool `json:"hideEmail"`
+		HideWorkEmail       null.Bool `json:"hideWorkEmail"`
+		HideLinkedInProfile null.Bool `json:"hideLinkedInProfile"`
+		HiddenFromSearch    null.Bool `json:"hiddenFromSearch"`
+	}
+)
+
+type PrivacySettingView struct {
+	PrivacyUseCase rfrl.PrivacyUseCase
+}
+
+// getViewer gets the client the response is for, it decides which client fields are shown
+func getViewer(c echo.Context) (rfrl.Viewer, error) {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return rfrl.Viewer{}, err
+	}
+
+	return rfrl.Viewer{ClientID: claims.ClientID, Admin: claims.Admin}, nil
+}
+
+// sessionsClients gathers the tutor and clients of the sessions so their fields are hidden together
+func sessionsClients(sessions ...*rfrl.Session) []*rfrl.Client {
+	clients := make([]*rfrl.Client, 0)
+
+	for _, session := range sessions {
+		clients = append(clients, &session.Tutor)
+
+		for i := range session.Clients {
+			clients = append(clients, &session.Clients[i])
+		}
+	}
+
+	return clients
+}
+
+// seriesSessions gathers the sessions of the series so their clients are hidden together
+func seriesSessions(series ...*rfrl.SessionSeries) []*rfrl.Session {
+	sessions := make([]*rfrl.Session, 0)
+
+	for _, s := range series {
+		for i := range s.Sessions {
+			sessions = append(sessions, &s.Sessions[i])
+		}
+	}
+
+	return sessions
+}
+
+// sessionsResponse responds with the body once what the viewer cannot see of the tutor and clients
+// of the sessions is hidden. Every response holding sessions goes through it
+func sessionsResponse(
+	c echo.Context,
+	privacyUseCase rfrl.PrivacyUseCase,
+	status int,
+	body interface{},
+	sessions ...*rfrl.Session,
+) error {
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = privacyUseCase.HideClientFields(viewer, sessionsClients(sessions...)...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(status, body)
+}
+
+func (psv *PrivacySettingView) GetPrivacySettingEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	setting, err := psv.PrivacyUseCase.GetPrivacySetting(claims.ClientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
+
+func (psv *PrivacySettingView) UpdatePrivacySettingEndpoint(c echo.Context) error {
+	payload := PrivacySettingPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdatePrivacySettingEndpoint - Bind"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	setting, err := psv.PrivacyUseCase.UpdatePrivacySetting(
+		claims.ClientID,
+		payload.HideEmail,
+		payload.HideWorkEmail,
+		payload.HideLinkedInProfile,
+		payload.HiddenFromSearch,
+	)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
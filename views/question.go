@@ -1,6 +1,7 @@
 package views
 
 import (
+	"database/sql"
 	"net/http"
 	"strconv"
 
@@ -15,7 +16,7 @@ type (
 	QuestionPayload struct {
 		ID       int       `path:"id"`
 		Title    string    `json:"title" validate:"required,gte=0,lte=150"`
-		Body     string    `json:body" validate:"required, gte=0"`
+		Body     string    `json:"body" validate:"required,gte=0"`
 		Tags     []int     `json:"tags" validate:"numeric"`
 		Resolved null.Bool `json:"resolved"`
 	}
@@ -23,6 +24,7 @@ type (
 
 type QuestionView struct {
 	QuestionUseCase rfrl.QuestionUseCase
+	PrivacyUseCase  rfrl.PrivacyUseCase
 }
 
 func (qv *QuestionView) CreateQuestionEndpoint(c echo.Context) error {
@@ -113,34 +115,105 @@ func (qv QuestionView) GetQuestionEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = qv.PrivacyUseCase.HideClientFields(viewer, &question.From)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
 	return c.JSON(http.StatusOK, question)
 }
 
-func (qv QuestionView) GetQuestionsEndpoint(c echo.Context) error {
-	lastQuestionParam := c.QueryParam("lastQuestion")
+// GetSuggestedTutorsEndpoint suggests tutors sharing tags with the question
+func (qv QuestionView) GetSuggestedTutorsEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetSuggestedTutorsEndpoint - Atoi"))
+	}
+
+	tutors, err := qv.QuestionUseCase.GetSuggestedTutors(ID)
 
-	var lastQuestion null.Int
-	if lastQuestionParam != "" {
-		i, err := strconv.Atoi(lastQuestionParam)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetQuestionsEndpoint - Atoi"))
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return echo.NewHTTPError(http.StatusNotFound, "Question not found").SetInternal(err)
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 		}
-		lastQuestion = null.IntFrom(int64(i))
+	}
+
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	clients := make([]*rfrl.Client, len(*tutors))
+
+	for i := range *tutors {
+		clients[i] = &(*tutors)[i].Client
+	}
+
+	err = qv.PrivacyUseCase.HideClientFields(viewer, clients...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, tutors)
+}
+
+// questionsPage responds with a page of questions after hiding what the viewer cannot see of their authors
+func (qv QuestionView) questionsPage(c echo.Context, questions *[]rfrl.Question, next *rfrl.Cursor) error {
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	clients := make([]*rfrl.Client, len(*questions))
+
+	for i := range *questions {
+		clients[i] = &(*questions)[i].From
+	}
+
+	err = qv.PrivacyUseCase.HideClientFields(viewer, clients...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(questions, next))
+}
+
+func (qv QuestionView) GetQuestionsEndpoint(c echo.Context) error {
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
 	resolved := null.Bool{}
-	err := resolved.UnmarshalText([]byte(c.QueryParam("withCompany")))
+	err = resolved.UnmarshalText([]byte(c.QueryParam("withCompany")))
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetQuestionsEndpoint - UnmarshalText"))
 	}
 
-	questions, err := qv.QuestionUseCase.GetQuestions(lastQuestion, resolved)
+	questions, next, err := qv.QuestionUseCase.GetQuestions(resolved, page)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		return pageHTTPError(err)
 	}
-	return c.JSON(http.StatusOK, questions)
+
+	return qv.questionsPage(c, questions, next)
 }
 
 func (qv QuestionView) GetQuestionsFromClientEndpoint(c echo.Context) error {
@@ -150,20 +223,26 @@ func (qv QuestionView) GetQuestionsFromClientEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetQuestionsFromClientEndpoint - Parse"))
 	}
 
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
 	resolved := null.Bool{}
-	err := resolved.UnmarshalText([]byte(c.QueryParam("withCompany")))
+	err = resolved.UnmarshalText([]byte(c.QueryParam("withCompany")))
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetQuestionsFromClientEndpoint - UnmarshalText"))
 	}
 
-	questions, err := qv.QuestionUseCase.GetQuestionsForClient(clientID, resolved)
+	questions, next, err := qv.QuestionUseCase.GetQuestionsForClient(clientID, resolved, page)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		return pageHTTPError(err)
 	}
 
-	return c.JSON(http.StatusOK, questions)
+	return qv.questionsPage(c, questions, next)
 }
 
 func (qv QuestionView) ApplyToQuestionEndpoint(c echo.Context) error {
@@ -0,0 +1,219 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// QuestionAnswerPayload is the struct used to hold payload from POST /question/:id/answers
+	QuestionAnswerPayload struct {
+		ParentID null.Int `json:"parentId"`
+		Body     string   `json:"body" validate:"required,lte=20000"`
+	}
+
+	// UpdateQuestionAnswerPayload is the struct used to hold payload from PUT /question-answer/:id
+	UpdateQuestionAnswerPayload struct {
+		ID   int    `path:"id"`
+		Body string `json:"body" validate:"required,lte=20000"`
+	}
+)
+
+type QuestionAnswerView struct {
+	QuestionAnswerUseCase rfrl.QuestionAnswerUseCase
+	PrivacyUseCase        rfrl.PrivacyUseCase
+}
+
+func questionAnswerHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Question or answer is not found").SetInternal(err)
+	case rfrl.ErrQuestionAnswerNotForClient, rfrl.ErrNotQuestionAuthor:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	case rfrl.ErrCommentNotAcceptable, rfrl.ErrQuestionAnswerParent:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+// hideAuthorFields hides what the viewer cannot see of who wrote the answers and their comments
+func (qav QuestionAnswerView) hideAuthorFields(c echo.Context, answers []rfrl.QuestionAnswer) error {
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	var clients []*rfrl.Client
+	var collect func(answers []rfrl.QuestionAnswer)
+	collect = func(answers []rfrl.QuestionAnswer) {
+		for i := range answers {
+			clients = append(clients, &answers[i].From)
+			collect(answers[i].Replies)
+		}
+	}
+	collect(answers)
+
+	err = qav.PrivacyUseCase.HideClientFields(viewer, clients...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return nil
+}
+
+func (qav QuestionAnswerView) CreateQuestionAnswerEndpoint(c echo.Context) error {
+	questionID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Question ID is not valid").SetInternal(errors.Wrap(err, "CreateQuestionAnswerEndpoint - Atoi"))
+	}
+
+	payload := QuestionAnswerPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateQuestionAnswerEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateQuestionAnswerEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	answer, err := qav.QuestionAnswerUseCase.CreateQuestionAnswer(claims.ClientID, questionID, payload.ParentID, payload.Body)
+
+	if err != nil {
+		return questionAnswerHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, answer)
+}
+
+func (qav QuestionAnswerView) UpdateQuestionAnswerEndpoint(c echo.Context) error {
+	payload := UpdateQuestionAnswerPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateQuestionAnswerEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateQuestionAnswerEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	answer, err := qav.QuestionAnswerUseCase.UpdateQuestionAnswer(claims.ClientID, payload.ID, payload.Body)
+
+	if err != nil {
+		return questionAnswerHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, answer)
+}
+
+func (qav QuestionAnswerView) DeleteQuestionAnswerEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteQuestionAnswerEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = qav.QuestionAnswerUseCase.DeleteQuestionAnswer(claims.ClientID, ID)
+
+	if err != nil {
+		return questionAnswerHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// acceptQuestionAnswer accepts or unaccepts the answer for the author of the question
+func (qav QuestionAnswerView) acceptQuestionAnswer(c echo.Context, accepted bool) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "acceptQuestionAnswer - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	answer, err := qav.QuestionAnswerUseCase.AcceptQuestionAnswer(claims.ClientID, ID, accepted)
+
+	if err != nil {
+		return questionAnswerHTTPError(err)
+	}
+
+	answers := []rfrl.QuestionAnswer{*answer}
+
+	if err = qav.hideAuthorFields(c, answers); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, answers[0])
+}
+
+// AcceptQuestionAnswerEndpoint accepts the answer and resolves its question
+func (qav QuestionAnswerView) AcceptQuestionAnswerEndpoint(c echo.Context) error {
+	return qav.acceptQuestionAnswer(c, true)
+}
+
+func (qav QuestionAnswerView) UnacceptQuestionAnswerEndpoint(c echo.Context) error {
+	return qav.acceptQuestionAnswer(c, false)
+}
+
+// GetQuestionAnswersEndpoint pages through the answers of the question with their comment threads
+func (qav QuestionAnswerView) GetQuestionAnswersEndpoint(c echo.Context) error {
+	questionID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Question ID is not valid").SetInternal(errors.Wrap(err, "GetQuestionAnswersEndpoint - Atoi"))
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	answers, next, err := qav.QuestionAnswerUseCase.GetQuestionAnswers(questionID, page)
+
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return questionAnswerHTTPError(err)
+		}
+		return pageHTTPError(err)
+	}
+
+	if err = qav.hideAuthorFields(c, *answers); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(answers, next))
+}
@@ -0,0 +1,216 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// QuestionApplicantsPayload is the struct used to hold payload from GET /question/:id/applicants
+	QuestionApplicantsPayload struct {
+		State null.String `query:"state"`
+	}
+
+	// UpdateQuestionApplicantPayload is the struct used to hold payload from PUT /question/:id/applicants/:applicantID
+	UpdateQuestionApplicantPayload struct {
+		State string `json:"state" validate:"required,oneof=shortlisted declined"`
+	}
+
+	// AcceptQuestionApplicantPayload is the struct used to hold payload from POST /question/:id/applicants/:applicantID/accept
+	AcceptQuestionApplicantPayload struct {
+		RoomID string `json:"roomId" validate:"required,lte=40"`
+	}
+
+	// AcceptedQuestionApplicantResponse is the accepted applicant with the session created with them
+	AcceptedQuestionApplicantResponse struct {
+		Applicant *rfrl.QuestionApplicant `json:"applicant"`
+		Session   *rfrl.Session           `json:"session"`
+	}
+)
+
+func questionApplicantHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Question is not found").SetInternal(err)
+	case rfrl.ErrNotAnApplicant:
+		return echo.NewHTTPError(http.StatusNotFound, err.Error()).SetInternal(err)
+	case rfrl.ErrNotQuestionAuthor:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	case rfrl.ErrInvalidApplicantTransition:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	case rfrl.ErrInvalidCursor:
+		return pageHTTPError(err)
+	default:
+		return sessionStateHTTPError(err)
+	}
+}
+
+// questionApplicantParams reads the question and the applicant from the path
+func questionApplicantParams(c echo.Context) (int, string, error) {
+	questionID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return 0, "", echo.NewHTTPError(http.StatusBadRequest, "Question ID is not valid").SetInternal(errors.Wrap(err, "questionApplicantParams - Atoi"))
+	}
+
+	applicantID := c.Param("applicantID")
+
+	if _, err := uuid.Parse(applicantID); err != nil {
+		return 0, "", echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "questionApplicantParams - Parse"))
+	}
+
+	return questionID, applicantID, nil
+}
+
+// hideApplicantFields hides what the author of the question cannot see of the applicants
+func (qv QuestionView) hideApplicantFields(c echo.Context, applicants []rfrl.QuestionApplicant) error {
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	clients := make([]*rfrl.Client, len(applicants))
+
+	for i := range applicants {
+		clients[i] = &applicants[i].Applicant
+	}
+
+	err = qv.PrivacyUseCase.HideClientFields(viewer, clients...)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return nil
+}
+
+// GetQuestionApplicantsEndpoint lists the applicants of the client's question with their profile and rating
+func (qv QuestionView) GetQuestionApplicantsEndpoint(c echo.Context) error {
+	questionID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Question ID is not valid").SetInternal(errors.Wrap(err, "GetQuestionApplicantsEndpoint - Atoi"))
+	}
+
+	payload := QuestionApplicantsPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetQuestionApplicantsEndpoint - Bind"))
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	applicants, next, err := qv.QuestionUseCase.GetQuestionApplicants(claims.ClientID, questionID, payload.State, page)
+
+	if err != nil {
+		return questionApplicantHTTPError(err)
+	}
+
+	if err = qv.hideApplicantFields(c, *applicants); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(*applicants, next))
+}
+
+// UpdateQuestionApplicantEndpoint shortlists or declines an applicant
+func (qv QuestionView) UpdateQuestionApplicantEndpoint(c echo.Context) error {
+	questionID, applicantID, err := questionApplicantParams(c)
+
+	if err != nil {
+		return err
+	}
+
+	payload := UpdateQuestionApplicantPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateQuestionApplicantEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateQuestionApplicantEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	applicant, err := qv.QuestionUseCase.UpdateQuestionApplicant(claims.ClientID, questionID, applicantID, payload.State)
+
+	if err != nil {
+		return questionApplicantHTTPError(err)
+	}
+
+	applicants := []rfrl.QuestionApplicant{*applicant}
+
+	if err = qv.hideApplicantFields(c, applicants); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, applicants[0])
+}
+
+// AcceptQuestionApplicantEndpoint accepts an applicant and creates a pending session with them
+func (qv QuestionView) AcceptQuestionApplicantEndpoint(c echo.Context) error {
+	questionID, applicantID, err := questionApplicantParams(c)
+
+	if err != nil {
+		return err
+	}
+
+	payload := AcceptQuestionApplicantPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "AcceptQuestionApplicantEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "AcceptQuestionApplicantEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	applicant, session, err := qv.QuestionUseCase.AcceptQuestionApplicant(claims.ClientID, questionID, applicantID, payload.RoomID)
+
+	if err != nil {
+		return questionApplicantHTTPError(err)
+	}
+
+	applicants := []rfrl.QuestionApplicant{*applicant}
+
+	if err = qv.hideApplicantFields(c, applicants); err != nil {
+		return err
+	}
+
+	return sessionsResponse(
+		c,
+		qv.PrivacyUseCase,
+		http.StatusCreated,
+		AcceptedQuestionApplicantResponse{&applicants[0], session},
+		session,
+	)
+}
@@ -0,0 +1,289 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// ReferralPayload is the struct used to hold payload from /referral
+	ReferralPayload struct {
+		ReferrerID   string   `json:"referrerId" validate:"required"`
+		CompanyID    int      `json:"companyId" validate:"required"`
+		JobPostingID null.Int `json:"jobPostingId"`
+		JobURL       string   `json:"jobUrl" validate:"required_without=JobPostingID,omitempty,url"`
+		DocumentID   int      `json:"documentId" validate:"required"`
+		Message      string   `json:"message" validate:"omitempty,lte=2000"`
+	}
+
+	// GetReferralsPayload is the struct used to hold payload from GET /referral
+	GetReferralsPayload struct {
+		Role  string `query:"role" validate:"omitempty,oneof=seeker referrer"`
+		State string `query:"state"`
+	}
+
+	// ReferralStatePayload is the struct used to hold payload from /referral/:id/state
+	ReferralStatePayload struct {
+		ID     int                `path:"id"`
+		State  rfrl.ReferralState `json:"state" validate:"required"`
+		Reason string             `json:"reason" validate:"omitempty,lte=500"`
+	}
+
+	// ReferralOutcomePayload is the struct used to hold payload from /referral/:id/outcome
+	ReferralOutcomePayload struct {
+		ID         int    `path:"id"`
+		Stage      string `json:"stage" validate:"required,oneof=interview offer hired rejected"`
+		OccurredOn string `json:"occurredOn" validate:"required"`
+		Note       string `json:"note" validate:"omitempty,lte=1000"`
+	}
+
+	// ReferrerLeaderboardPayload is the struct used to hold payload from /referral-leaderboard
+	ReferrerLeaderboardPayload struct {
+		CompanyID null.Int `query:"companyId"`
+		Limit     int      `query:"limit" validate:"gte=1,lte=50"`
+	}
+)
+
+// outcomeDateLayout is how outcome dates are sent, they do not need a time of day
+const outcomeDateLayout = "2006-01-02"
+
+type ReferralView struct {
+	ReferralUseCase rfrl.ReferralUseCase
+}
+
+func referralHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Referral is not found").SetInternal(err)
+	case rfrl.ErrInvalidReferralStateTransition, rfrl.ErrReferralAlreadyRequested, rfrl.ErrReferralNotSubmitted:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	case rfrl.ErrReferralStateTransitionNotAllowed:
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error()).SetInternal(err)
+	case rfrl.ErrReferrerNotVerified, rfrl.ErrInvalidReferralOutcome, rfrl.ErrJobPostingWrongCompany, rfrl.ErrJobPostingExpired:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+func (rv *ReferralView) CreateReferralEndpoint(c echo.Context) error {
+	payload := ReferralPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateReferralEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateReferralEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	referral, err := rv.ReferralUseCase.CreateReferral(
+		claims.ClientID,
+		payload.ReferrerID,
+		payload.CompanyID,
+		payload.JobPostingID,
+		payload.JobURL,
+		payload.DocumentID,
+		payload.Message,
+	)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, referral)
+}
+
+func (rv *ReferralView) GetReferralsEndpoint(c echo.Context) error {
+	payload := GetReferralsPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetReferralsEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetReferralsEndpoint - Validate"))
+	}
+
+	if payload.State != "" && !rfrl.ReferralState(payload.State).IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, "State is not a valid referral state")
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	referrals, next, err := rv.ReferralUseCase.GetReferrals(claims.ClientID, rfrl.GetReferralsOptions{
+		AsSeeker: null.NewBool(payload.Role == "seeker", payload.Role != ""),
+		State:    null.NewString(payload.State, payload.State != ""),
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(referrals, next))
+}
+
+func (rv *ReferralView) GetReferralEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetReferralEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	referral, err := rv.ReferralUseCase.GetReferral(claims.ClientID, ID)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, referral)
+}
+
+func (rv *ReferralView) UpdateReferralStateEndpoint(c echo.Context) error {
+	payload := ReferralStatePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateReferralStateEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "UpdateReferralStateEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	referral, err := rv.ReferralUseCase.UpdateReferralState(claims.ClientID, payload.ID, payload.State, payload.Reason)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, referral)
+}
+
+func (rv *ReferralView) ReportReferralOutcomeEndpoint(c echo.Context) error {
+	payload := ReferralOutcomePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReportReferralOutcomeEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReportReferralOutcomeEndpoint - Validate"))
+	}
+
+	occurredOn, err := time.Parse(outcomeDateLayout, payload.OccurredOn)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReportReferralOutcomeEndpoint - time.Parse"))
+	}
+
+	if occurredOn.After(time.Now()) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Outcome date cannot be in the future")
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	referral, err := rv.ReferralUseCase.ReportReferralOutcome(
+		claims.ClientID,
+		payload.ID,
+		payload.Stage,
+		occurredOn,
+		payload.Note,
+	)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, referral)
+}
+
+func (rv *ReferralView) GetReferrerProfileStatsEndpoint(c echo.Context) error {
+	clientID := c.Param("clientID")
+
+	if clientID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Client ID is not passed in")
+	}
+
+	stats, err := rv.ReferralUseCase.GetReferrerProfileStats(clientID)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+func (rv *ReferralView) GetCompanyReferralStatsEndpoint(c echo.Context) error {
+	companyID, err := strconv.Atoi(c.Param("companyID"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetCompanyReferralStatsEndpoint - Atoi"))
+	}
+
+	stats, err := rv.ReferralUseCase.GetCompanyReferralStats(companyID)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+func (rv *ReferralView) GetReferrerLeaderboardEndpoint(c echo.Context) error {
+	payload := ReferrerLeaderboardPayload{Limit: 10}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetReferrerLeaderboardEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetReferrerLeaderboardEndpoint - Validate"))
+	}
+
+	leaderboard, err := rv.ReferralUseCase.GetReferrerLeaderboard(payload.CompanyID, payload.Limit)
+
+	if err != nil {
+		return referralHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, leaderboard)
+}
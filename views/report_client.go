@@ -115,11 +115,17 @@ func (r *ReportClientView) GetReports(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "You are not authorized for this view")
 	}
 
-	reports, err := r.ReportClientUseCase.GetReports()
+	page, err := getPageOptions(c)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	reports, next, err := r.ReportClientUseCase.GetReports(page)
+
+	if err != nil {
+		return pageHTTPError(err)
 	}
 
-	return c.JSON(http.StatusOK, reports)
+	return c.JSON(http.StatusOK, rfrl.NewPage(reports, next))
 }
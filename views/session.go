@@ -17,12 +17,13 @@ import (
 type (
 	// DocumentPayload is the struct used to hold payload from /session
 	SessionPayload struct {
-		ID              int      `path:"id"`
-		TutorID         string   `json:"tutorId" validate:"required,gte=0,lte=100"`
-		RoomID          string   `json:"roomId" validate:"required, gte=0, lte=10"`
-		ClientIDs       []string `json:"clientIds" validate:"required"`
-		State           string   `json:"state" validate:"required"`
-		TargetedEventID int      `json:"targetedEventId" validate:"omitempty"`
+		ID              int               `path:"id"`
+		TutorID         string            `json:"tutorId" validate:"required,gte=0,lte=100"`
+		RoomID          string            `json:"roomId" validate:"required, gte=0, lte=10"`
+		ClientIDs       []string          `json:"clientIds" validate:"required"`
+		State           rfrl.SessionState `json:"state" validate:"required"`
+		Reason          string            `json:"reason" validate:"omitempty,lte=500"`
+		TargetedEventID int               `json:"targetedEventId" validate:"omitempty"`
 	}
 
 	// SessionEventPayload is the struct used to hold payload from /session/:sessionId/event/:id
@@ -35,7 +36,7 @@ type (
 	}
 
 	ClientSelectionOfSessionEventPayload struct {
-		SessionID int   `path:'sessionID"`
+		SessionID int   `path:"sessionID"`
 		CanAttend *bool `json:"canAttend" validate:"required"`
 	}
 
@@ -43,17 +44,46 @@ type (
 		SessionID int    `path:"sessionID"`
 		StartTime string `query:"start" validate:"omitempty, datetime"`
 		EndTime   string `query:"end" validate:"omitempty, datetime"`
-		State     string `query:"state" validate:"omitempty,oneof= scheduled pending"`
+		State     string `query:"state" validate:"omitempty,oneof= scheduled pending completed cancelled no_show"`
+	}
+
+	SessionProposalPayload struct {
+		SessionID int      `path:"sessionID"`
+		Start     string   `json:"start" validate:"required"`
+		End       string   `json:"end" validate:"required"`
+		Title     string   `json:"title" validate:"omitempty,lte=40"`
+		Reason    string   `json:"reason" validate:"omitempty,lte=500"`
+		CounterTo null.Int `json:"counterTo"`
+	}
+
+	SessionProposalResponsePayload struct {
+		SessionID int   `path:"sessionID"`
+		ID        int   `path:"id"`
+		Accept    *bool `json:"accept" validate:"required"`
 	}
 
 	GetSessionConferenceIDEndpointResponse struct {
 		ConferenceID string `json:"conferenceID"`
 	}
+
+	FindCommonFreeSlotsPayload struct {
+		ClientIDs []string `query:"clientIds" validate:"required,min=1,max=10"`
+		Duration  int      `query:"duration" validate:"gte=15,lte=480"`
+		Start     string   `query:"start" validate:"required"`
+		End       string   `query:"end" validate:"required"`
+		Limit     int      `query:"limit" validate:"gte=1,lte=50"`
+	}
+
+	EventsOverlapResponse struct {
+		Message   string               `json:"message"`
+		Conflicts []rfrl.EventConflict `json:"conflicts"`
+	}
 )
 
 type SessionView struct {
 	SessionUseCase     rfrl.SessionUseCase
 	TutorReviewUseCase rfrl.TutorReviewUseCase
+	PrivacyUseCase     rfrl.PrivacyUseCase
 }
 
 func (sv *SessionView) CreateSessionEndpoint(c echo.Context) error {
@@ -65,6 +95,10 @@ func (sv *SessionView) CreateSessionEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionEndpoint - Bind"))
 	}
 
+	if payload.State != rfrl.PENDING {
+		return echo.NewHTTPError(http.StatusBadRequest, "Session can only be created as pending")
+	}
+
 	claims, err := rfrl.GetClaims(c)
 
 	if err != nil {
@@ -77,13 +111,36 @@ func (sv *SessionView) CreateSessionEndpoint(c echo.Context) error {
 		payload.RoomID,
 		payload.ClientIDs,
 		payload.State,
+		null.Int{},
 	)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return sessionStateHTTPError(err)
 	}
 
-	return c.JSON(http.StatusCreated, session)
+	return sessionsResponse(c, sv.PrivacyUseCase, http.StatusCreated, session, session)
+}
+
+func sessionStateHTTPError(err error) *echo.HTTPError {
+	var overlapErr *rfrl.EventsOverlapError
+
+	if errors.As(err, &overlapErr) {
+		return echo.NewHTTPError(http.StatusConflict, EventsOverlapResponse{
+			Message:   overlapErr.Error(),
+			Conflicts: overlapErr.Conflicts,
+		}).SetInternal(err)
+	}
+
+	switch errors.Cause(err) {
+	case rfrl.ErrInvalidSessionStateTransition, rfrl.ErrSessionProposalClosed:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	case rfrl.ErrSessionStateTransitionNotAllowed:
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error()).SetInternal(err)
+	case rfrl.ErrNotApprovedTutor:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
 }
 
 func (sv *SessionView) UpdateSessionEndpoint(c echo.Context) error {
@@ -109,10 +166,14 @@ func (sv *SessionView) UpdateSessionEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to update this session")
 	}
 
-	session, err := sv.SessionUseCase.UpdateSession(payload.ID, claims.ClientID, payload.State)
+	if !payload.State.IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, "State is required")
+	}
+
+	session, err := sv.SessionUseCase.UpdateSession(payload.ID, claims.ClientID, payload.State, payload.Reason)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return sessionStateHTTPError(err)
 	}
 
 	if session.TargetedEventID.Valid {
@@ -125,7 +186,7 @@ func (sv *SessionView) UpdateSessionEndpoint(c echo.Context) error {
 		session.Event = event
 	}
 
-	return c.JSON(http.StatusOK, session)
+	return sessionsResponse(c, sv.PrivacyUseCase, http.StatusOK, session, session)
 }
 
 func (sv *SessionView) DeleteSessionEndpoint(c echo.Context) error {
@@ -188,7 +249,7 @@ func (sv *SessionView) GetSessionEndpoint(c echo.Context) error {
 		session.Event = event
 	}
 
-	return c.JSON(http.StatusOK, session)
+	return sessionsResponse(c, sv.PrivacyUseCase, http.StatusOK, session, session)
 }
 
 func (sv *SessionView) CreateSessionEventEndpoint(c echo.Context) error {
@@ -221,7 +282,7 @@ func (sv *SessionView) CreateSessionEventEndpoint(c echo.Context) error {
 	event, err = sv.SessionUseCase.CreateSessionEvent(claims.ClientID, payload.SessionID, *event)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return sessionStateHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, *event)
@@ -284,7 +345,7 @@ func (sv *SessionView) GetSessionConferenceIDEndpoint(c echo.Context) error {
 	}
 
 	if claims.ClientID != session.TutorID {
-		err = sv.TutorReviewUseCase.CreatePendingReview(claims.ClientID, session.TutorID)
+		err = sv.TutorReviewUseCase.CreatePendingReview(claims.ClientID, session.TutorID, session.ID)
 
 		if err != nil {
 			var pgErr *pgconn.PgError
@@ -345,7 +406,11 @@ func (sv *SessionView) GetSessionEventEndpoint(c echo.Context) error {
 
 func (sv *SessionView) GetSessionsEndpoint(c echo.Context) error {
 	roomID := c.QueryParam("roomId")
-	state := c.QueryParam("state")
+	state := rfrl.SessionState(c.QueryParam("state"))
+
+	if state != "" && !state.IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s is not a valid session state", state))
+	}
 
 	claims, err := rfrl.GetClaims(c)
 
@@ -353,9 +418,18 @@ func (sv *SessionView) GetSessionsEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
 	sessions := &([]rfrl.Session{})
 	sessionIDs := make([]int, 0)
 
+	// Sessions of a room are few so they are all on the first page
+	var next *rfrl.Cursor
+
 	if roomID != "" {
 		sessions, err = sv.SessionUseCase.GetSessionByRoomId(claims.ClientID, roomID, state)
 
@@ -380,10 +454,10 @@ func (sv *SessionView) GetSessionsEndpoint(c echo.Context) error {
 		}
 
 	} else {
-		sessions, err = sv.SessionUseCase.GetSessionByClientID(claims.ClientID, state)
+		sessions, next, err = sv.SessionUseCase.GetSessionByClientID(claims.ClientID, state, page)
 
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+			return pageHTTPError(err)
 		}
 
 		for i := 0; i < len(*sessions); i++ {
@@ -406,7 +480,13 @@ func (sv *SessionView) GetSessionsEndpoint(c echo.Context) error {
 		}
 	}
 
-	return c.JSON(http.StatusOK, *sessions)
+	sessionPointers := make([]*rfrl.Session, len(*sessions))
+
+	for i := range *sessions {
+		sessionPointers[i] = &(*sessions)[i]
+	}
+
+	return sessionsResponse(c, sv.PrivacyUseCase, http.StatusOK, rfrl.NewPage(*sessions, next), sessionPointers...)
 }
 
 func (sv *SessionView) CreateClientActionOnSessionEvent(c echo.Context) error {
@@ -425,20 +505,20 @@ func (sv *SessionView) CreateClientActionOnSessionEvent(c echo.Context) error {
 	err = sv.SessionUseCase.ClientActionOnSessionEvent(claims.ClientID, payload.SessionID, *payload.CanAttend)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+		return sessionStateHTTPError(err)
 	}
 
-	allClientsResponded, err := sv.SessionUseCase.CheckAllClientSessionHasResponded(payload.SessionID)
+	allCanAttend, err := sv.SessionUseCase.CheckAllClientsCanAttendSession(payload.SessionID)
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
 
-	if allClientsResponded {
-		_, err = sv.SessionUseCase.UpdateSession(payload.SessionID, claims.ClientID, rfrl.SCHEDULED)
+	if allCanAttend {
+		_, err = sv.SessionUseCase.UpdateSession(payload.SessionID, claims.ClientID, rfrl.SCHEDULED, "")
 
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+			return sessionStateHTTPError(err)
 		}
 	}
 
@@ -479,16 +559,285 @@ func (sv *SessionView) GetSessionRelatedEventsEndpoint(c echo.Context) error {
 
 	var state null.String
 	if payload.State == "" {
-		state = null.NewString(rfrl.SCHEDULED, true)
+		state = null.NewString(string(rfrl.SCHEDULED), true)
 	} else {
 		state = null.NewString(payload.State, true)
 	}
 
-	events, err := sv.SessionUseCase.GetSessionRelatedEvents(claims.ClientID, payload.SessionID, start, end, state)
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	events, next, err := sv.SessionUseCase.GetSessionRelatedEvents(claims.ClientID, payload.SessionID, start, end, state, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(events, next))
+}
+
+func (sv *SessionView) GetSessionStateChangesEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetSessionStateChangesEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	forClient, err := sv.SessionUseCase.CheckSessionsIsForClient(claims.ClientID, []int{ID})
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if !forClient && !claims.Admin {
+		return echo.NewHTTPError(http.StatusBadRequest, "Session does not belong to client")
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	changes, next, err := sv.SessionUseCase.GetSessionStateChanges(ID, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(changes, next))
+}
+
+func (sv *SessionView) CreateSessionProposalEndpoint(c echo.Context) error {
+	payload := SessionProposalPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionProposalEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionProposalEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionProposalEndpoint - time.Parse"))
+	}
+
+	end, err := time.Parse(time.RFC3339, payload.End)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionProposalEndpoint - time.Parse"))
+	}
+
+	if !end.After(start) {
+		return echo.NewHTTPError(http.StatusBadRequest, "End has to be after start")
+	}
+
+	if start.Before(time.Now()) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot propose a time in the past")
+	}
+
+	event := rfrl.NewEvent(start, end, payload.Title)
+
+	proposal, err := sv.SessionUseCase.CreateSessionProposal(
+		claims.ClientID,
+		payload.SessionID,
+		*event,
+		payload.Reason,
+		payload.CounterTo,
+	)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, proposal)
+}
+
+func (sv *SessionView) RespondToSessionProposalEndpoint(c echo.Context) error {
+	payload := SessionProposalResponsePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "RespondToSessionProposalEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "RespondToSessionProposalEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	proposal, err := sv.SessionUseCase.RespondToSessionProposal(
+		claims.ClientID,
+		payload.SessionID,
+		payload.ID,
+		*payload.Accept,
+	)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	if proposal.State != rfrl.PROPOSAL_ACCEPTED {
+		return c.JSON(http.StatusOK, proposal)
+	}
+
+	session, err := sv.SessionUseCase.GetSessionByID(claims.ClientID, payload.SessionID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if session.State != rfrl.PENDING {
+		return c.JSON(http.StatusOK, proposal)
+	}
+
+	allCanAttend, err := sv.SessionUseCase.CheckAllClientsCanAttendSession(payload.SessionID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if allCanAttend {
+		_, err = sv.SessionUseCase.UpdateSession(payload.SessionID, claims.ClientID, rfrl.SCHEDULED, "")
+
+		if err != nil {
+			return sessionStateHTTPError(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, proposal)
+}
+
+func (sv *SessionView) GetSessionProposalsEndpoint(c echo.Context) error {
+	sessionID, err := strconv.Atoi(c.Param("sessionID"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetSessionProposalsEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	forClient, err := sv.SessionUseCase.CheckSessionsIsForClient(claims.ClientID, []int{sessionID})
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if !forClient {
+		return echo.NewHTTPError(http.StatusBadRequest, "Session does not belong to client")
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	proposals, next, err := sv.SessionUseCase.GetSessionProposals(sessionID, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(proposals, next))
+}
+
+func (sv *SessionView) FindCommonFreeSlotsEndpoint(c echo.Context) error {
+	payload := FindCommonFreeSlotsPayload{Duration: 60, Limit: 10}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FindCommonFreeSlotsEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FindCommonFreeSlotsEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FindCommonFreeSlotsEndpoint - time.Parse"))
+	}
+
+	end, err := time.Parse(time.RFC3339, payload.End)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FindCommonFreeSlotsEndpoint - time.Parse"))
+	}
+
+	if now := time.Now(); start.Before(now) {
+		start = now
+	}
+
+	if !end.After(start) {
+		return echo.NewHTTPError(http.StatusBadRequest, "End has to be after start")
+	}
+
+	if end.Sub(start) > rfrl.MaxSlotSearchDays*24*time.Hour {
+		return echo.NewHTTPError(http.StatusBadRequest, "Slots can only be searched a month at a time")
+	}
+
+	clientIDs := payload.ClientIDs
+	forClient := false
+
+	for i := 0; i < len(clientIDs); i++ {
+		if clientIDs[i] == claims.ClientID {
+			forClient = true
+		}
+	}
+
+	if !forClient {
+		clientIDs = append(clientIDs, claims.ClientID)
+	}
+
+	slots, err := sv.SessionUseCase.FindCommonFreeSlots(
+		claims.ClientID,
+		clientIDs,
+		start,
+		end,
+		time.Duration(payload.Duration)*time.Minute,
+		payload.Limit,
+	)
+
+	if errors.Cause(err) == rfrl.ErrFreeSlotsNotAllowed {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	}
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, *events)
+	return c.JSON(http.StatusOK, slots)
 }
@@ -0,0 +1,322 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// SessionSeriesPayload is the struct used to hold payload from /session-series
+	SessionSeriesPayload struct {
+		TutorID   string   `json:"tutorId" validate:"required"`
+		RoomID    string   `json:"roomId" validate:"required,lte=40"`
+		ClientIDs []string `json:"clientIds" validate:"required"`
+		Start     string   `json:"start" validate:"required"`
+		End       string   `json:"end" validate:"required"`
+		Title     string   `json:"title" validate:"omitempty,lte=40"`
+		Rule      string   `json:"rrule" validate:"required"`
+	}
+
+	// SessionSeriesResponsePayload is the struct used to hold payload from /session-series/:seriesID/book
+	SessionSeriesResponsePayload struct {
+		SeriesID  int   `path:"seriesID"`
+		CanAttend *bool `json:"canAttend" validate:"required"`
+	}
+
+	// SessionSeriesOccurrencePayload is the struct used to hold payload from /session-series/:seriesID/occurrence/:id
+	SessionSeriesOccurrencePayload struct {
+		SeriesID int    `path:"seriesID"`
+		ID       int    `path:"id"`
+		Reason   string `json:"reason" validate:"omitempty,lte=500"`
+		Start    string `json:"start"`
+		End      string `json:"end"`
+		Title    string `json:"title" validate:"omitempty,lte=40"`
+	}
+
+	// CancelSessionSeriesPayload is the struct used to hold payload from /session-series/:id/cancel
+	CancelSessionSeriesPayload struct {
+		ID     int    `path:"id"`
+		Reason string `json:"reason" validate:"omitempty,lte=500"`
+	}
+)
+
+type SessionSeriesView struct {
+	SessionSeriesUseCase rfrl.SessionSeriesUseCase
+	SessionUseCase       rfrl.SessionUseCase
+	PrivacyUseCase       rfrl.PrivacyUseCase
+}
+
+func (ssv *SessionSeriesView) CreateSessionSeriesEndpoint(c echo.Context) error {
+	payload := SessionSeriesPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionSeriesEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionSeriesEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionSeriesEndpoint - time.Parse"))
+	}
+
+	end, err := time.Parse(time.RFC3339, payload.End)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CreateSessionSeriesEndpoint - time.Parse"))
+	}
+
+	if !end.After(start) {
+		return echo.NewHTTPError(http.StatusBadRequest, "End has to be after start")
+	}
+
+	if start.Before(time.Now()) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Series cannot start in the past")
+	}
+
+	if _, err = rfrl.ParseRecurrenceRule(payload.Rule); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	series, err := ssv.SessionSeriesUseCase.CreateSessionSeries(
+		claims.ClientID,
+		payload.TutorID,
+		payload.RoomID,
+		payload.ClientIDs,
+		*rfrl.NewEvent(start, end, payload.Title),
+		payload.Rule,
+	)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return sessionsResponse(c, ssv.PrivacyUseCase, http.StatusCreated, series, seriesSessions(series)...)
+}
+
+func (ssv *SessionSeriesView) checkSessionSeriesIsForClient(clientID string, ID int) error {
+	forClient, err := ssv.SessionSeriesUseCase.CheckSessionSeriesIsForClient(clientID, ID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if !forClient {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Series does not belong to client")
+	}
+
+	return nil
+}
+
+func (ssv *SessionSeriesView) GetSessionSeriesEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetSessionSeriesEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if err = ssv.checkSessionSeriesIsForClient(claims.ClientID, ID); err != nil {
+		return err
+	}
+
+	series, err := ssv.SessionSeriesUseCase.GetSessionSeries(claims.ClientID, ID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return sessionsResponse(c, ssv.PrivacyUseCase, http.StatusOK, series, seriesSessions(series)...)
+}
+
+func (ssv *SessionSeriesView) RespondToSessionSeriesEndpoint(c echo.Context) error {
+	payload := SessionSeriesResponsePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "RespondToSessionSeriesEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "RespondToSessionSeriesEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if err = ssv.checkSessionSeriesIsForClient(claims.ClientID, payload.SeriesID); err != nil {
+		return err
+	}
+
+	series, err := ssv.SessionSeriesUseCase.RespondToSessionSeries(claims.ClientID, payload.SeriesID, *payload.CanAttend)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return sessionsResponse(c, ssv.PrivacyUseCase, http.StatusOK, series, seriesSessions(series)...)
+}
+
+// checkSessionSeriesOccurrence makes sure the session belongs to both the client and the series
+func (ssv *SessionSeriesView) checkSessionSeriesOccurrence(clientID string, seriesID int, ID int) error {
+	forClient, err := ssv.SessionUseCase.CheckSessionsIsForClient(clientID, []int{ID})
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if !forClient {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Session does not belong to client")
+	}
+
+	session, err := ssv.SessionUseCase.GetSessionByID(clientID, ID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	if !session.SeriesID.Valid || int(session.SeriesID.Int64) != seriesID {
+		return echo.NewHTTPError(http.StatusNotFound, "Session is not part of the series")
+	}
+
+	return nil
+}
+
+func (ssv *SessionSeriesView) SkipSessionSeriesOccurrenceEndpoint(c echo.Context) error {
+	payload := SessionSeriesOccurrencePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SkipSessionSeriesOccurrenceEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SkipSessionSeriesOccurrenceEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if err = ssv.checkSessionSeriesOccurrence(claims.ClientID, payload.SeriesID, payload.ID); err != nil {
+		return err
+	}
+
+	session, err := ssv.SessionUseCase.UpdateSession(payload.ID, claims.ClientID, rfrl.CANCELLED, payload.Reason)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return sessionsResponse(c, ssv.PrivacyUseCase, http.StatusOK, session, session)
+}
+
+func (ssv *SessionSeriesView) EditSessionSeriesOccurrenceEndpoint(c echo.Context) error {
+	payload := SessionSeriesOccurrencePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "EditSessionSeriesOccurrenceEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "EditSessionSeriesOccurrenceEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	start, err := time.Parse(time.RFC3339, payload.Start)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "EditSessionSeriesOccurrenceEndpoint - time.Parse"))
+	}
+
+	end, err := time.Parse(time.RFC3339, payload.End)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "EditSessionSeriesOccurrenceEndpoint - time.Parse"))
+	}
+
+	if !end.After(start) {
+		return echo.NewHTTPError(http.StatusBadRequest, "End has to be after start")
+	}
+
+	if start.Before(time.Now()) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot propose a time in the past")
+	}
+
+	if err = ssv.checkSessionSeriesOccurrence(claims.ClientID, payload.SeriesID, payload.ID); err != nil {
+		return err
+	}
+
+	// Editing a single occurrence goes through the regular proposal flow so everyone agrees on it
+	proposal, err := ssv.SessionUseCase.CreateSessionProposal(
+		claims.ClientID,
+		payload.ID,
+		*rfrl.NewEvent(start, end, payload.Title),
+		payload.Reason,
+		null.NewInt(0, false),
+	)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, proposal)
+}
+
+func (ssv *SessionSeriesView) CancelSessionSeriesEndpoint(c echo.Context) error {
+	payload := CancelSessionSeriesPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CancelSessionSeriesEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "CancelSessionSeriesEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if err = ssv.checkSessionSeriesIsForClient(claims.ClientID, payload.ID); err != nil {
+		return err
+	}
+
+	series, err := ssv.SessionSeriesUseCase.CancelSessionSeries(claims.ClientID, payload.ID, payload.Reason)
+
+	if err != nil {
+		return sessionStateHTTPError(err)
+	}
+
+	return sessionsResponse(c, ssv.PrivacyUseCase, http.StatusOK, series, seriesSessions(series)...)
+}
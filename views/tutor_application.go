@@ -0,0 +1,180 @@
+package views
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	rfrl "github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// TutorApplicationPayload is the struct used to hold payload from POST /tutor-application
+	TutorApplicationPayload struct {
+		Motivation string `json:"motivation" validate:"lte=2000"`
+	}
+
+	// GetTutorApplicationsPayload is the struct used to hold payload from GET /tutor-application
+	GetTutorApplicationsPayload struct {
+		State    null.String `query:"state"`
+		ClientID null.String `query:"clientId"`
+	}
+
+	// ReviewTutorApplicationPayload is the struct used to hold payload from PUT /tutor-application/:id
+	ReviewTutorApplicationPayload struct {
+		ID    int    `path:"id"`
+		State string `json:"state" validate:"required,oneof=approved rejected"`
+		Notes string `json:"notes" validate:"lte=2000"`
+	}
+)
+
+type TutorApplicationView struct {
+	TutorApplicationUseCase rfrl.TutorApplicationUseCase
+}
+
+func tutorApplicationHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Tutor application is not found").SetInternal(err)
+	case rfrl.ErrAlreadyTutor, rfrl.ErrTutorApplicationPending, rfrl.ErrTutorApplicationReviewed:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	case rfrl.ErrTutorApplicationWrongReview:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+}
+
+func (tav *TutorApplicationView) SubmitTutorApplicationEndpoint(c echo.Context) error {
+	payload := TutorApplicationPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SubmitTutorApplicationEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "SubmitTutorApplicationEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	application, err := tav.TutorApplicationUseCase.SubmitTutorApplication(claims.ClientID, payload.Motivation)
+
+	if err != nil {
+		return tutorApplicationHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, application)
+}
+
+// GetTutorApplicationsEndpoint lists every application for admins, other clients only see their own
+func (tav *TutorApplicationView) GetTutorApplicationsEndpoint(c echo.Context) error {
+	payload := GetTutorApplicationsPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetTutorApplicationsEndpoint - Bind"))
+	}
+
+	if payload.State.Valid &&
+		payload.State.String != rfrl.TUTOR_APPLICATION_PENDING &&
+		payload.State.String != rfrl.TUTOR_APPLICATION_APPROVED &&
+		payload.State.String != rfrl.TUTOR_APPLICATION_REJECTED {
+		return echo.NewHTTPError(http.StatusBadRequest, "State is not a valid tutor application state")
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !claims.Admin {
+		payload.ClientID = null.StringFrom(claims.ClientID)
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	applications, next, err := tav.TutorApplicationUseCase.GetTutorApplications(rfrl.GetTutorApplicationsOptions{
+		State:    payload.State,
+		ClientID: payload.ClientID,
+	}, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(applications, next))
+}
+
+// GetTutorApplicationReviewEndpoint shows admins what they need to review an application
+func (tav *TutorApplicationView) GetTutorApplicationReviewEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetTutorApplicationReviewEndpoint - Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !claims.Admin {
+		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to use this view")
+	}
+
+	review, err := tav.TutorApplicationUseCase.GetTutorApplicationReview(ID)
+
+	if err != nil {
+		return tutorApplicationHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+func (tav *TutorApplicationView) ReviewTutorApplicationEndpoint(c echo.Context) error {
+	payload := ReviewTutorApplicationPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReviewTutorApplicationEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReviewTutorApplicationEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !claims.Admin {
+		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to use this view")
+	}
+
+	application, err := tav.TutorApplicationUseCase.ReviewTutorApplication(
+		payload.ID,
+		claims.ClientID,
+		payload.State,
+		payload.Notes,
+	)
+
+	if err != nil {
+		return tutorApplicationHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
@@ -1,27 +1,49 @@
 package views
 
 import (
+	"database/sql"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Arun4rangan/api-rfrl/rfrl"
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 )
 
 type (
 	TutorReviewPayload struct {
-		ID       int    `path:"id"`
-		TutorID  string `json:"tutorId" validate:"required,gte=0,lte=100"`
-		Stars    int    `json:"stars" validate:"required,numeric,gte=0,lte=10"`
-		Review   string `json:"review"`
-		Headline string `json:"headline"`
+		ID        int    `path:"id"`
+		SessionID int    `json:"sessionId" validate:"required"`
+		Stars     int    `json:"stars" validate:"required,numeric,gte=0,lte=10"`
+		Review    string `json:"review"`
+		Headline  string `json:"headline"`
+	}
+
+	// TutorReviewsAggregatePayload is the struct used to hold payload from GET /tutor-reviews-aggregate/:tutorID/period
+	TutorReviewsAggregatePayload struct {
+		Period string `query:"period"`
+		Since  string `query:"since"`
 	}
 )
 
 type TutorReviewView struct {
 	TutorReviewUseCase rfrl.TutorReviewUseCase
+	PrivacyUseCase     rfrl.PrivacyUseCase
+}
+
+func tutorReviewHTTPError(err error) *echo.HTTPError {
+	switch errors.Cause(err) {
+	case sql.ErrNoRows:
+		return echo.NewHTTPError(http.StatusNotFound, "Tutor review is not found").SetInternal(err)
+	case rfrl.ErrTutorReviewNotForClient, rfrl.ErrNotReviewedTutor:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+	case rfrl.ErrTutorReviewExists, rfrl.ErrTutorReviewAlreadyFlagged:
+		return echo.NewHTTPError(http.StatusConflict, err.Error()).SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
 }
 
 func (trv *TutorReviewView) CreateTutorReviewEndpoint(c echo.Context) error {
@@ -37,20 +59,16 @@ func (trv *TutorReviewView) CreateTutorReviewEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
-	if claims.ClientID == payload.TutorID {
-		return echo.NewHTTPError(http.StatusBadRequest, "Cannot create a review for yourself")
-	}
-
 	tutorReview, err := trv.TutorReviewUseCase.CreateTutorReview(
 		claims.ClientID,
-		payload.TutorID,
+		payload.SessionID,
 		payload.Stars,
 		payload.Review,
 		payload.Headline,
 	)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		return tutorReviewHTTPError(err)
 	}
 
 	return c.JSON(http.StatusCreated, tutorReview)
@@ -78,7 +96,7 @@ func (trv *TutorReviewView) UpdateTutorReviewEndpoint(c echo.Context) error {
 	)
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		return tutorReviewHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, tutorReview)
@@ -119,25 +137,63 @@ func (trv *TutorReviewView) GetTutorReviewEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
 
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !tutorReview.CanBeSeenBy(viewer) {
+		return echo.NewHTTPError(http.StatusNotFound, "Tutor review is not found")
+	}
+
+	err = trv.PrivacyUseCase.HideClientFields(viewer, &tutorReview.FromClient)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
 	return c.JSON(http.StatusOK, tutorReview)
 }
 
 func (trv *TutorReviewView) GetTutorReviewsEndpoint(c echo.Context) error {
 	clientID := c.Param("tutorID")
-	// offest := c.QueryParam("offset")
-	// pageLimit := c.QueryParam("page_limit")
 
 	if clientID == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Tutor ID is not passed in")
 	}
 
-	tutorReviews, err := trv.TutorReviewUseCase.GetTutorReviews(clientID)
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	tutorReviews, next, err := trv.TutorReviewUseCase.GetTutorReviews(clientID, page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	clients := make([]*rfrl.Client, len(*tutorReviews))
+
+	for i := range *tutorReviews {
+		clients[i] = &(*tutorReviews)[i].FromClient
+	}
+
+	err = trv.PrivacyUseCase.HideClientFields(viewer, clients...)
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, *tutorReviews)
+	return c.JSON(http.StatusOK, rfrl.NewPage(*tutorReviews, next))
 }
 
 func (trv *TutorReviewView) GetTutorReviewsAggregateEndpoint(c echo.Context) error {
@@ -156,6 +212,64 @@ func (trv *TutorReviewView) GetTutorReviewsAggregateEndpoint(c echo.Context) err
 	return c.JSON(http.StatusOK, *aggregateReview)
 }
 
+// GetTutorReviewsAggregateBySessionTypeEndpoint aggregates the tutor's reviews for one off and recurring sessions
+func (trv *TutorReviewView) GetTutorReviewsAggregateBySessionTypeEndpoint(c echo.Context) error {
+	clientID := c.Param("tutorID")
+
+	if clientID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Tutor ID is not passed in")
+	}
+
+	aggregates, err := trv.TutorReviewUseCase.GetTutorReviewsAggregateBySessionType(clientID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, aggregates)
+}
+
+// GetTutorReviewsAggregateByPeriodEndpoint aggregates the tutor's reviews per week or month
+func (trv *TutorReviewView) GetTutorReviewsAggregateByPeriodEndpoint(c echo.Context) error {
+	payload := TutorReviewsAggregatePayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetTutorReviewsAggregateByPeriodEndpoint - Bind"))
+	}
+
+	clientID := c.Param("tutorID")
+
+	if clientID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Tutor ID is not passed in")
+	}
+
+	if payload.Period == "" {
+		payload.Period = rfrl.REVIEW_PERIOD_MONTH
+	}
+
+	var since null.Time
+	if payload.Since != "" {
+		parsedSince, err := time.Parse(time.RFC3339, payload.Since)
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetTutorReviewsAggregateByPeriodEndpoint - time.Parse"))
+		}
+
+		since = null.NewTime(parsedSince, true)
+	}
+
+	aggregates, err := trv.TutorReviewUseCase.GetTutorReviewsAggregateByPeriod(clientID, payload.Period, since)
+
+	if err != nil {
+		if errors.Cause(err) == rfrl.ErrInvalidReviewPeriod {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, aggregates)
+}
+
 func (trv *TutorReviewView) GetPendingReviewsEndpoint(c echo.Context) error {
 	claims, err := rfrl.GetClaims(c)
 
@@ -175,14 +289,10 @@ func (trv *TutorReviewView) GetPendingReviewsEndpoint(c echo.Context) error {
 }
 
 func (trv *TutorReviewView) DeletePendingReviewEndpoint(c echo.Context) error {
-	tutorID := c.Param("tutorID")
+	sessionID, err := strconv.Atoi(c.Param("sessionID"))
 
-	if tutorID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "Tutor ID is not passed in")
-	}
-
-	if _, err := uuid.Parse(tutorID); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Tutor ID is not a valid uuid").SetInternal(errors.Wrap(err, "DeletePendingReviewEndpoint - uuid.Parse"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Session ID is not valid").SetInternal(errors.Wrap(err, "DeletePendingReviewEndpoint - strconv.Atoi"))
 	}
 
 	claims, err := rfrl.GetClaims(c)
@@ -191,7 +301,7 @@ func (trv *TutorReviewView) DeletePendingReviewEndpoint(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
-	err = trv.TutorReviewUseCase.DeletePendingReview(claims.ClientID, tutorID)
+	err = trv.TutorReviewUseCase.DeletePendingReview(claims.ClientID, sessionID)
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
@@ -0,0 +1,183 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arun4rangan/api-rfrl/rfrl"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type (
+	// FlagTutorReviewPayload is the struct used to hold payload from POST /tutor-review/:id/flag
+	FlagTutorReviewPayload struct {
+		ID     int    `path:"id"`
+		Reason string `json:"reason" validate:"required,lte=2000"`
+	}
+
+	// TutorReviewReplyPayload is the struct used to hold payload from PUT /tutor-review/:id/reply
+	TutorReviewReplyPayload struct {
+		ID    int    `path:"id"`
+		Reply string `json:"reply" validate:"required,lte=2000"`
+	}
+
+	// ModerateTutorReviewPayload is the struct used to hold payload from PUT /tutor-review-moderation/:id
+	ModerateTutorReviewPayload struct {
+		ID     int    `path:"id"`
+		Action string `json:"action" validate:"required,oneof=hide restore"`
+	}
+)
+
+func (trv *TutorReviewView) FlagTutorReviewEndpoint(c echo.Context) error {
+	payload := FlagTutorReviewPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FlagTutorReviewEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "FlagTutorReviewEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	flag, err := trv.TutorReviewUseCase.FlagTutorReview(claims.ClientID, payload.ID, payload.Reason)
+
+	if err != nil {
+		return tutorReviewHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, flag)
+}
+
+func (trv *TutorReviewView) ReplyToTutorReviewEndpoint(c echo.Context) error {
+	payload := TutorReviewReplyPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReplyToTutorReviewEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ReplyToTutorReviewEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	reply, err := trv.TutorReviewUseCase.ReplyToTutorReview(claims.ClientID, payload.ID, payload.Reply)
+
+	if err != nil {
+		return tutorReviewHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, reply)
+}
+
+func (trv *TutorReviewView) DeleteTutorReviewReplyEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "DeleteTutorReviewReplyEndpoint - strconv.Atoi"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	err = trv.TutorReviewUseCase.DeleteTutorReviewReply(claims.ClientID, ID)
+
+	if err != nil {
+		return tutorReviewHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (trv *TutorReviewView) GetTutorReviewRevisionsEndpoint(c echo.Context) error {
+	ID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "GetTutorReviewRevisionsEndpoint - strconv.Atoi"))
+	}
+
+	viewer, err := getViewer(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	revisions, err := trv.TutorReviewUseCase.GetTutorReviewRevisions(viewer, ID)
+
+	if err != nil {
+		return tutorReviewHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// GetFlaggedTutorReviewsEndpoint is the moderation queue of admins
+func (trv *TutorReviewView) GetFlaggedTutorReviewsEndpoint(c echo.Context) error {
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !claims.Admin {
+		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to use this view")
+	}
+
+	page, err := getPageOptions(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	flaggedReviews, next, err := trv.TutorReviewUseCase.GetFlaggedTutorReviews(page)
+
+	if err != nil {
+		return pageHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, rfrl.NewPage(*flaggedReviews, next))
+}
+
+func (trv *TutorReviewView) ModerateTutorReviewEndpoint(c echo.Context) error {
+	payload := ModerateTutorReviewPayload{}
+
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ModerateTutorReviewEndpoint - Bind"))
+	}
+
+	if err := c.Validate(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(errors.Wrap(err, "ModerateTutorReviewEndpoint - Validate"))
+	}
+
+	claims, err := rfrl.GetClaims(c)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if !claims.Admin {
+		return echo.NewHTTPError(http.StatusUnauthorized, "You are unauthorized to use this view")
+	}
+
+	review, err := trv.TutorReviewUseCase.ModerateTutorReview(claims.ClientID, payload.ID, payload.Action)
+
+	if err != nil {
+		return tutorReviewHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, review)
+}